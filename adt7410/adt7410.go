@@ -59,6 +59,13 @@ func (d *Device) Connected() bool {
 	return data[0]&0xF8 == 0xC8
 }
 
+// Probe returns whether an ADT7410 answers at addr.
+func Probe(bus drivers.I2C, addr uint16) bool {
+	d := New(bus)
+	d.Address = uint8(addr)
+	return d.Connected()
+}
+
 // ReadTemperature returns the temperature in celsius milli degrees (°C/1000)
 func (d *Device) ReadTemperature() (temperature int32, err error) {
 	return (int32(d.readUint16(RegTempValueMSB)) * 1000) / 128, nil
@@ -76,14 +76,35 @@ func (d *Device) Configure() {
 
 // Halt stops the sensor, values will not updated
 func (d *Device) Halt() {
-	d.powerCtl.measure = 0
-	d.bus.WriteRegister(uint8(d.Address), REG_POWER_CTL, []byte{d.powerCtl.toByte()})
+	d.setMeasure(0)
 }
 
 // Restart makes reading the sensor working again after a halt
 func (d *Device) Restart() {
-	d.powerCtl.measure = 1
-	d.bus.WriteRegister(uint8(d.Address), REG_POWER_CTL, []byte{d.powerCtl.toByte()})
+	d.setMeasure(1)
+}
+
+// Sleep puts the sensor in standby mode, where it stops measuring. It
+// implements drivers.Sleeper.
+func (d *Device) Sleep() error {
+	return d.setMeasure(0)
+}
+
+// Wake puts the sensor back in measurement mode. It implements
+// drivers.Sleeper.
+func (d *Device) Wake() error {
+	return d.setMeasure(1)
+}
+
+// Sleeping returns whether the sensor is in standby mode.
+func (d *Device) Sleeping() bool {
+	return d.powerCtl.measure == 0
+}
+
+func (d *Device) setMeasure(measure uint8) error {
+	d.powerCtl.measure = measure
+	err := d.bus.WriteRegister(uint8(d.Address), REG_POWER_CTL, []byte{d.powerCtl.toByte()})
+	return drivers.WrapError("adxl345: set power control", err)
 }
 
 // ReadAcceleration reads the current acceleration from the device and returns
@@ -106,3 +106,23 @@ func (d *Device) Celsius() float32 {
 func (d *Device) DeciCelsius() int32 {
 	return ((int32(d.temp) * 2000) / 0x100000) - 500
 }
+
+// ReadTemperature performs a measurement and returns the temperature in
+// celsius milli degrees (°C/1000).
+func (d *Device) ReadTemperature() (int32, error) {
+	err := d.Read()
+	if err != nil {
+		return 0, err
+	}
+	return int32((int64(d.temp)*200000)/0x100000) - 50000, nil
+}
+
+// ReadHumidity performs a measurement and returns the relative humidity in
+// hundredths of a percent.
+func (d *Device) ReadHumidity() (int32, error) {
+	err := d.Read()
+	if err != nil {
+		return 0, err
+	}
+	return int32((int64(d.humidity) * 10000) / 0x100000), nil
+}
@@ -48,6 +48,24 @@ func TestRead(t *testing.T) {
 	c.Assert(dev.DeciRelHumidity(), qt.Equals, int32(363))
 }
 
+func TestReadTemperatureHumidity(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewI2CBus(c)
+	fdev := tester.NewI2CDeviceCmd(c, Address)
+	fdev.Commands = defaultCommands()
+	bus.AddDevice(fdev)
+
+	dev := New(bus)
+
+	temp, err := dev.ReadTemperature()
+	c.Assert(err, qt.IsNil)
+	c.Assert(temp, qt.Equals, int32(25088))
+
+	hum, err := dev.ReadHumidity()
+	c.Assert(err, qt.IsNil)
+	c.Assert(hum, qt.Equals, int32(3635))
+}
+
 func defaultCommands() map[uint8]*tester.Cmd {
 	return map[uint8]*tester.Cmd{
 		CMD_INITIALIZE: {
@@ -1,6 +1,10 @@
 package aht20
 
-import "errors"
+import (
+	"errors"
+
+	"tinygo.org/x/drivers"
+)
 
 const (
 	Address = 0x38
@@ -16,5 +20,5 @@ const (
 
 var (
 	ErrBusy    = errors.New("device busy")
-	ErrTimeout = errors.New("timeout")
+	ErrTimeout = drivers.WrapError("aht20", drivers.ErrTimeout)
 )
@@ -5,9 +5,9 @@ package apa102 // import "tinygo.org/x/drivers/apa102"
 
 import (
 	"image/color"
-	"machine"
 
 	"tinygo.org/x/drivers"
+	"tinygo.org/x/drivers/pixel"
 )
 
 const (
@@ -21,6 +21,13 @@ const (
 	GRB
 )
 
+// orders holds the component order of each color order.
+var orders = [...]pixel.Order{
+	BGR: pixel.BGR,
+	BRG: pixel.BRG,
+	GRB: pixel.GRB,
+}
+
 var startFrame = []byte{0x00, 0x00, 0x00, 0x00}
 
 // Device wraps APA102 SPI LEDs.
@@ -28,6 +35,10 @@ type Device struct {
 	bus   drivers.SPI
 	Order int
 	buf   [4]byte
+
+	// Gamma, if non-nil, corrects the colors written by WriteColors, for
+	// example with pixel.Gamma28. The brightness is not corrected.
+	Gamma *pixel.GammaTable
 }
 
 // New returns a new APA102 driver. Pass in a fully configured SPI bus.
@@ -37,7 +48,7 @@ func New(b drivers.SPI) *Device {
 
 // NewSoftwareSPI returns a new APA102 driver that will use a software based
 // implementation of the SPI protocol.
-func NewSoftwareSPI(sckPin, sdoPin machine.Pin, delay uint32) *Device {
+func NewSoftwareSPI(sckPin, sdoPin drivers.Pin, delay uint32) *Device {
 	return New(&bbSPI{SCK: sckPin, SDO: sdoPin, Delay: delay})
 }
 
@@ -46,26 +57,22 @@ func NewSoftwareSPI(sckPin, sdoPin machine.Pin, delay uint32) *Device {
 func (d *Device) WriteColors(cs []color.RGBA) (n int, err error) {
 	d.startFrame()
 
+	order := pixel.BGR
+	if d.Order >= 0 && d.Order < len(orders) {
+		order = orders[d.Order]
+	}
+
 	// write data
 	for _, c := range cs {
+		if d.Gamma != nil {
+			c = d.Gamma.Correct(c)
+		}
+
 		// brightness is scaled to 5 bit value
 		d.buf[0] = 0xe0 | (c.A >> 3)
 
 		// set the colors
-		switch d.Order {
-		case BRG:
-			d.buf[1] = c.B
-			d.buf[2] = c.R
-			d.buf[3] = c.G
-		case GRB:
-			d.buf[1] = c.G
-			d.buf[2] = c.R
-			d.buf[3] = c.B
-		case BGR:
-			d.buf[1] = c.B
-			d.buf[2] = c.G
-			d.buf[3] = c.R
-		}
+		order.Put(d.buf[1:], c)
 		d.bus.Tx(d.buf[:], nil)
 	}
 
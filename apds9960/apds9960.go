@@ -73,6 +73,13 @@ func (d *Device) Connected() bool {
 	return data[0] == 0xAB
 }
 
+// Probe returns whether an APDS-9960 answers at addr.
+func Probe(bus drivers.I2C, addr uint16) bool {
+	d := New(bus)
+	d.Address = uint8(addr)
+	return d.Connected()
+}
+
 // GetMode returns current engine mode
 func (d *Device) GetMode() uint8 {
 	return d.mode
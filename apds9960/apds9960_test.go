@@ -0,0 +1,27 @@
+package apds9960
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"tinygo.org/x/drivers/tester"
+)
+
+func TestProximityColor(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.LoadI2CReplay(c, "testdata/proximity_color.trace")
+
+	dev := New(bus)
+	c.Assert(dev.Connected(), qt.IsTrue)
+	dev.Configure(Configuration{})
+
+	dev.EnableProximity()
+	c.Assert(dev.ProximityAvailable(), qt.IsTrue)
+	c.Assert(dev.ReadProximity(), qt.Equals, int32(55))
+
+	dev.EnableColor()
+	c.Assert(dev.ColorAvailable(), qt.IsTrue)
+	r, g, b, clear := dev.ReadColor()
+	c.Assert([]int32{r, g, b, clear}, qt.DeepEquals, []int32{154, 193, 127, 564})
+	bus.AssertDone()
+}
@@ -8,6 +8,7 @@ package axp192 // import "tinygo.org/x/drivers/axp192"
 
 import (
 	"tinygo.org/x/drivers"
+	"tinygo.org/x/drivers/regmap"
 )
 
 type Error uint8
@@ -25,19 +26,38 @@ func (e Error) Error() string {
 	}
 }
 
+// Is reports whether e matches target, so that ErrInvalidID matches
+// drivers.ErrNotConnected with errors.Is.
+func (e Error) Is(target error) bool {
+	return e == ErrInvalidID && target == drivers.ErrNotConnected
+}
+
 type Device struct {
 	bus     drivers.I2C
-	buf     []byte
+	regs    *regmap.Map
 	Address uint8
 }
 
+// Fields of the output voltage and switch registers.
+var (
+	fieldDCDC1Voltage = regmap.Field{Reg: RegDCDC1VoltageSet, Width: 7}
+	fieldDCDC2Voltage = regmap.Field{Reg: RegDCDC2VoltageSet, Width: 7}
+	fieldDCDC3Voltage = regmap.Field{Reg: RegDCDC3VoltageSet, Width: 7}
+	fieldLDO2Voltage  = regmap.Field{Reg: RegLDO23VoltageSet, Shift: 4, Width: 4}
+	fieldLDO3Voltage  = regmap.Field{Reg: RegLDO23VoltageSet, Width: 4}
+)
+
 // New returns AXP192 device for the provided I2C bus using default address.
 func New(i2c drivers.I2C) *Device {
-	return &Device{
+	d := &Device{
 		bus:     i2c,
-		buf:     make([]byte, 2),
 		Address: Address,
 	}
+	d.regs = regmap.New((*deviceBus)(d), regmap.Uint8)
+	// The voltage and switch settings only change when they are written.
+	d.regs.Cache(RegDCDC13LDO23Switch, RegDCDC2VoltageSet, RegDCDC1VoltageSet,
+		RegDCDC3VoltageSet, RegLDO23VoltageSet)
+	return d
 }
 
 type Config struct {
@@ -203,14 +223,11 @@ func (d *Device) SetDCVoltage(number uint8, voltage uint16) {
 
 	switch number {
 	case 0:
-		v := d.GetDCDC1VoltageSet()
-		d.SetDCDC1VoltageSet((v & 0x80) | (uint8(voltage) & 0x7F))
+		d.regs.Set(fieldDCDC1Voltage, voltage)
 	case 1:
-		v := d.GetDCDC2VoltageSet()
-		d.SetDCDC2VoltageSet((v & 0x80) | (uint8(voltage) & 0x7F))
+		d.regs.Set(fieldDCDC2Voltage, voltage)
 	case 2:
-		v := d.GetDCDC3VoltageSet()
-		d.SetDCDC3VoltageSet((v & 0x80) | (uint8(voltage) & 0x7F))
+		d.regs.Set(fieldDCDC3Voltage, voltage)
 	}
 }
 
@@ -224,35 +241,39 @@ func (d *Device) SetLDOVoltage(number uint8, voltage uint16) {
 
 	switch number {
 	case 2:
-		v := d.GetLDO23VoltageSet()
-		d.SetLDO23VoltageSet((v & 0x0F) | (uint8(voltage) << 4))
-		break
+		d.regs.Set(fieldLDO2Voltage, voltage)
 	case 3:
-		v := d.GetLDO23VoltageSet()
-		d.SetLDO23VoltageSet((v & 0xF0) | uint8(voltage))
-		break
+		d.regs.Set(fieldLDO3Voltage, voltage)
 	}
 }
 
 // SetLDOEnable enable LDO.
 func (d *Device) SetLDOEnable(number uint8, state bool) {
-	mark := uint8(0x01)
-	mark <<= number
 	switch number {
-	case 2:
-		v := d.GetDCDC13LDO23Switch()
-		d.SetDCDC13LDO23Switch(v | mark)
-	case 3:
-		v := d.GetDCDC13LDO23Switch()
-		d.SetDCDC13LDO23Switch(v & (^mark))
+	case 2, 3:
+		d.regs.SetBool(regmap.Bit(RegDCDC13LDO23Switch, number), state)
 	}
 }
 
 func (d *Device) write1Byte(reg, data uint8) {
-	d.bus.WriteRegister(d.Address, reg, []byte{data})
+	d.regs.Write(reg, uint16(data))
 }
 
 func (d *Device) read8bit(reg uint8) uint8 {
-	d.bus.ReadRegister(d.Address, reg, d.buf[:1])
-	return d.buf[0]
+	v, _ := d.regs.Read(reg)
+	return uint8(v)
+}
+
+// deviceBus gives the register map access to the device at its current
+// address, which may be changed after New.
+type deviceBus Device
+
+// ReadRegisters implements regmap.Bus.
+func (b *deviceBus) ReadRegisters(reg uint8, buf []byte) error {
+	return b.bus.ReadRegister(b.Address, reg, buf)
+}
+
+// WriteRegisters implements regmap.Bus.
+func (b *deviceBus) WriteRegisters(reg uint8, buf []byte) error {
+	return b.bus.WriteRegister(b.Address, reg, buf)
 }
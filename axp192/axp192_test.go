@@ -0,0 +1,49 @@
+package axp192
+
+import (
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"tinygo.org/x/drivers/tester"
+)
+
+func TestSetLDOVoltage(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewI2CBus(c)
+	fake := bus.NewDevice(Address)
+	fake.Registers[RegLDO23VoltageSet] = 0xcc
+	var trace strings.Builder
+	dev := New(tester.NewI2CRecorder(bus, &trace))
+
+	dev.SetLDOVoltage(2, 3300)
+	dev.SetLDOVoltage(3, 2000)
+	c.Assert(fake.Registers[RegLDO23VoltageSet], qt.Equals, uint8(0xf2))
+	// The register is only read once.
+	c.Assert(strings.Count(trace.String(), "read"), qt.Equals, 1)
+}
+
+func TestSetDCVoltage(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewI2CBus(c)
+	fake := bus.NewDevice(Address)
+	fake.Registers[RegDCDC1VoltageSet] = 0x80
+	dev := New(bus)
+
+	dev.SetDCVoltage(0, 3350)
+	c.Assert(fake.Registers[RegDCDC1VoltageSet], qt.Equals, uint8(0x80|106))
+}
+
+func TestSetLDOEnable(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewI2CBus(c)
+	fake := bus.NewDevice(Address)
+	fake.Registers[RegDCDC13LDO23Switch] = 0x01
+	dev := New(bus)
+
+	dev.SetLDOEnable(2, true)
+	dev.SetLDOEnable(3, true)
+	c.Assert(fake.Registers[RegDCDC13LDO23Switch], qt.Equals, uint8(0x0d))
+	dev.SetLDOEnable(2, false)
+	c.Assert(fake.Registers[RegDCDC13LDO23Switch], qt.Equals, uint8(0x09))
+}
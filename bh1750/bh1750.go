@@ -16,9 +16,10 @@ type SamplingMode byte
 
 // Device wraps an I2C connection to a bh1750 device.
 type Device struct {
-	bus     drivers.I2C
-	Address uint16
-	mode    SamplingMode
+	bus      drivers.I2C
+	Address  uint16
+	mode     SamplingMode
+	sleeping bool
 }
 
 // New creates a new bh1750 connection. The I2C bus must already be
@@ -70,3 +71,30 @@ func (d *Device) SetMode(mode SamplingMode) {
 	d.bus.Tx(d.Address, []byte{byte(d.mode)}, nil)
 	time.Sleep(10 * time.Millisecond)
 }
+
+// Sleep powers the sensor down. It implements drivers.Sleeper.
+func (d *Device) Sleep() error {
+	err := d.bus.Tx(d.Address, []byte{POWER_DOWN}, nil)
+	if err != nil {
+		return drivers.WrapError("bh1750: power down", err)
+	}
+	d.sleeping = true
+	return nil
+}
+
+// Wake powers the sensor on and restores its sampling mode. It implements
+// drivers.Sleeper.
+func (d *Device) Wake() error {
+	err := d.bus.Tx(d.Address, []byte{POWER_ON}, nil)
+	if err != nil {
+		return drivers.WrapError("bh1750: power on", err)
+	}
+	d.sleeping = false
+	d.SetMode(d.mode)
+	return nil
+}
+
+// Sleeping returns whether the sensor has been powered down.
+func (d *Device) Sleeping() bool {
+	return d.sleeping
+}
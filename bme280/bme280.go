@@ -108,6 +108,13 @@ func (d *Device) Connected() bool {
 	return data[0] == CHIP_ID
 }
 
+// Probe returns whether a BME280, rather than a BMP280, answers at addr.
+func Probe(bus drivers.I2C, addr uint16) bool {
+	d := New(bus)
+	d.Address = addr
+	return d.Connected()
+}
+
 // Reset the device
 func (d *Device) Reset() {
 	d.bus.WriteRegister(uint8(d.Address), CMD_RESET, []byte{0xB6})
@@ -151,7 +158,10 @@ func (d *Device) ReadHumidity() (int32, error) {
 // Calculation is based on code from Adafruit BME280 library
 // 	https://github.com/adafruit/Adafruit_BME280_Library
 func (d *Device) ReadAltitude() (alt int32, err error) {
-	mPa, _ := d.ReadPressure()
+	mPa, err := d.ReadPressure()
+	if err != nil {
+		return 0, err
+	}
 	atmP := float32(mPa) / 100000
 	alt = int32(44330.0 * (1.0 - math.Pow(float64(atmP/SEALEVEL_PRESSURE), 0.1903)))
 	return
@@ -187,10 +197,6 @@ func readIntLE(msb byte, lsb byte) int16 {
 // resulting in an slice with 8 bytes 0-2 = pressure / 3-5 = temperature / 6-7 = humidity
 func (d *Device) readData() (data [8]byte, err error) {
 	err = d.bus.ReadRegister(uint8(d.Address), REG_PRESSURE, data[:])
-	if err != nil {
-		println(err)
-		return
-	}
 	return
 }
 
@@ -0,0 +1,42 @@
+package bme280
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"tinygo.org/x/drivers/tester"
+)
+
+func TestConnected(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewI2CBus(c)
+	fake := bus.NewDevice(Address)
+	fake.Registers[WHO_AM_I] = CHIP_ID
+
+	dev := New(bus)
+	c.Assert(dev.Connected(), qt.IsTrue)
+
+	bus.NACK(Address)
+	c.Assert(dev.Connected(), qt.IsFalse)
+}
+
+func TestReadFaults(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewI2CBus(c)
+	fake := bus.NewDevice(Address)
+
+	dev := New(bus)
+	fake.Faults.FailAfter = 4
+	_, err := dev.ReadTemperature()
+	c.Assert(err, qt.Equals, tester.ErrBusFault)
+	_, err = dev.ReadPressure()
+	c.Assert(err, qt.Equals, tester.ErrBusFault)
+	_, err = dev.ReadHumidity()
+	c.Assert(err, qt.Equals, tester.ErrBusFault)
+	_, err = dev.ReadAltitude()
+	c.Assert(err, qt.Equals, tester.ErrBusFault)
+
+	bus.NACK(Address)
+	_, err = dev.ReadTemperature()
+	c.Assert(err, qt.Equals, tester.ErrNACK)
+}
@@ -1,28 +1,29 @@
 package bmi160
 
 import (
-	"machine"
 	"time"
 
 	"tinygo.org/x/drivers"
+	"tinygo.org/x/drivers/regmap"
 )
 
 // DeviceSPI is the SPI interface to a BMI160 accelerometer/gyroscope. There is
 // also an I2C interface, but it is not yet supported.
 type DeviceSPI struct {
 	// Chip select pin
-	CSB machine.Pin
-
-	buf [7]byte
+	CSB drivers.Pin
 
 	// SPI bus (requires chip select to be usable).
 	Bus drivers.SPI
+
+	spi  regmap.SPI
+	regs *regmap.Map
 }
 
 // NewSPI returns a new device driver. The pin and SPI interface are not
 // touched, provide a fully configured SPI object and call Configure to start
 // using this device.
-func NewSPI(csb machine.Pin, spi drivers.SPI) *DeviceSPI {
+func NewSPI(csb drivers.Pin, spi drivers.SPI) *DeviceSPI {
 	return &DeviceSPI{
 		CSB: csb, // chip select
 		Bus: spi,
@@ -33,7 +34,7 @@ func NewSPI(csb machine.Pin, spi drivers.SPI) *DeviceSPI {
 // configures the BMI160, but it does not configure the SPI interface (it is
 // assumed to be up and running).
 func (d *DeviceSPI) Configure() error {
-	d.CSB.Configure(machine.PinConfig{Mode: machine.PinOutput})
+	drivers.ConfigurePin(d.CSB, drivers.PinOutput)
 	d.CSB.High()
 
 	// The datasheet recommends doing a register read from address 0x7F to get
@@ -82,17 +83,12 @@ func (d *DeviceSPI) Reset() error {
 
 // ReadTemperature returns the temperature in celsius milli degrees (°C/1000).
 func (d *DeviceSPI) ReadTemperature() (temperature int32, err error) {
-	data := d.buf[:3]
-	data[0] = 0x80 | reg_TEMPERATURE_0
-	data[1] = 0
-	data[2] = 0
-	d.CSB.Low()
-	err = d.Bus.Tx(data, data)
-	d.CSB.High()
+	var data [2]uint16
+	err = d.registers().ReadBlock(reg_TEMPERATURE_0, data[:])
 	if err != nil {
 		return
 	}
-	rawTemperature := int16(uint16(data[1]) | uint16(data[2])<<8)
+	rawTemperature := int16(data[0] | data[1]<<8)
 	// 0x0000 is 23°C
 	// 0x7fff is ~87°C
 	// We use 0x8000 instead of 0x7fff to make the formula easier. The result
@@ -118,14 +114,8 @@ func (d *DeviceSPI) ReadTemperature() (temperature int32, err error) {
 // and the sensor is not moving the returned value will be around 1000000 or
 // -1000000.
 func (d *DeviceSPI) ReadAcceleration() (x int32, y int32, z int32, err error) {
-	data := d.buf[:7]
-	data[0] = 0x80 | reg_ACC_XL
-	for i := 1; i < len(data); i++ {
-		data[i] = 0
-	}
-	d.CSB.Low()
-	err = d.Bus.Tx(data, data)
-	d.CSB.High()
+	var data [6]uint16
+	err = d.registers().ReadBlock(reg_ACC_XL, data[:])
 	if err != nil {
 		return
 	}
@@ -137,9 +127,9 @@ func (d *DeviceSPI) ReadAcceleration() (x int32, y int32, z int32, err error) {
 	//    overflow we do it at 1/64 of the value:
 	//      1000000 / 64 = 15625
 	//      16384   / 64 = 256
-	x = int32(int16(uint16(data[1])|uint16(data[2])<<8)) * 15625 / 256
-	y = int32(int16(uint16(data[3])|uint16(data[4])<<8)) * 15625 / 256
-	z = int32(int16(uint16(data[5])|uint16(data[6])<<8)) * 15625 / 256
+	x = int32(int16(data[0]|data[1]<<8)) * 15625 / 256
+	y = int32(int16(data[2]|data[3]<<8)) * 15625 / 256
+	z = int32(int16(data[4]|data[5]<<8)) * 15625 / 256
 	return
 }
 
@@ -148,14 +138,8 @@ func (d *DeviceSPI) ReadAcceleration() (x int32, y int32, z int32, err error) {
 // rotation along one axis and while doing so integrate all values over time,
 // you would get a value close to 360000000.
 func (d *DeviceSPI) ReadRotation() (x int32, y int32, z int32, err error) {
-	data := d.buf[:7]
-	data[0] = 0x80 | reg_GYR_XL
-	for i := 1; i < len(data); i++ {
-		data[i] = 0
-	}
-	d.CSB.Low()
-	err = d.Bus.Tx(data, data)
-	d.CSB.High()
+	var data [6]uint16
+	err = d.registers().ReadBlock(reg_GYR_XL, data[:])
 	if err != nil {
 		return
 	}
@@ -171,15 +155,24 @@ func (d *DeviceSPI) ReadRotation() (x int32, y int32, z int32, err error) {
 	// 3. Simplify.
 	//    rawX * 2e9 / 32768
 	//    rawX * 1953125 / 32
-	rawX := int32(int16(uint16(data[1]) | uint16(data[2])<<8))
-	rawY := int32(int16(uint16(data[3]) | uint16(data[4])<<8))
-	rawZ := int32(int16(uint16(data[5]) | uint16(data[6])<<8))
+	rawX := int32(int16(data[0] | data[1]<<8))
+	rawY := int32(int16(data[2] | data[3]<<8))
+	rawZ := int32(int16(data[4] | data[5]<<8))
 	x = int32(int64(rawX) * 1953125 / 32)
 	y = int32(int64(rawY) * 1953125 / 32)
 	z = int32(int64(rawZ) * 1953125 / 32)
 	return
 }
 
+// registers returns the register map of the device, created on first use
+// so that a DeviceSPI can also be declared with its fields.
+func (d *DeviceSPI) registers() *regmap.Map {
+	if d.regs == nil {
+		d.regs = regmap.New((*deviceBus)(d), regmap.Uint8)
+	}
+	return d.regs
+}
+
 // runCommand runs a BMI160 command through the CMD register. It waits for the
 // command to complete before returning.
 func (d *DeviceSPI) runCommand(command uint8) {
@@ -198,13 +191,8 @@ func (d *DeviceSPI) readRegister(address uint8) uint8 {
 	// I don't know why but it appears necessary to sleep for a bit here.
 	time.Sleep(time.Millisecond)
 
-	data := d.buf[:2]
-	data[0] = 0x80 | address
-	data[1] = 0
-	d.CSB.Low()
-	d.Bus.Tx(data, data)
-	d.CSB.High()
-	return data[1]
+	v, _ := d.registers().Read(address)
+	return uint8(v)
 }
 
 // writeRegister writes a single byte BMI160 register. It should only be used
@@ -213,11 +201,26 @@ func (d *DeviceSPI) writeRegister(address, data uint8) {
 	// I don't know why but it appears necessary to sleep for a bit here.
 	time.Sleep(time.Millisecond)
 
-	buf := d.buf[:2]
-	buf[0] = address
-	buf[1] = data
+	d.registers().Write(address, uint16(data))
+}
 
-	d.CSB.Low()
-	d.Bus.Tx(buf, buf)
-	d.CSB.High()
+// deviceBus gives the register map access to the device through its current
+// chip select pin and bus, which may be changed after NewSPI.
+type deviceBus DeviceSPI
+
+// ReadRegisters implements regmap.Bus.
+func (b *deviceBus) ReadRegisters(reg uint8, buf []byte) error {
+	b.setup()
+	return b.spi.ReadRegisters(reg, buf)
+}
+
+// WriteRegisters implements regmap.Bus.
+func (b *deviceBus) WriteRegisters(reg uint8, buf []byte) error {
+	b.setup()
+	return b.spi.WriteRegisters(reg, buf)
+}
+
+func (b *deviceBus) setup() {
+	// The register address has its top bit set for reads.
+	b.spi.Bus, b.spi.CS, b.spi.ReadFlag = b.Bus, b.CSB, 0x80
 }
@@ -0,0 +1,66 @@
+package bmi160
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"tinygo.org/x/drivers/tester"
+)
+
+func TestConnected(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewSPIBus(c)
+	dev := NewSPI(tester.NewPin(), bus)
+
+	bus.Expect([]byte{0x80 | reg_CHIPID}, nil)
+	bus.Expect(nil, []byte{0xd1})
+	c.Assert(dev.Connected(), qt.IsTrue)
+	bus.AssertDone()
+}
+
+func TestDeclared(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewSPIBus(c)
+	dev := &DeviceSPI{CSB: tester.NewPin(), Bus: bus}
+
+	bus.Expect([]byte{0x80 | reg_CHIPID}, nil)
+	bus.Expect(nil, []byte{0xd1})
+	c.Assert(dev.Connected(), qt.IsTrue)
+
+	// The register map follows a change of bus.
+	other := tester.NewSPIBus(c)
+	dev.Bus = other
+	other.Expect([]byte{0x80 | reg_CHIPID}, nil)
+	other.Expect(nil, []byte{0xd1})
+	c.Assert(dev.Connected(), qt.IsTrue)
+	bus.AssertDone()
+	other.AssertDone()
+}
+
+func TestReadAcceleration(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewSPIBus(c)
+	csb := tester.NewPin()
+	dev := NewSPI(csb, bus)
+
+	// 1g on the Z axis, -0.5g on the X axis.
+	bus.Expect([]byte{0x80 | reg_ACC_XL}, nil)
+	bus.Expect(nil, []byte{0x00, 0xe0, 0x00, 0x00, 0x00, 0x40})
+	x, y, z, err := dev.ReadAcceleration()
+	c.Assert(err, qt.IsNil)
+	c.Assert([]int32{x, y, z}, qt.DeepEquals, []int32{-500000, 0, 1000000})
+	c.Assert(csb.Levels(), qt.DeepEquals, []bool{false, true})
+	bus.AssertDone()
+}
+
+func TestReadTemperature(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewSPIBus(c)
+	dev := NewSPI(tester.NewPin(), bus)
+
+	bus.Expect([]byte{0x80 | reg_TEMPERATURE_0}, nil)
+	bus.Expect(nil, []byte{0x00, 0x02})
+	temp, err := dev.ReadTemperature()
+	c.Assert(err, qt.IsNil)
+	c.Assert(temp, qt.Equals, int32(24000))
+}
@@ -59,6 +59,13 @@ func (d *Device) Connected() bool {
 	return data[0] == CHIP_ID
 }
 
+// Probe returns whether a BMP180 answers at addr.
+func Probe(bus drivers.I2C, addr uint16) bool {
+	d := New(bus)
+	d.Address = addr
+	return d.Connected()
+}
+
 // Configure sets up the device for communication and
 // read the calibration coefficients.
 func (d *Device) Configure() {
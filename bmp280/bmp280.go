@@ -68,6 +68,13 @@ func (d *Device) Connected() bool {
 	return data[0] == CHIP_ID
 }
 
+// Probe returns whether a BMP280, rather than a BME280, answers at addr.
+func Probe(bus drivers.I2C, addr uint16) bool {
+	d := New(bus)
+	d.Address = addr
+	return d.Connected()
+}
+
 // Reset preforms complete power-on-reset procedure.
 // It is required to call Configure afterwards.
 func (d *Device) Reset() {
@@ -1,17 +1,12 @@
 package bmp388
 
 import (
-	"errors"
-
 	"tinygo.org/x/drivers"
 )
 
 var (
-	errConfigWrite  = errors.New("bmp388: failed to configure sensor, check connection")
-	errConfig       = errors.New("bmp388: there is a problem with the configuration, try reducing ODR")
-	errCaliRead     = errors.New("bmp388: failed to read calibration coefficient register")
-	errSoftReset    = errors.New("bmp388: failed to perform a soft reset")
-	errNotConnected = errors.New("bmp388: not connected")
+	errConfig       = drivers.WrapError("bmp388: there is a problem with the configuration, try reducing ODR", drivers.ErrInvalidConfig)
+	errNotConnected = drivers.WrapError("bmp388", drivers.ErrNotConnected)
 )
 
 type Oversampling byte
@@ -30,10 +25,11 @@ type Config struct {
 
 // Device wraps the I2C connection and configuration values for the BMP388
 type Device struct {
-	bus     drivers.I2C
-	Address uint8
-	cali    calibrationCoefficients
-	Config  Config
+	bus      drivers.I2C
+	Address  uint8
+	cali     calibrationCoefficients
+	Config   Config
+	wakeMode Mode
 }
 
 type calibrationCoefficients struct {
@@ -72,16 +68,18 @@ func (d *Device) Configure(config Config) (err error) {
 		d.Config.Mode = Normal
 	}
 
-	// Turning on the pressure and temperature sensors and setting the measurement mode
-	err = d.writeRegister(RegPwrCtrl, PwrPress|PwrTemp|byte(d.Config.Mode))
-
-	// Configure the oversampling, output data rate, and iir filter coefficient settings
-	err = d.writeRegister(RegOSR, byte(d.Config.Pressure|d.Config.Temperature<<3))
-	err = d.writeRegister(RegODR, byte(d.Config.ODR))
-	err = d.writeRegister(RegIIR, byte(d.Config.IIR<<1))
-
-	if err != nil {
-		return errConfigWrite
+	// Turning on the pressure and temperature sensors and setting the measurement mode, then configure the
+	// oversampling, output data rate, and iir filter coefficient settings
+	writes := [...]struct{ reg, value byte }{
+		{RegPwrCtrl, PwrPress | PwrTemp | byte(d.Config.Mode)},
+		{RegOSR, byte(d.Config.Pressure | d.Config.Temperature<<3)},
+		{RegODR, byte(d.Config.ODR)},
+		{RegIIR, byte(d.Config.IIR << 1)},
+	}
+	for _, w := range writes {
+		if err = d.writeRegister(w.reg, w.value); err != nil {
+			return drivers.WrapError("bmp388: failed to configure sensor", err)
+		}
 	}
 
 	// Check if there is a problem with the given configuration
@@ -93,7 +91,7 @@ func (d *Device) Configure(config Config) (err error) {
 	// in the datasheet is implemented in floating point
 	buffer, err := d.readRegister(RegCali, 21)
 	if err != nil {
-		return errCaliRead
+		return drivers.WrapError("bmp388: failed to read calibration coefficient register", err)
 	}
 
 	d.cali.t1 = uint16(buffer[1])<<8 | uint16(buffer[0])
@@ -133,7 +131,7 @@ func (d *Device) tlinCompensate() (int64, error) {
 
 }
 
-// ReadTemperature returns the temperature in centicelsius, i.e 2426 / 100 = 24.26 C
+// ReadTemperature returns the temperature in celsius milli degrees (°C/1000).
 func (d *Device) ReadTemperature() (int32, error) {
 
 	tlin, err := d.tlinCompensate()
@@ -141,11 +139,11 @@ func (d *Device) ReadTemperature() (int32, error) {
 		return 0, err
 	}
 
-	temp := (tlin * 25) / 16384
+	temp := (tlin * 250) / 16384
 	return int32(temp), nil
 }
 
-// ReadPressure returns the pressure in centipascals, i.e 10132520 / 100 = 101325.20 Pa
+// ReadPressure returns the pressure in milli pascals (mPa).
 func (d *Device) ReadPressure() (int32, error) {
 
 	tlin, err := d.tlinCompensate()
@@ -183,16 +181,13 @@ func (d *Device) ReadPressure() (int32, error) {
 	partialData3 = (partialData2 * rawPress) / 128
 	partialData4 = (offset / 4) + partialData1 + partialData5 + partialData3
 	compPress := ((uint64(partialData4) * 25) / uint64(1099511627776))
-	return int32(compPress), nil
+	return int32(compPress) * 10, nil
 }
 
 // SoftReset commands the BMP388 to reset of all user configuration settings
 func (d *Device) SoftReset() error {
 	err := d.writeRegister(RegCmd, SoftReset)
-	if err != nil {
-		return errSoftReset
-	}
-	return nil
+	return drivers.WrapError("bmp388: failed to perform a soft reset", err)
 }
 
 // Connected tries to reach the bmp388 and check its chip id register. Returns true if it was able to successfully
@@ -202,6 +197,13 @@ func (d *Device) Connected() bool {
 	return err == nil && data[0] == ChipId // returns true if i2c comm was good and response equals 0x50
 }
 
+// Probe returns whether a BMP388 answers at addr.
+func Probe(bus drivers.I2C, addr uint16) bool {
+	d := New(bus)
+	d.Address = uint8(addr)
+	return d.Connected()
+}
+
 // SetMode changes the run mode of the sensor, NORMAL is the one to use for most cases. Use FORCED if you plan to take
 // measurements infrequently and want to conserve power. SLEEP will of course put the sensor to sleep
 func (d *Device) SetMode(mode Mode) error {
@@ -209,6 +211,29 @@ func (d *Device) SetMode(mode Mode) error {
 	return d.writeRegister(RegPwrCtrl, PwrPress|PwrTemp|byte(d.Config.Mode))
 }
 
+// Sleep puts the sensor in SLEEP mode. It implements drivers.Sleeper.
+func (d *Device) Sleep() error {
+	if d.Config.Mode != Sleep {
+		d.wakeMode = d.Config.Mode
+	}
+	return d.SetMode(Sleep)
+}
+
+// Wake puts the sensor back in the mode it was in before Sleep, or in NORMAL mode if it was never awake. It
+// implements drivers.Sleeper.
+func (d *Device) Wake() error {
+	mode := d.wakeMode
+	if mode == Sleep {
+		mode = Normal
+	}
+	return d.SetMode(mode)
+}
+
+// Sleeping returns whether the sensor is in SLEEP mode.
+func (d *Device) Sleeping() bool {
+	return d.Config.Mode == Sleep
+}
+
 func (d *Device) readSensorData(register byte) (data int64, err error) {
 
 	if !d.Connected() {
@@ -0,0 +1,47 @@
+package bmp388
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"tinygo.org/x/drivers"
+	"tinygo.org/x/drivers/tester"
+)
+
+var _ drivers.Sleeper = (*Device)(nil)
+
+func TestSleepWake(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewI2CBus(c)
+	fake := tester.NewI2CDevice(c, Address)
+	bus.AddDevice(fake)
+	dev := New(bus)
+
+	for _, mode := range []Mode{Normal, Forced} {
+		c.Assert(dev.Configure(Config{Mode: mode, Pressure: Sampling2X}), qt.IsNil)
+		c.Assert(fake.Registers[RegPwrCtrl], qt.Equals, PwrPress|PwrTemp|byte(mode))
+
+		c.Assert(dev.Sleep(), qt.IsNil)
+		c.Assert(dev.Sleeping(), qt.IsTrue)
+		c.Assert(fake.Registers[RegPwrCtrl], qt.Equals, PwrPress|PwrTemp|byte(Sleep))
+
+		// Sleeping again does not forget the mode to restore.
+		c.Assert(dev.Sleep(), qt.IsNil)
+		c.Assert(dev.Wake(), qt.IsNil)
+		c.Assert(dev.Sleeping(), qt.IsFalse)
+		c.Assert(dev.Config.Mode, qt.Equals, mode)
+		c.Assert(fake.Registers[RegPwrCtrl], qt.Equals, PwrPress|PwrTemp|byte(mode))
+	}
+}
+
+func TestWakeNeverAwake(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewI2CBus(c)
+	fake := tester.NewI2CDevice(c, Address)
+	bus.AddDevice(fake)
+	dev := New(bus)
+
+	c.Assert(dev.Configure(Config{Mode: Sleep, Pressure: Sampling2X}), qt.IsNil)
+	c.Assert(dev.Wake(), qt.IsNil)
+	c.Assert(dev.Config.Mode, qt.Equals, Normal)
+}
@@ -0,0 +1,136 @@
+package busstats
+
+import (
+	"time"
+
+	"tinygo.org/x/drivers"
+)
+
+// I2C wraps an I2C bus and collects statistics for each device address.
+type I2C struct {
+	bus   drivers.I2C
+	stats map[uint16]*Stats
+
+	// Sink, if non-nil, receives every transaction.
+	Sink Sink
+}
+
+// NewI2C returns a wrapper around bus.
+func NewI2C(bus drivers.I2C) *I2C {
+	return &I2C{
+		bus:   bus,
+		stats: make(map[uint16]*Stats),
+	}
+}
+
+// Stats returns the statistics of the device at the given address.
+func (b *I2C) Stats(addr uint16) Stats {
+	if s := b.stats[addr]; s != nil {
+		return *s
+	}
+	return Stats{}
+}
+
+// Addresses returns the addresses that have been accessed, in no particular
+// order.
+func (b *I2C) Addresses() []uint16 {
+	addrs := make([]uint16, 0, len(b.stats))
+	for addr := range b.stats {
+		addrs = append(addrs, addr)
+	}
+	return addrs
+}
+
+// Reset clears the statistics.
+func (b *I2C) Reset() {
+	b.stats = make(map[uint16]*Stats)
+}
+
+// ReadRegister implements drivers.I2C.
+func (b *I2C) ReadRegister(addr uint8, r uint8, buf []byte) error {
+	start := time.Now()
+	err := b.bus.ReadRegister(addr, r, buf)
+	b.record(Event{Op: OpI2CRead, Addr: uint16(addr), Reg: r, Written: 1, Read: len(buf), Err: err}, start)
+	return err
+}
+
+// WriteRegister implements drivers.I2C.
+func (b *I2C) WriteRegister(addr uint8, r uint8, buf []byte) error {
+	start := time.Now()
+	err := b.bus.WriteRegister(addr, r, buf)
+	b.record(Event{Op: OpI2CWrite, Addr: uint16(addr), Reg: r, Written: 1 + len(buf), Err: err}, start)
+	return err
+}
+
+// Tx implements drivers.I2C.
+func (b *I2C) Tx(addr uint16, w, r []byte) error {
+	start := time.Now()
+	err := b.bus.Tx(addr, w, r)
+	b.record(Event{Op: OpI2CTx, Addr: addr, Written: len(w), Read: len(r), Err: err}, start)
+	return err
+}
+
+func (b *I2C) record(e Event, start time.Time) {
+	e.Duration = time.Since(start)
+	s := b.stats[e.Addr]
+	if s == nil {
+		s = &Stats{}
+		b.stats[e.Addr] = s
+	}
+	s.add(&e)
+	if b.Sink != nil {
+		b.Sink.Trace(e)
+	}
+}
+
+// SPI wraps a SPI bus and collects statistics for all its transactions.
+// SPI devices have no address: to get statistics per device, wrap the bus
+// of each device, for example each shared.SPIDevice.
+type SPI struct {
+	bus   drivers.SPI
+	stats Stats
+
+	// Sink, if non-nil, receives every transaction.
+	Sink Sink
+}
+
+// NewSPI returns a wrapper around bus.
+func NewSPI(bus drivers.SPI) *SPI {
+	return &SPI{
+		bus: bus,
+	}
+}
+
+// Stats returns the statistics of the bus.
+func (b *SPI) Stats() Stats {
+	return b.stats
+}
+
+// Reset clears the statistics.
+func (b *SPI) Reset() {
+	b.stats = Stats{}
+}
+
+// Tx implements drivers.SPI.
+func (b *SPI) Tx(w, r []byte) error {
+	start := time.Now()
+	err := b.bus.Tx(w, r)
+	b.record(Event{Op: OpSPITx, Written: len(w), Read: len(r), Err: err}, start)
+	return err
+}
+
+// Transfer implements drivers.SPI.
+func (b *SPI) Transfer(w byte) (byte, error) {
+	start := time.Now()
+	r, err := b.bus.Transfer(w)
+	b.record(Event{Op: OpSPITransfer, Written: 1, Read: 1, Err: err}, start)
+	return r, err
+}
+
+func (b *SPI) record(e Event, start time.Time) {
+	e.Duration = time.Since(start)
+	b.stats.add(&e)
+	if b.Sink != nil {
+		b.Sink.Trace(e)
+	}
+}
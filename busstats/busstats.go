@@ -0,0 +1,152 @@
+// Package busstats provides I2C and SPI bus wrappers that collect statistics
+// about the transactions made by drivers, and can trace them.
+//
+// The wrappers implement drivers.I2C and drivers.SPI, so they can be passed
+// to any driver constructor:
+//
+//	bus := busstats.NewI2C(machine.I2C0)
+//	bus.Sink = busstats.NewRing(32)
+//	sensor := bme280.New(bus)
+//	...
+//	stats := bus.Stats(bme280.Address)
+//	println(stats.Transactions, stats.Errors)
+//
+// The wrappers are not safe for concurrent use. To share a bus between
+// goroutines, wrap it with busstats first and then with the shared package.
+package busstats // import "tinygo.org/x/drivers/busstats"
+
+import (
+	"strconv"
+	"time"
+)
+
+// Stats holds the statistics of the transactions to one device.
+type Stats struct {
+	Transactions uint32
+	Errors       uint32
+	BytesWritten uint32
+	BytesRead    uint32
+	Latency      Histogram
+}
+
+// add records a transaction in the statistics.
+func (s *Stats) add(e *Event) {
+	s.Transactions++
+	if e.Err != nil {
+		s.Errors++
+	}
+	s.BytesWritten += uint32(e.Written)
+	s.BytesRead += uint32(e.Read)
+	s.Latency.Add(e.Duration)
+}
+
+// Histogram counts durations in buckets of increasing size: bucket 0 counts
+// durations under 1µs, and bucket i the durations from 2^(i-1)µs to 2^iµs.
+// The last bucket counts all longer durations.
+type Histogram [16]uint32
+
+// Add counts a duration in its bucket.
+func (h *Histogram) Add(d time.Duration) {
+	us := d / time.Microsecond
+	i := 0
+	for us > 0 && i < len(h)-1 {
+		us >>= 1
+		i++
+	}
+	h[i]++
+}
+
+// Count returns the number of durations in the histogram.
+func (h *Histogram) Count() uint32 {
+	var n uint32
+	for _, c := range h {
+		n += c
+	}
+	return n
+}
+
+// Limit returns the upper limit of bucket i. It returns a negative duration
+// for the last bucket, which has no limit.
+func (h *Histogram) Limit(i int) time.Duration {
+	if i >= len(h)-1 {
+		return -1
+	}
+	return time.Microsecond << uint(i)
+}
+
+// Percentile returns the upper limit of the bucket that holds the given
+// percentile of the durations, or a negative duration if it is the last
+// bucket. It returns 0 for an empty histogram.
+func (h *Histogram) Percentile(p int) time.Duration {
+	total := h.Count()
+	if total == 0 {
+		return 0
+	}
+	target := (uint64(total)*uint64(p) + 99) / 100
+	var n uint64
+	for i, c := range h {
+		n += uint64(c)
+		if n >= target {
+			return h.Limit(i)
+		}
+	}
+	return -1
+}
+
+// Op is the kind of a transaction.
+type Op uint8
+
+const (
+	OpI2CRead Op = iota
+	OpI2CWrite
+	OpI2CTx
+	OpSPITx
+	OpSPITransfer
+)
+
+var opNames = [...]string{"i2c read", "i2c write", "i2c tx", "spi tx", "spi transfer"}
+
+// String returns the name of the operation.
+func (op Op) String() string {
+	if int(op) < len(opNames) {
+		return opNames[op]
+	}
+	return "unknown"
+}
+
+// Event is a transaction seen by a wrapper.
+type Event struct {
+	Op Op
+	// Addr is the address of an I2C device.
+	Addr uint16
+	// Reg is the register of an I2C read or write.
+	Reg      uint8
+	Written  int
+	Read     int
+	Duration time.Duration
+	Err      error
+}
+
+// String returns a single line describing the event.
+func (e Event) String() string {
+	b := []byte(e.Op.String())
+	if e.Op <= OpI2CTx {
+		b = append(b, " 0x"...)
+		b = strconv.AppendUint(b, uint64(e.Addr), 16)
+	}
+	if e.Op == OpI2CRead || e.Op == OpI2CWrite {
+		b = append(b, " reg=0x"...)
+		b = strconv.AppendUint(b, uint64(e.Reg), 16)
+	}
+	b = append(b, " w="...)
+	b = strconv.AppendInt(b, int64(e.Written), 10)
+	b = append(b, " r="...)
+	b = strconv.AppendInt(b, int64(e.Read), 10)
+	b = append(b, ' ')
+	b = append(b, e.Duration.String()...)
+	if e.Err != nil {
+		b = append(b, " err="...)
+		b = append(b, e.Err.Error()...)
+	}
+	return string(b)
+}
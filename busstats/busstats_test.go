@@ -0,0 +1,108 @@
+package busstats
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"tinygo.org/x/drivers/tester"
+)
+
+func TestI2C(t *testing.T) {
+	c := qt.New(t)
+	mock := tester.NewI2CBus(c)
+	mock.NewDevice(0x76)
+	mock.NewDevice(0x68).Err = errors.New("arbitration lost")
+	bus := NewI2C(mock)
+	ring := NewRing(8)
+	bus.Sink = ring
+
+	c.Assert(bus.ReadRegister(0x76, 0xf7, make([]byte, 8)), qt.IsNil)
+	c.Assert(bus.WriteRegister(0x76, 0xf4, []byte{0x27}), qt.IsNil)
+	c.Assert(bus.ReadRegister(0x68, 0x75, make([]byte, 1)), qt.ErrorMatches, "arbitration lost")
+
+	s := bus.Stats(0x76)
+	c.Assert(s.Transactions, qt.Equals, uint32(2))
+	c.Assert(s.Errors, qt.Equals, uint32(0))
+	c.Assert(s.BytesWritten, qt.Equals, uint32(3))
+	c.Assert(s.BytesRead, qt.Equals, uint32(8))
+	c.Assert(s.Latency.Count(), qt.Equals, uint32(2))
+	c.Assert(bus.Stats(0x68).Errors, qt.Equals, uint32(1))
+	c.Assert(bus.Addresses(), qt.HasLen, 2)
+
+	events := ring.Events()
+	c.Assert(events, qt.HasLen, 3)
+	c.Assert(events[0].Op, qt.Equals, OpI2CRead)
+	c.Assert(events[2].Err, qt.ErrorMatches, "arbitration lost")
+
+	bus.Reset()
+	c.Assert(bus.Stats(0x76), qt.DeepEquals, Stats{})
+}
+
+func TestSPI(t *testing.T) {
+	c := qt.New(t)
+	mock := tester.NewSPIBus(c)
+	mock.Expect([]byte{0x9f, 0, 0}, []byte{0, 0xef, 0x40})
+	mock.ExpectTransfer(0x05, 0x00)
+	bus := NewSPI(mock)
+	var trace strings.Builder
+	bus.Sink = WriterSink{&trace}
+
+	c.Assert(bus.Tx([]byte{0x9f, 0, 0}, make([]byte, 3)), qt.IsNil)
+	_, err := bus.Transfer(0x05)
+	c.Assert(err, qt.IsNil)
+	mock.AssertDone()
+
+	s := bus.Stats()
+	c.Assert(s.Transactions, qt.Equals, uint32(2))
+	c.Assert(s.BytesWritten, qt.Equals, uint32(4))
+	c.Assert(s.BytesRead, qt.Equals, uint32(4))
+	lines := strings.Split(strings.TrimSpace(trace.String()), "\n")
+	c.Assert(lines, qt.HasLen, 2)
+	c.Assert(lines[0], qt.Matches, `spi tx w=3 r=3 .*`)
+	c.Assert(lines[1], qt.Matches, `spi transfer w=1 r=1 .*`)
+}
+
+func TestHistogram(t *testing.T) {
+	c := qt.New(t)
+	var h Histogram
+	h.Add(500 * time.Nanosecond)
+	h.Add(3 * time.Microsecond)
+	h.Add(100 * time.Microsecond)
+	h.Add(100 * time.Microsecond)
+	h.Add(time.Minute)
+
+	c.Assert(h[0], qt.Equals, uint32(1))
+	c.Assert(h[2], qt.Equals, uint32(1))
+	c.Assert(h[7], qt.Equals, uint32(2))
+	c.Assert(h[len(h)-1], qt.Equals, uint32(1))
+	c.Assert(h.Count(), qt.Equals, uint32(5))
+	c.Assert(h.Percentile(50), qt.Equals, 128*time.Microsecond)
+	c.Assert(h.Percentile(100), qt.Equals, time.Duration(-1))
+	c.Assert(new(Histogram).Percentile(50), qt.Equals, time.Duration(0))
+}
+
+func TestRing(t *testing.T) {
+	c := qt.New(t)
+	r := NewRing(2)
+	r.Trace(Event{Addr: 1})
+	c.Assert(r.Events(), qt.HasLen, 1)
+	r.Trace(Event{Addr: 2})
+	r.Trace(Event{Addr: 3})
+	events := r.Events()
+	c.Assert(events, qt.HasLen, 2)
+	c.Assert(events[0].Addr, qt.Equals, uint16(2))
+	c.Assert(events[1].Addr, qt.Equals, uint16(3))
+	r.Reset()
+	c.Assert(r.Events(), qt.HasLen, 0)
+}
+
+func TestEventString(t *testing.T) {
+	c := qt.New(t)
+	e := Event{Op: OpI2CRead, Addr: 0x76, Reg: 0xf7, Written: 1, Read: 8, Duration: 250 * time.Microsecond}
+	c.Assert(e.String(), qt.Equals, "i2c read 0x76 reg=0xf7 w=1 r=8 250µs")
+	e = Event{Op: OpI2CTx, Addr: 0x29, Written: 2, Err: tester.ErrNACK}
+	c.Assert(e.String(), qt.Equals, "i2c tx 0x29 w=2 r=0 0s err=i2c: address not acknowledged")
+}
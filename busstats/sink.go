@@ -0,0 +1,69 @@
+package busstats
+
+import "io"
+
+// Sink receives the events traced by a wrapper.
+type Sink interface {
+	Trace(e Event)
+}
+
+// PrintSink prints each event with println, usually to the serial console.
+type PrintSink struct{}
+
+// Trace implements Sink.
+func (PrintSink) Trace(e Event) {
+	println(e.String())
+}
+
+// WriterSink writes each event on its own line to W, for example a UART.
+// Write errors are ignored.
+type WriterSink struct {
+	W io.Writer
+}
+
+// Trace implements Sink.
+func (s WriterSink) Trace(e Event) {
+	s.W.Write([]byte(e.String() + "\n"))
+}
+
+// Ring keeps the last events in memory, so they can be inspected after a
+// failure without the cost of printing every transaction.
+type Ring struct {
+	events []Event
+	next   int
+	full   bool
+}
+
+// NewRing returns a ring that keeps the last n events.
+func NewRing(n int) *Ring {
+	return &Ring{
+		events: make([]Event, n),
+	}
+}
+
+// Trace implements Sink.
+func (r *Ring) Trace(e Event) {
+	if len(r.events) == 0 {
+		return
+	}
+	r.events[r.next] = e
+	r.next++
+	if r.next == len(r.events) {
+		r.next = 0
+		r.full = true
+	}
+}
+
+// Events returns the events kept by the ring, oldest first.
+func (r *Ring) Events() []Event {
+	if !r.full {
+		return append([]Event(nil), r.events[:r.next]...)
+	}
+	return append(append([]Event(nil), r.events[r.next:]...), r.events[:r.next]...)
+}
+
+// Reset empties the ring.
+func (r *Ring) Reset() {
+	r.next = 0
+	r.full = false
+}
@@ -0,0 +1,334 @@
+// Package button turns the raw states of buttons into debounced events and
+// gestures: down, up, click, double-click, long-press and repeat.
+//
+// The buttons are read from a Source, which returns a bitmask of the pressed
+// buttons. Sources are provided for GPIO pins, and any other input such as
+// a shift register can be adapted with SourceFunc:
+//
+//	buttons := shifter.NewButtons()
+//	buttons.Configure()
+//	g := button.New(button.SourceFunc(func() (uint32, error) {
+//		v, err := buttons.ReadInput()
+//		return uint32(v), err
+//	}), 8)
+//	g.Configure(button.Config{})
+//	for {
+//		g.Update()
+//		for e, ok := g.Next(); ok; e, ok = g.Next() {
+//			println(e.Button, e.Type.String())
+//		}
+//		time.Sleep(5 * time.Millisecond)
+//	}
+//
+// Update reads the source, while Process takes the states directly. Together
+// with a tester.Clock, Process makes the gestures of an application testable
+// without hardware.
+package button // import "tinygo.org/x/drivers/button"
+
+import (
+	"time"
+
+	"tinygo.org/x/drivers"
+	"tinygo.org/x/drivers/internal/events"
+)
+
+// MaxButtons is the maximum number of buttons of a Group.
+const MaxButtons = 32
+
+// Defaults used for the zero values of Config.
+const (
+	defaultDebounce    = 20 * time.Millisecond
+	defaultLongPress   = 800 * time.Millisecond
+	defaultDoubleClick = 300 * time.Millisecond
+)
+
+// Source returns the state of a set of buttons: bit i is set when button i
+// is pressed.
+type Source interface {
+	Read() (uint32, error)
+}
+
+// SourceFunc adapts a function to a Source.
+type SourceFunc func() (uint32, error)
+
+// Read implements Source.
+func (f SourceFunc) Read() (uint32, error) {
+	return f()
+}
+
+// Pins is a Source for buttons wired to GPIO pins. Button i is read from
+// Pins[i].
+type Pins struct {
+	Pins []drivers.Pin
+
+	// ActiveHigh must be set when a pressed button drives its pin high.
+	// By default, buttons pull their pin low, against a pull-up.
+	ActiveHigh bool
+}
+
+// Configure configures the pins as inputs, with pull-ups for active low
+// buttons.
+func (p Pins) Configure() {
+	mode := drivers.PinInputPullup
+	if p.ActiveHigh {
+		mode = drivers.PinInput
+	}
+	for _, pin := range p.Pins {
+		drivers.ConfigurePin(pin, mode)
+	}
+}
+
+// Read implements Source.
+func (p Pins) Read() (uint32, error) {
+	var state uint32
+	for i, pin := range p.Pins {
+		if pin.Get() == p.ActiveHigh {
+			state |= 1 << uint(i)
+		}
+	}
+	return state, nil
+}
+
+// EventType is the kind of an Event.
+type EventType uint8
+
+const (
+	// Down is sent when a button is pressed.
+	Down EventType = iota
+	// Up is sent when a button is released.
+	Up
+	// Click is sent after a short press. When double-clicks are enabled,
+	// it is delayed until Config.DoubleClick has passed without a second
+	// press.
+	Click
+	// DoubleClick is sent instead of Click after two short presses.
+	DoubleClick
+	// LongPress is sent once when a button has been held for
+	// Config.LongPress. No Click is sent for that press.
+	LongPress
+	// Repeat is sent every Config.RepeatInterval after LongPress while the
+	// button is held.
+	Repeat
+)
+
+var eventTypeNames = []string{"down", "up", "click", "double-click", "long-press", "repeat"}
+
+// String returns the name of the event type.
+func (t EventType) String() string {
+	return events.Name(eventTypeNames, uint8(t))
+}
+
+// Event is a change or a gesture of a button.
+type Event struct {
+	Type   EventType
+	Button int
+}
+
+// Config holds the timings of a Group. Negative durations disable the
+// corresponding feature.
+type Config struct {
+	// Debounce is how long a button must be stable before its change is
+	// reported. It is 20ms if zero.
+	Debounce time.Duration
+
+	// LongPress is how long a button must be held to send LongPress. It
+	// is 800ms if zero.
+	LongPress time.Duration
+
+	// DoubleClick is the longest time between two clicks that make a
+	// double-click. It is 300ms if zero. When double-clicks are disabled,
+	// Click is sent as soon as the button is released.
+	DoubleClick time.Duration
+
+	// RepeatInterval is the time between the Repeat events of a held
+	// button. No Repeat events are sent if it is zero.
+	RepeatInterval time.Duration
+
+	// QueueSize is the number of events that can be queued before Next is
+	// called. Further events are dropped. It is 16 if zero.
+	QueueSize int
+}
+
+// state is the state of a single button.
+type state struct {
+	raw      bool
+	pressed  bool
+	long     bool          // LongPress has been sent for this press
+	clicks   uint8         // short presses waiting for a double-click
+	changed  time.Duration // time of the last change of raw
+	released time.Duration // time of the last release
+	next     time.Duration // time of the next LongPress or Repeat event
+}
+
+// Group is a set of buttons read from the same Source.
+type Group struct {
+	source  Source
+	config  Config
+	buttons []state
+	start   time.Time
+	events  events.Queue
+
+	// Clock is used to time the gestures. It is drivers.SystemClock by
+	// default.
+	Clock drivers.Clock
+}
+
+// New returns a group of n buttons read from source. It panics if n is
+// larger than MaxButtons.
+func New(source Source, n int) *Group {
+	if n > MaxButtons {
+		panic("button: too many buttons")
+	}
+	return &Group{
+		source:  source,
+		buttons: make([]state, n),
+		Clock:   drivers.SystemClock,
+	}
+}
+
+// Configure applies the timings of config. It clears the state of the
+// buttons and the event queue.
+func (g *Group) Configure(config Config) {
+	if config.Debounce == 0 {
+		config.Debounce = defaultDebounce
+	}
+	if config.LongPress == 0 {
+		config.LongPress = defaultLongPress
+	}
+	if config.DoubleClick == 0 {
+		config.DoubleClick = defaultDoubleClick
+	}
+	if config.QueueSize == 0 {
+		config.QueueSize = events.DefaultQueueSize
+	}
+	g.config = config
+	for i := range g.buttons {
+		g.buttons[i] = state{}
+	}
+	g.events.Reset(config.QueueSize)
+	g.start = g.Clock.Now()
+}
+
+// Update reads the source and processes the state of the buttons. It must
+// be called regularly, every few milliseconds, for the timings to be
+// accurate.
+func (g *Group) Update() error {
+	pressed, err := g.source.Read()
+	if err != nil {
+		return err
+	}
+	g.Process(pressed)
+	return nil
+}
+
+// Process queues the events caused by the given state of the buttons at the
+// current time of the clock: bit i of pressed is set when button i is
+// pressed.
+func (g *Group) Process(pressed uint32) {
+	now := g.Clock.Now().Sub(g.start)
+	for i := range g.buttons {
+		b := &g.buttons[i]
+		raw := pressed&(1<<uint(i)) != 0
+		if raw != b.raw {
+			b.raw = raw
+			b.changed = now
+		}
+		g.update(b, i, now)
+	}
+}
+
+func (g *Group) update(b *state, i int, now time.Duration) {
+	if b.raw != b.pressed && now-b.changed >= g.config.Debounce {
+		b.pressed = b.raw
+		if b.pressed {
+			g.press(b, i, now)
+		} else {
+			g.release(b, i, now)
+		}
+		return
+	}
+	switch {
+	case b.pressed && now >= b.next:
+		if !b.long {
+			b.long = true
+			g.flushClick(b, i)
+			g.push(LongPress, i)
+		} else {
+			g.push(Repeat, i)
+		}
+		if g.config.RepeatInterval > 0 {
+			b.next += g.config.RepeatInterval
+		} else {
+			b.next = events.Never
+		}
+	case !b.pressed && b.clicks > 0 && now-b.released >= g.config.DoubleClick:
+		g.flushClick(b, i)
+	}
+}
+
+func (g *Group) press(b *state, i int, now time.Duration) {
+	g.push(Down, i)
+	b.long = false
+	b.next = events.Never
+	if g.config.LongPress >= 0 {
+		b.next = now + g.config.LongPress
+	}
+}
+
+func (g *Group) release(b *state, i int, now time.Duration) {
+	g.push(Up, i)
+	if b.long {
+		return
+	}
+	switch {
+	case g.config.DoubleClick < 0:
+		g.push(Click, i)
+	case b.clicks > 0:
+		b.clicks = 0
+		g.push(DoubleClick, i)
+	default:
+		b.clicks = 1
+		b.released = now
+	}
+}
+
+// flushClick sends the Click of a short press that did not become a
+// double-click.
+func (g *Group) flushClick(b *state, i int) {
+	if b.clicks > 0 {
+		b.clicks = 0
+		g.push(Click, i)
+	}
+}
+
+func (g *Group) push(t EventType, i int) {
+	g.events.Push(uint8(t), i)
+}
+
+// Next returns the oldest queued event. It returns false if there is none.
+func (g *Group) Next() (Event, bool) {
+	e, ok := g.events.Next()
+	return Event{Type: EventType(e.Type), Button: e.Index}, ok
+}
+
+// Dropped returns the number of events dropped because the queue was full.
+func (g *Group) Dropped() int {
+	return g.events.Dropped()
+}
+
+// Pressed returns whether button i is pressed, after debouncing.
+func (g *Group) Pressed(i int) bool {
+	return g.buttons[i].pressed
+}
+
+// State returns the debounced state of all the buttons: bit i is set when
+// button i is pressed.
+func (g *Group) State() uint32 {
+	var s uint32
+	for i := range g.buttons {
+		if g.buttons[i].pressed {
+			s |= 1 << uint(i)
+		}
+	}
+	return s
+}
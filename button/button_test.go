@@ -0,0 +1,206 @@
+package button
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"tinygo.org/x/drivers"
+	"tinygo.org/x/drivers/tester"
+)
+
+// step holds the state of the buttons for a duration.
+type step struct {
+	pressed uint32
+	d       time.Duration
+}
+
+// play processes the steps with a fake clock, every millisecond, and
+// returns the events.
+func play(g *Group, clock *tester.Clock, steps ...step) []Event {
+	for _, s := range steps {
+		for end := clock.Elapsed() + s.d; clock.Elapsed() < end; {
+			g.Process(s.pressed)
+			clock.Advance(time.Millisecond)
+		}
+	}
+	var events []Event
+	for e, ok := g.Next(); ok; e, ok = g.Next() {
+		events = append(events, e)
+	}
+	return events
+}
+
+func newTestGroup(n int, config Config) (*Group, *tester.Clock) {
+	clock := tester.NewClock()
+	g := New(nil, n)
+	g.Clock = clock
+	g.Configure(config)
+	return g, clock
+}
+
+func types(events []Event) []EventType {
+	var types []EventType
+	for _, e := range events {
+		types = append(types, e.Type)
+	}
+	return types
+}
+
+func TestClick(t *testing.T) {
+	c := qt.New(t)
+	g, clock := newTestGroup(2, Config{})
+
+	events := play(g, clock,
+		step{0b10, 100 * time.Millisecond},
+		step{0, 500 * time.Millisecond},
+	)
+	c.Assert(events, qt.DeepEquals, []Event{
+		{Type: Down, Button: 1},
+		{Type: Up, Button: 1},
+		{Type: Click, Button: 1},
+	})
+}
+
+func TestDebounce(t *testing.T) {
+	c := qt.New(t)
+	g, clock := newTestGroup(1, Config{})
+
+	// Bounces shorter than the debounce time are ignored.
+	events := play(g, clock,
+		step{1, 5 * time.Millisecond},
+		step{0, 5 * time.Millisecond},
+		step{1, 5 * time.Millisecond},
+		step{0, 100 * time.Millisecond},
+	)
+	c.Assert(events, qt.HasLen, 0)
+	c.Assert(g.Pressed(0), qt.IsFalse)
+
+	play(g, clock, step{1, 25 * time.Millisecond})
+	c.Assert(g.Pressed(0), qt.IsTrue)
+	c.Assert(g.State(), qt.Equals, uint32(1))
+}
+
+func TestDoubleClick(t *testing.T) {
+	c := qt.New(t)
+	g, clock := newTestGroup(1, Config{})
+
+	events := play(g, clock,
+		step{1, 80 * time.Millisecond},
+		step{0, 100 * time.Millisecond},
+		step{1, 80 * time.Millisecond},
+		step{0, 500 * time.Millisecond},
+	)
+	c.Assert(types(events), qt.DeepEquals, []EventType{Down, Up, Down, Up, DoubleClick})
+
+	// Clicks too far apart are two clicks.
+	events = play(g, clock,
+		step{1, 80 * time.Millisecond},
+		step{0, 400 * time.Millisecond},
+		step{1, 80 * time.Millisecond},
+		step{0, 400 * time.Millisecond},
+	)
+	c.Assert(types(events), qt.DeepEquals, []EventType{Down, Up, Click, Down, Up, Click})
+}
+
+func TestDoubleClickDisabled(t *testing.T) {
+	c := qt.New(t)
+	g, clock := newTestGroup(1, Config{DoubleClick: -1})
+
+	events := play(g, clock,
+		step{1, 80 * time.Millisecond},
+		step{0, 100 * time.Millisecond},
+		step{1, 80 * time.Millisecond},
+		step{0, 21 * time.Millisecond},
+	)
+	c.Assert(types(events), qt.DeepEquals, []EventType{Down, Up, Click, Down, Up, Click})
+}
+
+func TestLongPressRepeat(t *testing.T) {
+	c := qt.New(t)
+	g, clock := newTestGroup(1, Config{
+		LongPress:      500 * time.Millisecond,
+		RepeatInterval: 100 * time.Millisecond,
+	})
+
+	events := play(g, clock,
+		step{1, 730 * time.Millisecond},
+		step{0, 500 * time.Millisecond},
+	)
+	c.Assert(types(events), qt.DeepEquals, []EventType{Down, LongPress, Repeat, Repeat, Up})
+}
+
+func TestClickThenLongPress(t *testing.T) {
+	c := qt.New(t)
+	g, clock := newTestGroup(1, Config{})
+
+	// The pending click is sent before the long press.
+	events := play(g, clock,
+		step{1, 80 * time.Millisecond},
+		step{0, 100 * time.Millisecond},
+		step{1, time.Second},
+		step{0, 500 * time.Millisecond},
+	)
+	c.Assert(types(events), qt.DeepEquals, []EventType{Down, Up, Down, Click, LongPress, Up})
+}
+
+func TestLongPressDisabled(t *testing.T) {
+	c := qt.New(t)
+	g, clock := newTestGroup(1, Config{LongPress: -1, DoubleClick: -1})
+
+	events := play(g, clock,
+		step{1, 5 * time.Second},
+		step{0, 100 * time.Millisecond},
+	)
+	c.Assert(types(events), qt.DeepEquals, []EventType{Down, Up, Click})
+}
+
+func TestPins(t *testing.T) {
+	c := qt.New(t)
+	a, b := tester.NewPin(), tester.NewPin()
+	src := Pins{Pins: []drivers.Pin{a, b}}
+	src.Configure()
+	c.Assert(a.Mode(), qt.Equals, drivers.PinInputPullup)
+
+	// Active low: a pressed button reads low.
+	a.Input = func() bool { return true }
+	b.Input = func() bool { return false }
+	state, err := src.Read()
+	c.Assert(err, qt.IsNil)
+	c.Assert(state, qt.Equals, uint32(0b10))
+
+	src.ActiveHigh = true
+	state, err = src.Read()
+	c.Assert(err, qt.IsNil)
+	c.Assert(state, qt.Equals, uint32(0b01))
+}
+
+func TestUpdate(t *testing.T) {
+	c := qt.New(t)
+	pressed := uint32(0)
+	var readErr error
+	clock := tester.NewClock()
+	g := New(SourceFunc(func() (uint32, error) {
+		return pressed, readErr
+	}), 1)
+	g.Clock = clock
+	g.Configure(Config{Debounce: -1, DoubleClick: -1})
+
+	pressed = 1
+	c.Assert(g.Update(), qt.IsNil)
+	pressed = 0
+	c.Assert(g.Update(), qt.IsNil)
+	c.Assert(types(play(g, clock)), qt.DeepEquals, []EventType{Down, Up, Click})
+
+	readErr = errors.New("bus error")
+	c.Assert(g.Update(), qt.ErrorMatches, "bus error")
+}
+
+func TestQueueFull(t *testing.T) {
+	c := qt.New(t)
+	g, clock := newTestGroup(4, Config{Debounce: -1, QueueSize: 2})
+	play(g, clock, step{0b1111, time.Millisecond})
+	c.Assert(g.Dropped(), qt.Equals, 2)
+}
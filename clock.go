@@ -0,0 +1,24 @@
+package drivers
+
+import "time"
+
+// Clock is the source of time of drivers that sleep or wait with a timeout.
+// Drivers use SystemClock by default. Tests replace it with tester.Clock, a
+// fake clock that is advanced manually, so that timeouts can be tested
+// without waiting.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// Sleep pauses the current goroutine for at least the duration d.
+	Sleep(d time.Duration)
+}
+
+// SystemClock is the clock of the time package.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func (systemClock) Sleep(d time.Duration) { time.Sleep(d) }
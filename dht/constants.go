@@ -12,6 +12,8 @@ package dht // import "tinygo.org/x/drivers/dht"
 import (
 	"machine"
 	"time"
+
+	"tinygo.org/x/drivers"
 )
 
 // Celsius and Fahrenheit temperature scales
@@ -69,11 +71,29 @@ func (e ErrorCode) Error() string {
 	return "unknown error"
 }
 
+// Is reports whether e matches target, so that errors.Is matches the error codes with the errors shared by the
+// drivers: ChecksumError with drivers.ErrChecksum, NoSignalError with drivers.ErrNotConnected and NoDataError with
+// drivers.ErrTimeout.
+func (e ErrorCode) Is(target error) bool {
+	switch e {
+	case ChecksumError:
+		return target == drivers.ErrChecksum
+	case NoSignalError:
+		return target == drivers.ErrNotConnected
+	case NoDataError:
+		return target == drivers.ErrTimeout
+	}
+	return false
+}
+
 // Update policy of the DHT device. UpdateTime cannot be shorter than 2 seconds. According to dht specification sensor
 // will return undefined data if update requested less than 2 seconds before last usage
 type UpdatePolicy struct {
 	UpdateTime          time.Duration
 	UpdateAutomatically bool
+	// Clock is used to wait for the sensor and to measure the time since the last update.
+	// If nil, drivers.SystemClock is used. The bits sent by the sensor are still timed by counting cycles.
+	Clock drivers.Clock
 }
 
 var (
@@ -11,7 +11,8 @@ package dht // import "tinygo.org/x/drivers/dht"
 
 import (
 	"machine"
-	"time"
+
+	"tinygo.org/x/drivers"
 )
 
 // DummyDevice provides a basic interface for DHT devices.
@@ -30,7 +31,8 @@ type DummyDevice interface {
 // Since taking measurements from the sensor is time consuming procedure and blocks interrupts,
 // user can avoid any hidden calls to the sensor.
 type device struct {
-	pin machine.Pin
+	pin   machine.Pin
+	clock drivers.Clock
 
 	measurements DeviceType
 	initialized  bool
@@ -43,7 +45,7 @@ type device struct {
 // According to documentation pin should be always, but the t *device restores pin to the state before call.
 func (t *device) ReadMeasurements() error {
 	// initial waiting
-	state := powerUp(t.pin)
+	state := powerUp(t.pin, t.clock)
 	defer t.pin.Set(state)
 	err := t.read()
 	if err == nil {
@@ -93,11 +95,11 @@ func (t *device) HumidityFloat() (float32, error) {
 // Perform initialization of the communication protocol.
 // Device lowers the voltage on pin for startingLow=20ms and starts listening for response
 // Section 5.2 in [1]
-func initiateCommunication(p machine.Pin) {
+func initiateCommunication(p machine.Pin, clock drivers.Clock) {
 	// Send low signal to the device
 	p.Configure(machine.PinConfig{Mode: machine.PinOutput})
 	p.Low()
-	time.Sleep(startingLow)
+	clock.Sleep(startingLow)
 	// Set pin to high and wait for reply
 	p.High()
 	p.Configure(machine.PinConfig{Mode: machine.PinInput})
@@ -131,7 +133,7 @@ func (t *device) read() error {
 	signals := signalsData[:]
 
 	// Start communication protocol with sensor
-	initiateCommunication(t.pin)
+	initiateCommunication(t.pin, t.clock)
 	// Wait for sensor's response and abort if sensor does not reply
 	err := waitForDataTransmission(t.pin)
 	if err != nil {
@@ -213,6 +215,7 @@ func NewDummyDevice(pin machine.Pin, deviceType DeviceType) DummyDevice {
 	pin.High()
 	return &device{
 		pin:          pin,
+		clock:        drivers.SystemClock,
 		measurements: deviceType,
 		initialized:  false,
 		temperature:  0,
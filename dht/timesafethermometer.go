@@ -12,6 +12,8 @@ package dht // import "tinygo.org/x/drivers/dht"
 import (
 	"machine"
 	"time"
+
+	"tinygo.org/x/drivers"
 )
 
 // Device interface provides main functionality of the DHTXX sensors.
@@ -101,7 +103,7 @@ func (m *managedDevice) HumidityFloat() (float32, error) {
 // ReadMeasurements reads data from the sensor.
 // The function will return UpdateError if it is called more frequently than specified in UpdatePolicy
 func (m *managedDevice) ReadMeasurements() (err error) {
-	timestamp := time.Now()
+	timestamp := m.t.clock.Now()
 	if !m.t.initialized || timestamp.Sub(m.lastUpdate) > m.policy.UpdateTime {
 		err = m.t.ReadMeasurements()
 	} else {
@@ -120,6 +122,10 @@ func (m *managedDevice) Configure(policy UpdatePolicy) {
 	if policy.UpdateAutomatically && policy.UpdateTime < time.Second*2 {
 		policy.UpdateTime = time.Second * 2
 	}
+	if policy.Clock == nil {
+		policy.Clock = drivers.SystemClock
+	}
+	m.t.clock = policy.Clock
 	m.policy = policy
 }
 
@@ -130,6 +136,7 @@ func New(pin machine.Pin, deviceType DeviceType) Device {
 	return &managedDevice{
 		t: device{
 			pin:          pin,
+			clock:        drivers.SystemClock,
 			measurements: deviceType,
 			initialized:  false,
 		},
@@ -137,6 +144,7 @@ func New(pin machine.Pin, deviceType DeviceType) Device {
 		policy: UpdatePolicy{
 			UpdateTime:          time.Second * 2,
 			UpdateAutomatically: true,
+			Clock:               drivers.SystemClock,
 		},
 	}
 }
@@ -5,15 +5,16 @@ package dht // import "tinygo.org/x/drivers/dht"
 
 import (
 	"machine"
-	"time"
+
+	"tinygo.org/x/drivers"
 )
 
 // Check if the pin is disabled
-func powerUp(p machine.Pin) bool {
+func powerUp(p machine.Pin, clock drivers.Clock) bool {
 	state := p.Get()
 	if !state {
 		p.High()
-		time.Sleep(startTimeout)
+		clock.Sleep(startTimeout)
 	}
 	return state
 }
@@ -12,3 +12,55 @@ type Displayer interface {
 	// Display sends the buffer (if any) to the screen.
 	Display() error
 }
+
+// Rotation is the clockwise rotation of a display.
+type Rotation uint8
+
+const (
+	Rotation0 Rotation = iota
+	Rotation90
+	Rotation180
+	Rotation270
+)
+
+// AcceleratedDisplayer is optionally implemented by a Displayer that draws
+// directly to the memory of the display, such as the TFT and OLED color
+// displays. Graphics libraries can detect it with a type assertion, to draw
+// large areas at once instead of pixel by pixel:
+//
+//	if d, ok := display.(drivers.AcceleratedDisplayer); ok {
+//		return d.FillRectangle(x, y, width, height, c)
+//	}
+//
+// Coordinates follow the current rotation. The drawing methods return
+// ErrOutOfBounds if the area is not entirely on the display.
+type AcceleratedDisplayer interface {
+	Displayer
+
+	// FillRectangle fills a rectangle with a single color.
+	FillRectangle(x, y, width, height int16, c color.RGBA) error
+
+	// DrawRGBBitmap copies an image of w*h RGB565 pixels, stored row by row,
+	// to the given position.
+	DrawRGBBitmap(x, y int16, data []uint16, w, h int16) error
+
+	// SetScrollArea sets the number of lines at the top and bottom of the
+	// display that do not scroll. It returns ErrInvalidConfig if the display
+	// does not support these fixed areas.
+	SetScrollArea(topFixedArea, bottomFixedArea int16) error
+
+	// SetScroll sets the line of the display memory shown at the top of the
+	// scroll area.
+	SetScroll(line int16) error
+
+	// StopScroll returns the display to its normal state.
+	StopScroll() error
+
+	// Rotation returns the current rotation of the display.
+	Rotation() Rotation
+
+	// SetRotation changes the rotation of the display, and thus its Size.
+	// The content of the display is not redrawn. It returns
+	// ErrInvalidConfig if the rotation is not supported.
+	SetRotation(rotation Rotation) error
+}
@@ -0,0 +1,20 @@
+package drivers_test
+
+import (
+	"tinygo.org/x/drivers"
+	"tinygo.org/x/drivers/ili9341"
+	"tinygo.org/x/drivers/ssd1331"
+	"tinygo.org/x/drivers/ssd1351"
+	"tinygo.org/x/drivers/st7735"
+	"tinygo.org/x/drivers/st7789"
+)
+
+// Compile-time checks that the color displays implement the fast drawing
+// path.
+var (
+	_ drivers.AcceleratedDisplayer = (*ili9341.Device)(nil)
+	_ drivers.AcceleratedDisplayer = (*ssd1331.Device)(nil)
+	_ drivers.AcceleratedDisplayer = (*ssd1351.Device)(nil)
+	_ drivers.AcceleratedDisplayer = (*st7735.Device)(nil)
+	_ drivers.AcceleratedDisplayer = (*st7789.Device)(nil)
+)
@@ -1,16 +1,67 @@
 // Package easystepper provides a simple driver to rotate a 4-wire stepper motor.
+//
+// Move rotates the motor at a constant speed and blocks until it is done.
+// For smoother and concurrent motion, set a target position, a maximum speed
+// and an acceleration, then call Run as often as possible: each call makes a
+// step when it is due, so that several motors can move at once:
+//
+//	motor.SetMaxSpeed(800)
+//	motor.SetAcceleration(400)
+//	motor.MoveTo(2000)
+//	for motor.Run() {
+//		// Do other work, or run other motors.
+//	}
 package easystepper // import "tinygo.org/x/drivers/easystepper"
 
 import (
-	"machine"
+	"math"
 	"time"
+
+	"tinygo.org/x/drivers"
+)
+
+// StepMode is the sequence used to drive the coils of the motor.
+type StepMode uint8
+
+const (
+	// FullStep energizes two coils at a time. It is the default.
+	FullStep StepMode = iota
+	// HalfStep alternates between one and two coils, which doubles the
+	// resolution of the motor. Positions and speeds are then counted in
+	// half steps.
+	HalfStep
 )
 
+// phases holds the levels of the 4 pins for each half step. Full steps use
+// the even entries.
+var phases = [8][4]bool{
+	{true, false, true, false},
+	{false, false, true, false},
+	{false, true, true, false},
+	{false, true, false, false},
+	{false, true, false, true},
+	{false, false, false, true},
+	{true, false, false, true},
+	{true, false, false, false},
+}
+
 // Device holds the pins and the delay between steps
 type Device struct {
-	pins       [4]machine.Pin
-	stepDelay  int32
-	stepNumber uint8
+	pins  [4]drivers.Pin
+	phase uint8
+	mode  StepMode
+
+	position     int32
+	target       int32
+	maxSpeed     float32 // steps per second
+	acceleration float32 // steps per second², 0 for none
+	speed        float32 // steps per second, negative backwards
+	lastStep     time.Time
+	interval     time.Duration
+
+	// Clock is used to schedule the steps. It is drivers.SystemClock by
+	// default.
+	Clock drivers.Clock
 }
 
 // DualDevice holds information for controlling 2 motors
@@ -19,31 +70,26 @@ type DualDevice struct {
 }
 
 // New returns a new easystepper driver given 4 pins, number of steps and rpm
-func New(pin1, pin2, pin3, pin4 machine.Pin, steps int32, rpm int32) Device {
+func New(pin1, pin2, pin3, pin4 drivers.Pin, steps int32, rpm int32) Device {
 	return Device{
-		pins:      [4]machine.Pin{pin1, pin2, pin3, pin4},
-		stepDelay: 60000000 / (steps * rpm),
+		pins:     [4]drivers.Pin{pin1, pin2, pin3, pin4},
+		maxSpeed: float32(steps*rpm) / 60,
+		Clock:    drivers.SystemClock,
 	}
 }
 
 // Configure configures the pins of the Device
 func (d *Device) Configure() {
 	for _, pin := range d.pins {
-		pin.Configure(machine.PinConfig{Mode: machine.PinOutput})
+		drivers.ConfigurePin(pin, drivers.PinOutput)
 	}
 }
 
 // NewDual returns a new dual easystepper driver given 8 pins, number of steps and rpm
-func NewDual(pin1, pin2, pin3, pin4, pin5, pin6, pin7, pin8 machine.Pin, steps int32, rpm int32) DualDevice {
+func NewDual(pin1, pin2, pin3, pin4, pin5, pin6, pin7, pin8 drivers.Pin, steps int32, rpm int32) DualDevice {
 	var dual DualDevice
-	dual.devices[0] = Device{
-		pins:      [4]machine.Pin{pin1, pin2, pin3, pin4},
-		stepDelay: 60000000 / (steps * rpm),
-	}
-	dual.devices[1] = Device{
-		pins:      [4]machine.Pin{pin5, pin6, pin7, pin8},
-		stepDelay: 60000000 / (steps * rpm),
-	}
+	dual.devices[0] = New(pin1, pin2, pin3, pin4, steps, rpm)
+	dual.devices[1] = New(pin5, pin6, pin7, pin8, steps, rpm)
 	return dual
 }
 
@@ -53,20 +99,214 @@ func (d *DualDevice) Configure() {
 	d.devices[1].Configure()
 }
 
-// Move rotates the motor the number of given steps
-// (negative steps will rotate it the opposite direction)
-func (d *Device) Move(steps int32) {
-	direction := steps > 0
-	if steps < 0 {
+// SetMode sets the step mode. It should be called while the motor is
+// stopped.
+func (d *Device) SetMode(mode StepMode) {
+	d.mode = mode
+	if mode == FullStep {
+		d.phase &^= 1
+	}
+}
+
+// SetMaxSpeed sets the maximum speed of the motor in steps per second.
+func (d *Device) SetMaxSpeed(stepsPerSecond float32) {
+	d.maxSpeed = stepsPerSecond
+}
+
+// MaxSpeed returns the maximum speed of the motor in steps per second.
+func (d *Device) MaxSpeed() float32 {
+	return d.maxSpeed
+}
+
+// SetAcceleration sets the acceleration and deceleration of the motor in
+// steps per second per second. With an acceleration of 0, the default, the
+// motor starts and stops at its maximum speed.
+func (d *Device) SetAcceleration(stepsPerSecond2 float32) {
+	d.acceleration = stepsPerSecond2
+}
+
+// Speed returns the current speed of the motor in steps per second,
+// negative when it turns backwards.
+func (d *Device) Speed() float32 {
+	return d.speed
+}
+
+// Position returns the absolute position of the motor in steps.
+func (d *Device) Position() int32 {
+	return d.position
+}
+
+// SetPosition sets the current position of the motor, for example once it
+// has reached a limit switch, and stops it.
+func (d *Device) SetPosition(position int32) {
+	d.position = position
+	d.target = position
+	d.speed = 0
+}
+
+// MoveTo sets the absolute position to move to. The motion is made by Run.
+func (d *Device) MoveTo(position int32) {
+	d.target = position
+}
+
+// MoveBy sets a target position relative to the current one. The motion is
+// made by Run.
+func (d *Device) MoveBy(steps int32) {
+	d.target = d.position + steps
+}
+
+// Target returns the position the motor moves to.
+func (d *Device) Target() int32 {
+	return d.target
+}
+
+// DistanceToGo returns the number of steps between the position and the
+// target.
+func (d *Device) DistanceToGo() int32 {
+	return d.target - d.position
+}
+
+// Stop sets the target to the closest position where the motor can stop
+// with its acceleration. The motion is made by Run.
+func (d *Device) Stop() {
+	if d.speed == 0 {
+		d.target = d.position
+		return
+	}
+	steps := int32(1)
+	if d.acceleration > 0 {
+		steps = int32(d.speed*d.speed/(2*d.acceleration)) + 1
+	}
+	if d.speed < 0 {
 		steps = -steps
 	}
-	steps += int32(d.stepNumber)
-	var s int32
-	d.stepMotor(d.stepNumber)
-	for s = int32(d.stepNumber); s < steps; s++ {
-		time.Sleep(time.Duration(d.stepDelay) * time.Microsecond)
-		d.moveDirectionSteps(direction, s)
+	d.target = d.position + steps
+}
+
+// Run makes a step if one is due, and returns whether the motor is still
+// moving. It must be called at least as often as the steps, and does not
+// block. A step is due once the interval of the previous one has passed,
+// even when the motor stopped in between.
+func (d *Device) Run() bool {
+	if d.speed == 0 && d.position == d.target {
+		return false
+	}
+	now := d.Clock.Now()
+	if now.Sub(d.lastStep) < d.interval {
+		return true
+	}
+	if !d.updateSpeed() {
+		return false
+	}
+	if d.speed > 0 {
+		d.step(true)
+	} else {
+		d.step(false)
+	}
+	d.lastStep = now
+	d.interval = time.Duration(float32(time.Second) / abs(d.speed))
+	if d.position == d.target {
+		d.speed = 0
+		return false
 	}
+	return true
+}
+
+// RunToPosition runs the motor until it reaches its target, sleeping
+// between the steps. It returns once the interval of the last step has
+// passed, so that n steps take as long as n intervals.
+func (d *Device) RunToPosition() {
+	for d.Run() {
+		if wait := d.wait(); wait > 0 {
+			d.Clock.Sleep(wait)
+		}
+	}
+	if wait := d.wait(); wait > 0 {
+		d.Clock.Sleep(wait)
+	}
+}
+
+// wait returns the time until the next step is due.
+func (d *Device) wait() time.Duration {
+	return d.interval - d.Clock.Now().Sub(d.lastStep)
+}
+
+// updateSpeed computes the speed of the next step, following a trapezoidal
+// profile, and returns false if the motor stopped at its target. The speed
+// after a step of a constant acceleration a is given by v² = v0² ± 2a.
+func (d *Device) updateSpeed() bool {
+	distance := d.target - d.position
+	if d.acceleration <= 0 {
+		switch {
+		case distance > 0:
+			d.speed = d.maxSpeed
+		case distance < 0:
+			d.speed = -d.maxSpeed
+		default:
+			d.speed = 0
+			return false
+		}
+		return true
+	}
+
+	if distance == 0 {
+		// The target was moved to the current position.
+		d.speed = 0
+		return false
+	}
+	v := abs(d.speed)
+	towards := (d.speed > 0 && distance > 0) || (d.speed < 0 && distance < 0)
+	stopping := v * v / (2 * d.acceleration)
+	switch {
+	case v == 0:
+	case !towards || stopping >= abs(float32(distance)) || v > d.maxSpeed:
+		v = sqrt(v*v - 2*d.acceleration)
+	default:
+		v = sqrt(v*v + 2*d.acceleration)
+		if v > d.maxSpeed {
+			v = d.maxSpeed
+		}
+	}
+	if v == 0 {
+		// Start from rest, towards the target.
+		v = sqrt(2 * d.acceleration)
+		if v > d.maxSpeed {
+			v = d.maxSpeed
+		}
+		if distance < 0 {
+			v = -v
+		}
+		d.speed = v
+		return true
+	}
+	if d.speed < 0 {
+		v = -v
+	}
+	d.speed = v
+	return true
+}
+
+// step moves the motor by one step and updates its position.
+func (d *Device) step(forward bool) {
+	inc := uint8(2)
+	if d.mode == HalfStep {
+		inc = 1
+	}
+	if forward {
+		d.position++
+	} else {
+		d.position--
+		inc = 8 - inc
+	}
+	d.setPhase((d.phase + inc) % 8)
+}
+
+// Move rotates the motor the number of given steps
+// (negative steps will rotate it the opposite direction)
+func (d *Device) Move(steps int32) {
+	d.setPhase(d.phase)
+	d.MoveBy(steps)
+	d.RunToPosition()
 }
 
 // Off turns off all motor pins
@@ -76,38 +316,46 @@ func (d *Device) Off() {
 	}
 }
 
+// Motor returns motor i of the DualDevice, 0 or 1, to set its speed and
+// acceleration or to run it independently.
+func (d *DualDevice) Motor(i int) *Device {
+	return &d.devices[i]
+}
+
 // Move rotates the motors the number of given steps
 // (negative steps will rotate it the opposite direction)
 func (d *DualDevice) Move(stepsA, stepsB int32) {
-	min := uint8(1)
-	max := uint8(0)
-	var directions [2]bool
-	var minStep int32
-
-	directions[0] = stepsA > 0
-	directions[1] = stepsB > 0
-	if stepsA < 0 {
-		stepsA = -stepsA
-	}
-	if stepsB < 0 {
-		stepsB = -stepsB
-	}
-	if stepsB > stepsA {
-		stepsA, stepsB = stepsB, stepsA
-		max, min = min, max
-	}
-	d.devices[0].stepMotor(d.devices[0].stepNumber)
-	d.devices[1].stepMotor(d.devices[1].stepNumber)
-	stepsA += int32(d.devices[max].stepNumber)
-	minStep = int32(d.devices[min].stepNumber)
-	for s := int32(d.devices[max].stepNumber); s < stepsA; s++ {
-		time.Sleep(time.Duration(d.devices[0].stepDelay) * time.Microsecond)
-		d.devices[max].moveDirectionSteps(directions[max], s)
-
-		if ((s * stepsB) / stepsA) > minStep {
-			minStep++
-			d.devices[min].moveDirectionSteps(directions[min], minStep)
+	d.devices[0].MoveBy(stepsA)
+	d.devices[1].MoveBy(stepsB)
+	d.RunToPosition()
+}
+
+// RunToPosition runs both motors until they reach their target, and until
+// the interval of their last step has passed.
+func (d *DualDevice) RunToPosition() {
+	for {
+		runA := d.devices[0].Run()
+		runB := d.devices[1].Run()
+		if !runA && !runB {
+			break
 		}
+		// Sleep until the first of the next steps.
+		var wait time.Duration = -1
+		for i, running := range [2]bool{runA, runB} {
+			if w := d.devices[i].wait(); running && (wait < 0 || w < wait) {
+				wait = w
+			}
+		}
+		if wait > 0 {
+			d.devices[0].Clock.Sleep(wait)
+		}
+	}
+	wait := d.devices[0].wait()
+	if w := d.devices[1].wait(); w > wait {
+		wait = w
+	}
+	if wait > 0 {
+		d.devices[0].Clock.Sleep(wait)
 	}
 }
 
@@ -117,44 +365,24 @@ func (d *DualDevice) Off() {
 	d.devices[1].Off()
 }
 
-// stepMotor changes the pins' state to the correct step
-func (d *Device) stepMotor(step uint8) {
-	switch step {
-	case 0:
-		d.pins[0].High()
-		d.pins[1].Low()
-		d.pins[2].High()
-		d.pins[3].Low()
-		break
-	case 1:
-		d.pins[0].Low()
-		d.pins[1].High()
-		d.pins[2].High()
-		d.pins[3].Low()
-		break
-	case 2:
-		d.pins[0].Low()
-		d.pins[1].High()
-		d.pins[2].Low()
-		d.pins[3].High()
-		break
-	case 3:
-		d.pins[0].High()
-		d.pins[1].Low()
-		d.pins[2].Low()
-		d.pins[3].High()
-		break
-	}
-	d.stepNumber = step
-}
-
-// moveDirectionSteps uses the direction to calculate the correct step and change the motor to it.
-// Direction true: 0, 1, 2, 3, 0, 1, 2, ...
-// Direction false: 0, 3, 2, 1, 0, 3, 2, ...
-func (d *Device) moveDirectionSteps(direction bool, step int32) {
-	if direction {
-		d.stepMotor(uint8(step % 4))
-	} else {
-		d.stepMotor(uint8((step + 2*(step%2)) % 4))
+// setPhase changes the pins' state to the given half step.
+func (d *Device) setPhase(phase uint8) {
+	for i, level := range phases[phase] {
+		d.pins[i].Set(level)
+	}
+	d.phase = phase
+}
+
+func abs(x float32) float32 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func sqrt(x float32) float32 {
+	if x <= 0 {
+		return 0
 	}
+	return float32(math.Sqrt(float64(x)))
 }
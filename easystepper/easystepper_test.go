@@ -0,0 +1,230 @@
+package easystepper
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"tinygo.org/x/drivers/tester"
+)
+
+func newTestDevice(steps, rpm int32) (*Device, [4]*tester.Pin, *tester.Clock) {
+	var pins [4]*tester.Pin
+	for i := range pins {
+		pins[i] = tester.NewPin()
+	}
+	d := New(pins[0], pins[1], pins[2], pins[3], steps, rpm)
+	clock := tester.NewClock()
+	d.Clock = clock
+	d.Configure()
+	return &d, pins, clock
+}
+
+// levels returns the state of the 4 pins.
+func levels(pins [4]*tester.Pin) [4]bool {
+	return [4]bool{pins[0].Get(), pins[1].Get(), pins[2].Get(), pins[3].Get()}
+}
+
+// runPhases runs the motor to its target and returns the successive states
+// of the pins.
+func runPhases(d *Device, pins [4]*tester.Pin) [][4]bool {
+	var seen [][4]bool
+	for running := true; running; {
+		running = d.Run()
+		if len(seen) == 0 || seen[len(seen)-1] != levels(pins) {
+			seen = append(seen, levels(pins))
+		}
+		d.Clock.Sleep(time.Millisecond)
+	}
+	return seen
+}
+
+func TestMove(t *testing.T) {
+	c := qt.New(t)
+	d, pins, clock := newTestDevice(200, 60)
+	c.Assert(d.MaxSpeed(), qt.Equals, float32(200))
+
+	d.Move(10)
+	c.Assert(d.Position(), qt.Equals, int32(10))
+	c.Assert(d.DistanceToGo(), qt.Equals, int32(0))
+	c.Assert(d.Speed(), qt.Equals, float32(0))
+	// The first step is made right away, then one every 5ms, and Move
+	// returns once the last one is done.
+	c.Assert(clock.Sleeps, qt.HasLen, 10)
+	for _, s := range clock.Sleeps {
+		c.Assert(s, qt.Equals, 5*time.Millisecond)
+	}
+	c.Assert(levels(pins), qt.Equals, phases[(10*2)%8])
+
+	d.Move(-3)
+	c.Assert(d.Position(), qt.Equals, int32(7))
+	c.Assert(levels(pins), qt.Equals, phases[(7*2)%8])
+}
+
+func TestStepModes(t *testing.T) {
+	c := qt.New(t)
+	d, pins, _ := newTestDevice(200, 60)
+
+	d.MoveTo(4)
+	seen := runPhases(d, pins)
+	c.Assert(seen, qt.DeepEquals, [][4]bool{phases[2], phases[4], phases[6], phases[0]})
+
+	// The first half step waits for the interval of the last full step.
+	d.SetMode(HalfStep)
+	d.MoveTo(0)
+	seen = runPhases(d, pins)
+	c.Assert(seen, qt.DeepEquals, [][4]bool{phases[0], phases[7], phases[6], phases[5], phases[4]})
+	c.Assert(d.Position(), qt.Equals, int32(0))
+}
+
+func TestRunNonBlocking(t *testing.T) {
+	c := qt.New(t)
+	d, _, clock := newTestDevice(200, 60)
+
+	c.Assert(d.Run(), qt.IsFalse)
+	d.MoveBy(2)
+	c.Assert(d.Run(), qt.IsTrue)
+	c.Assert(d.Position(), qt.Equals, int32(1))
+
+	// The next step is not due yet.
+	clock.Advance(4 * time.Millisecond)
+	c.Assert(d.Run(), qt.IsTrue)
+	c.Assert(d.Position(), qt.Equals, int32(1))
+
+	// The last step stops the motor.
+	clock.Advance(time.Millisecond)
+	c.Assert(d.Run(), qt.IsFalse)
+	c.Assert(d.Position(), qt.Equals, int32(2))
+	c.Assert(d.Speed(), qt.Equals, float32(0))
+	c.Assert(clock.Sleeps, qt.HasLen, 0)
+
+	// A step from rest waits for the interval of the last step.
+	d.MoveBy(1)
+	clock.Advance(4 * time.Millisecond)
+	c.Assert(d.Run(), qt.IsTrue)
+	c.Assert(d.Position(), qt.Equals, int32(2))
+	clock.Advance(time.Millisecond)
+	c.Assert(d.Run(), qt.IsFalse)
+	c.Assert(d.Position(), qt.Equals, int32(3))
+}
+
+func TestMoveBackToBack(t *testing.T) {
+	c := qt.New(t)
+	d, _, clock := newTestDevice(200, 60)
+
+	// The steps of consecutive moves keep the interval of the speed.
+	d.Move(2)
+	d.Move(2)
+	d.Move(2)
+	c.Assert(d.Position(), qt.Equals, int32(6))
+	c.Assert(clock.Elapsed(), qt.Equals, 6*5*time.Millisecond)
+	for _, s := range clock.Sleeps {
+		c.Assert(s, qt.Equals, 5*time.Millisecond)
+	}
+}
+
+func TestAcceleration(t *testing.T) {
+	c := qt.New(t)
+	d, _, clock := newTestDevice(200, 60)
+	d.SetMaxSpeed(400)
+	d.SetAcceleration(1000)
+
+	d.MoveTo(300)
+	var maxSpeed float32
+	for d.Run() {
+		if d.Speed() > maxSpeed {
+			maxSpeed = d.Speed()
+		}
+		clock.Sleep(d.wait())
+	}
+	c.Assert(d.Position(), qt.Equals, int32(300))
+	c.Assert(d.Speed(), qt.Equals, float32(0))
+	c.Assert(maxSpeed, qt.Equals, float32(400))
+
+	// The intervals shrink while accelerating, stay constant at the maximum
+	// speed, then grow while decelerating.
+	intervals := clock.Sleeps
+	c.Assert(len(intervals) > 150, qt.IsTrue)
+	for i := 1; i < 50; i++ {
+		c.Assert(intervals[i] <= intervals[i-1], qt.IsTrue, qt.Commentf("step %d", i))
+	}
+	c.Assert(intervals[len(intervals)/2], qt.Equals, 2500*time.Microsecond)
+	for i := len(intervals) - 50; i < len(intervals); i++ {
+		c.Assert(intervals[i] >= intervals[i-1], qt.IsTrue, qt.Commentf("step %d", i))
+	}
+	// Accelerating from 0 to 400 steps/s at 1000 steps/s² takes 0.4s and 80
+	// steps, the same to stop, and the 140 remaining steps take 0.35s.
+	c.Assert(clock.Elapsed() > 1000*time.Millisecond, qt.IsTrue)
+	c.Assert(clock.Elapsed() < 1200*time.Millisecond, qt.IsTrue)
+}
+
+func TestReverse(t *testing.T) {
+	c := qt.New(t)
+	d, _, clock := newTestDevice(200, 60)
+	d.SetAcceleration(1000)
+
+	d.MoveTo(100)
+	for d.Position() < 50 {
+		d.Run()
+		clock.Sleep(d.wait())
+	}
+	// The motor slows down past 50 before turning back.
+	d.MoveTo(0)
+	var furthest int32
+	for d.Run() {
+		if d.Position() > furthest {
+			furthest = d.Position()
+		}
+		clock.Sleep(d.wait())
+	}
+	c.Assert(furthest > 50, qt.IsTrue)
+	c.Assert(d.Position(), qt.Equals, int32(0))
+}
+
+func TestStop(t *testing.T) {
+	c := qt.New(t)
+	d, _, clock := newTestDevice(200, 60)
+	d.SetAcceleration(1000)
+
+	d.MoveTo(1000)
+	for d.Position() < 100 {
+		d.Run()
+		clock.Sleep(d.wait())
+	}
+	speed := d.Speed()
+	d.Stop()
+	c.Assert(d.Target(), qt.Equals, int32(100+int32(speed*speed/2000)+1))
+	d.RunToPosition()
+	c.Assert(d.Position(), qt.Equals, d.Target())
+	c.Assert(d.Speed(), qt.Equals, float32(0))
+
+	d.SetPosition(0)
+	c.Assert(d.Position(), qt.Equals, int32(0))
+	c.Assert(d.Run(), qt.IsFalse)
+}
+
+func TestDualDevice(t *testing.T) {
+	c := qt.New(t)
+	var pins [8]*tester.Pin
+	for i := range pins {
+		pins[i] = tester.NewPin()
+	}
+	d := NewDual(pins[0], pins[1], pins[2], pins[3], pins[4], pins[5], pins[6], pins[7], 200, 60)
+	clock := tester.NewClock()
+	d.Motor(0).Clock = clock
+	d.Motor(1).Clock = clock
+	d.Configure()
+
+	// Both motors move at the same speed and stop independently.
+	d.Move(20, -10)
+	c.Assert(d.Motor(0).Position(), qt.Equals, int32(20))
+	c.Assert(d.Motor(1).Position(), qt.Equals, int32(-10))
+	c.Assert(clock.Elapsed(), qt.Equals, 20*5*time.Millisecond)
+
+	d.Motor(1).SetMaxSpeed(400)
+	d.Move(10, 20)
+	c.Assert(d.Motor(0).Position(), qt.Equals, int32(30))
+	c.Assert(d.Motor(1).Position(), qt.Equals, int32(10))
+	c.Assert(clock.Elapsed(), qt.Equals, 20*5*time.Millisecond+20*2500*time.Microsecond)
+}
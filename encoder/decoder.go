@@ -0,0 +1,109 @@
+package encoder
+
+// Quadrature signals go through the states (A, B) = 00, 10, 11, 01 in this
+// order when the encoder turns clockwise, A leading B, and in the reverse
+// order counterclockwise. position gives the index of each state, as
+// A<<1 | B, in the clockwise sequence.
+var position = [4]uint8{0b00: 0, 0b10: 1, 0b11: 2, 0b01: 3}
+
+// Decoder is the state machine that decodes the quadrature signals of an
+// encoder into detents. Transitions that skip a state, where A and B change
+// at once, are rejected and counted as errors. It can be fed with recorded
+// A/B sequences for testing.
+//
+// The detents are counted when the signals reach a rest state: 00, where
+// the encoder rests at a detent, and also 11 for encoders with 2 steps per
+// detent. The quadrature steps are counted again from each rest state,
+// so that the decoder recovers from a rejected transition or from a start
+// between two detents.
+type Decoder struct {
+	state  uint8
+	sub    int8
+	steps  int8
+	errors uint32
+}
+
+// NewDecoder returns a decoder for encoders with 1, 2 or 4 quadrature steps
+// per detent, starting in state (a, b). Other values of stepsPerDetent are
+// treated as 4, the most common.
+func NewDecoder(stepsPerDetent int, a, b bool) *Decoder {
+	d := &Decoder{}
+	d.Reset(stepsPerDetent, a, b)
+	return d
+}
+
+// Reset resets the decoder to state (a, b), as NewDecoder.
+func (d *Decoder) Reset(stepsPerDetent int, a, b bool) {
+	switch stepsPerDetent {
+	case 1, 2:
+		d.steps = int8(stepsPerDetent)
+	default:
+		d.steps = 4
+	}
+	d.state = abState(a, b)
+	d.sub = 0
+	d.errors = 0
+}
+
+// Feed processes a new reading of A and B, and returns the detents moved:
+// 1 clockwise, -1 counterclockwise, or 0. Readings identical to the previous
+// one are ignored, so the pins can be sampled faster than they change.
+func (d *Decoder) Feed(a, b bool) int {
+	state := abState(a, b)
+	if state == d.state {
+		return 0
+	}
+	diff := (position[state] - position[d.state]) & 3
+	d.state = state
+	switch diff {
+	case 1:
+		d.sub++
+	case 3:
+		d.sub--
+	default:
+		// Both signals changed: a state was missed, so the direction is
+		// unknown.
+		d.errors++
+	}
+	if !d.rest(state) {
+		return 0
+	}
+	// A detent is counted when most of its steps were seen, even if a step
+	// was missed on the way.
+	sub := d.sub
+	d.sub = 0
+	switch {
+	case sub > d.steps/2:
+		return 1
+	case sub < -d.steps/2:
+		return -1
+	}
+	return 0
+}
+
+// rest returns whether the encoder rests at a detent in state.
+func (d *Decoder) rest(state uint8) bool {
+	switch d.steps {
+	case 1:
+		return true
+	case 2:
+		return state == 0b00 || state == 0b11
+	}
+	return state == 0b00
+}
+
+// Errors returns the number of invalid transitions that were rejected.
+func (d *Decoder) Errors() uint32 {
+	return d.errors
+}
+
+func abState(a, b bool) uint8 {
+	var s uint8
+	if a {
+		s |= 0b10
+	}
+	if b {
+		s |= 0b01
+	}
+	return s
+}
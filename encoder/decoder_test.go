@@ -0,0 +1,62 @@
+package encoder
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+// feed parses a recorded sequence of A/B states such as "11 01 00" and
+// returns the sum of the detents moved.
+func feed(d *Decoder, seq string) int {
+	total := 0
+	for i := 0; i+1 < len(seq); i += 3 {
+		total += d.Feed(seq[i] == '1', seq[i+1] == '1')
+	}
+	return total
+}
+
+func TestDecoder(t *testing.T) {
+	tests := []struct {
+		name   string
+		steps  int
+		seq    string
+		want   int
+		errors uint32
+	}{
+		{"clockwise", 4, "00 10 11 01 00 10 11 01 00", 2, 0},
+		{"counterclockwise", 4, "00 01 11 10 00", -1, 0},
+		{"incomplete detent", 4, "00 10 11 01", 0, 0},
+		{"bounce", 4, "00 10 00 10 11 01 00", 1, 0},
+		{"back and forth", 4, "00 10 11 10 00", 0, 0},
+		{"repeated states", 4, "00 00 10 10 11 11 01 01 00", 1, 0},
+		{"invalid transition", 4, "00 11 01 00", 0, 1},
+		{"missed step", 4, "00 10 11 00 10 11", 0, 1},
+		{"detent after missed step", 4, "00 10 11 00 10 11 01 00", 1, 1},
+		{"two steps", 2, "00 10 11 01 00", 2, 0},
+		{"one step", 1, "00 01 11 10 00", -4, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := qt.New(t)
+			d := NewDecoder(test.steps, false, false)
+			c.Assert(feed(d, test.seq[3:]), qt.Equals, test.want)
+			c.Assert(d.Errors(), qt.Equals, test.errors)
+		})
+	}
+}
+
+func TestDecoderDefaultSteps(t *testing.T) {
+	c := qt.New(t)
+	d := NewDecoder(3, false, false)
+	c.Assert(feed(d, "10 11 01"), qt.Equals, 0)
+	c.Assert(feed(d, "00"), qt.Equals, 1)
+}
+
+func TestDecoderStartBetweenDetents(t *testing.T) {
+	c := qt.New(t)
+	d := NewDecoder(4, true, true)
+	c.Assert(feed(d, "01 00"), qt.Equals, 0)
+	c.Assert(feed(d, "10 11"), qt.Equals, 0)
+	c.Assert(feed(d, "01 00"), qt.Equals, 1)
+}
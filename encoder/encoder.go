@@ -0,0 +1,173 @@
+// Package encoder implements a driver for incremental rotary encoders with
+// quadrature outputs, such as the KY-040 and the EC11, and their optional
+// push button.
+//
+// The A and B pins are either polled by calling Update regularly, or
+// sampled on pin-change interrupts with EnableInterrupts. Fast turns can be
+// accelerated, which makes long menus quick to scroll:
+//
+//	enc := encoder.New(machine.D2, machine.D3, machine.D4)
+//	enc.Configure(encoder.Config{Acceleration: 50 * time.Millisecond})
+//	for {
+//		enc.Update()
+//		if delta := enc.Delta(); delta != 0 {
+//			println("moved", delta)
+//		}
+//		for e, ok := enc.Button.Next(); ok; e, ok = enc.Button.Next() {
+//			println(e.Type.String())
+//		}
+//		time.Sleep(time.Millisecond)
+//	}
+package encoder // import "tinygo.org/x/drivers/encoder"
+
+import (
+	"sync/atomic"
+	"time"
+
+	"tinygo.org/x/drivers"
+	"tinygo.org/x/drivers/button"
+)
+
+const defaultMaxAcceleration = 10
+
+// Config holds the settings of an encoder.
+type Config struct {
+	// StepsPerDetent is the number of quadrature steps between two detents
+	// of the encoder: 1, 2 or 4. It is 4 if zero.
+	StepsPerDetent int
+
+	// Acceleration enables acceleration: detents closer than this are
+	// counted as several, up to MaxAcceleration for the fastest turns. It
+	// is disabled if zero.
+	Acceleration time.Duration
+
+	// MaxAcceleration is the number of counts of a detent at the highest
+	// speed. It is 10 if zero.
+	MaxAcceleration int
+
+	// Button holds the timings of the push button.
+	Button button.Config
+}
+
+// Device is a rotary encoder.
+type Device struct {
+	a, b    drivers.Pin
+	sw      drivers.Pin
+	config  Config
+	decoder Decoder
+
+	// position and delta are updated from interrupts.
+	position   int32
+	delta      int32
+	last       time.Time
+	interrupts bool
+
+	// Button reports the events of the push button. It is nil if the
+	// encoder has no button.
+	Button *button.Group
+
+	// Clock is used to measure the speed of the encoder for acceleration,
+	// and by the button. It is drivers.SystemClock by default.
+	Clock drivers.Clock
+}
+
+// New returns an encoder on pins a and b, with the push button on sw. The
+// button is active low. sw may be nil or machine.NoPin for encoders without
+// a button.
+func New(a, b, sw drivers.Pin) *Device {
+	d := &Device{
+		a:     a,
+		b:     b,
+		sw:    sw,
+		Clock: drivers.SystemClock,
+	}
+	if !drivers.IsNoPin(sw) {
+		d.Button = button.New(button.Pins{Pins: []drivers.Pin{sw}}, 1)
+	}
+	return d
+}
+
+// Configure configures the pins, including the button pin, as inputs with
+// pull-ups and applies config.
+// The position is reset to 0.
+func (d *Device) Configure(config Config) {
+	if config.MaxAcceleration == 0 {
+		config.MaxAcceleration = defaultMaxAcceleration
+	}
+	d.config = config
+	drivers.ConfigurePin(d.a, drivers.PinInputPullup)
+	drivers.ConfigurePin(d.b, drivers.PinInputPullup)
+	d.decoder.Reset(config.StepsPerDetent, !d.a.Get(), !d.b.Get())
+	atomic.StoreInt32(&d.position, 0)
+	atomic.StoreInt32(&d.delta, 0)
+	if d.Button != nil {
+		drivers.ConfigurePin(d.sw, drivers.PinInputPullup)
+		d.Button.Clock = d.Clock
+		d.Button.Configure(config.Button)
+	}
+}
+
+// Update samples the A and B pins, unless interrupts are enabled, and reads
+// the button. It must be called regularly, often enough not to miss a
+// quadrature step when polling.
+func (d *Device) Update() error {
+	if !d.interrupts {
+		d.Sample()
+	}
+	if d.Button != nil {
+		return d.Button.Update()
+	}
+	return nil
+}
+
+// Sample reads the A and B pins once and updates the position. It is called
+// by Update, or on each pin change when interrupts are enabled.
+func (d *Device) Sample() {
+	// The pins are active low: both are pulled high at a detent.
+	dir := d.decoder.Feed(!d.a.Get(), !d.b.Get())
+	if dir == 0 {
+		return
+	}
+	n := int32(d.accelerate())
+	atomic.AddInt32(&d.position, int32(dir)*n)
+	atomic.AddInt32(&d.delta, int32(dir)*n)
+}
+
+// accelerate returns the number of counts of a detent, from the time since
+// the previous one.
+func (d *Device) accelerate() int {
+	if d.config.Acceleration <= 0 {
+		return 1
+	}
+	now := d.Clock.Now()
+	interval := now.Sub(d.last)
+	d.last = now
+	if interval >= d.config.Acceleration {
+		return 1
+	}
+	extra := time.Duration(d.config.MaxAcceleration-1) * (d.config.Acceleration - interval) / d.config.Acceleration
+	return 1 + int(extra)
+}
+
+// Position returns the position of the encoder, in detents counted since
+// Configure, positive clockwise.
+func (d *Device) Position() int {
+	return int(atomic.LoadInt32(&d.position))
+}
+
+// SetPosition changes the current position.
+func (d *Device) SetPosition(position int) {
+	atomic.StoreInt32(&d.position, int32(position))
+}
+
+// Delta returns the change of the position since the previous call to
+// Delta.
+func (d *Device) Delta() int {
+	return int(atomic.SwapInt32(&d.delta, 0))
+}
+
+// Errors returns the number of invalid transitions of A and B, which are
+// caused by a sampling rate too low for the speed of the encoder.
+func (d *Device) Errors() uint32 {
+	return d.decoder.Errors()
+}
@@ -0,0 +1,12 @@
+//go:build !tinygo
+// +build !tinygo
+
+package encoder
+
+import "tinygo.org/x/drivers"
+
+// EnableInterrupts is only supported by machine pins, so it always fails
+// outside of TinyGo. See the TinyGo version.
+func (d *Device) EnableInterrupts() error {
+	return drivers.WrapError("encoder: interrupts need machine pins", drivers.ErrInvalidConfig)
+}
@@ -0,0 +1,135 @@
+package encoder
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"tinygo.org/x/drivers"
+	"tinygo.org/x/drivers/button"
+	"tinygo.org/x/drivers/tester"
+)
+
+// mockEncoder drives the A and B pins of a simulated encoder.
+type mockEncoder struct {
+	a, b, sw *tester.Pin
+	state    int
+}
+
+// clockwise is the sequence of (A, B) states when turning clockwise, from
+// the detent where both are high.
+var clockwise = [4][2]bool{{true, true}, {false, true}, {false, false}, {true, false}}
+
+func newMockEncoder() *mockEncoder {
+	m := &mockEncoder{a: tester.NewPin(), b: tester.NewPin(), sw: tester.NewPin()}
+	m.a.Input = func() bool { return clockwise[m.state&3][0] }
+	m.b.Input = func() bool { return clockwise[m.state&3][1] }
+	m.sw.Input = func() bool { return true }
+	return m
+}
+
+// turn moves the encoder by the given number of detents, calling update
+// after each step.
+func (m *mockEncoder) turn(detents int, update func()) {
+	dir := 1
+	if detents < 0 {
+		dir, detents = -1, -detents
+	}
+	for i := 0; i < detents*4; i++ {
+		m.state += dir
+		update()
+	}
+}
+
+func newTestEncoder(c *qt.C, config Config) (*Device, *mockEncoder, *tester.Clock) {
+	m := newMockEncoder()
+	clock := tester.NewClock()
+	d := New(m.a, m.b, m.sw)
+	d.Clock = clock
+	d.Configure(config)
+	c.Assert(m.a.Mode(), qt.Equals, drivers.PinInputPullup)
+	return d, m, clock
+}
+
+func TestPosition(t *testing.T) {
+	c := qt.New(t)
+	d, m, _ := newTestEncoder(c, Config{})
+	update := func() { c.Assert(d.Update(), qt.IsNil) }
+
+	m.turn(3, update)
+	c.Assert(d.Position(), qt.Equals, 3)
+	m.turn(-5, update)
+	c.Assert(d.Position(), qt.Equals, -2)
+	c.Assert(d.Delta(), qt.Equals, -2)
+	c.Assert(d.Delta(), qt.Equals, 0)
+
+	d.SetPosition(10)
+	m.turn(1, update)
+	c.Assert(d.Position(), qt.Equals, 11)
+	c.Assert(d.Errors(), qt.Equals, uint32(0))
+}
+
+func TestAcceleration(t *testing.T) {
+	c := qt.New(t)
+	d, m, clock := newTestEncoder(c, Config{
+		Acceleration:    100 * time.Millisecond,
+		MaxAcceleration: 5,
+	})
+
+	// Slow turns are not accelerated.
+	slow := func() {
+		clock.Advance(50 * time.Millisecond)
+		d.Update()
+	}
+	m.turn(2, slow)
+	c.Assert(d.Delta(), qt.Equals, 2)
+
+	// Detents 20ms apart count as 1 + 4*80/100 = 4.
+	fast := func() {
+		clock.Advance(5 * time.Millisecond)
+		d.Update()
+	}
+	m.turn(2, fast)
+	c.Assert(d.Delta(), qt.Equals, 4*2)
+}
+
+func TestButton(t *testing.T) {
+	c := qt.New(t)
+	d, m, clock := newTestEncoder(c, Config{
+		Button: button.Config{DoubleClick: -1},
+	})
+	c.Assert(m.sw.Mode(), qt.Equals, drivers.PinInputPullup)
+
+	pressed := false
+	m.sw.Input = func() bool { return !pressed }
+	for _, p := range []bool{true, false} {
+		pressed = p
+		for i := 0; i < 50; i++ {
+			d.Update()
+			clock.Advance(time.Millisecond)
+		}
+	}
+	var types []button.EventType
+	for e, ok := d.Button.Next(); ok; e, ok = d.Button.Next() {
+		types = append(types, e.Type)
+	}
+	c.Assert(types, qt.DeepEquals, []button.EventType{button.Down, button.Up, button.Click})
+}
+
+func TestNoButton(t *testing.T) {
+	c := qt.New(t)
+	m := newMockEncoder()
+	d := New(m.a, m.b, nil)
+	d.Configure(Config{})
+	c.Assert(d.Button, qt.IsNil)
+	c.Assert(d.Update(), qt.IsNil)
+}
+
+func TestEnableInterrupts(t *testing.T) {
+	c := qt.New(t)
+	d, _, _ := newTestEncoder(c, Config{})
+	err := d.EnableInterrupts()
+	c.Assert(errors.Is(err, drivers.ErrInvalidConfig), qt.IsTrue)
+}
@@ -0,0 +1,33 @@
+//go:build tinygo
+// +build tinygo
+
+package encoder
+
+import (
+	"machine"
+
+	"tinygo.org/x/drivers"
+)
+
+// EnableInterrupts samples the A and B pins on each of their changes, so
+// that Update does not need to be called for the position to be tracked. It
+// is only needed to read the button then. The pins must be machine.Pin
+// values that support pin-change interrupts.
+func (d *Device) EnableInterrupts() error {
+	a, okA := d.a.(machine.Pin)
+	b, okB := d.b.(machine.Pin)
+	if !okA || !okB {
+		return drivers.WrapError("encoder: interrupts need machine pins", drivers.ErrInvalidConfig)
+	}
+	callback := func(machine.Pin) {
+		d.Sample()
+	}
+	if err := a.SetInterrupt(machine.PinToggle, callback); err != nil {
+		return err
+	}
+	if err := b.SetInterrupt(machine.PinToggle, callback); err != nil {
+		return err
+	}
+	d.interrupts = true
+	return nil
+}
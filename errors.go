@@ -0,0 +1,62 @@
+package drivers
+
+import "errors"
+
+// Errors shared by the drivers in this repository. Drivers usually return
+// them wrapped with some context, so they should be tested with errors.Is:
+//
+//	if errors.Is(err, drivers.ErrNotConnected) {
+//		println("sensor not found")
+//	}
+var (
+	// ErrNotConnected is returned when a device does not respond, or
+	// responds with an unexpected identity.
+	ErrNotConnected = errors.New("device not connected")
+
+	// ErrTimeout is returned when a device did not become ready in time.
+	ErrTimeout = errors.New("timeout")
+
+	// ErrInvalidConfig is returned when a configuration is not supported
+	// by the device, or is rejected by it.
+	ErrInvalidConfig = errors.New("invalid configuration")
+
+	// ErrChecksum is returned when the checksum or CRC of data received
+	// from a device does not match.
+	ErrChecksum = errors.New("checksum mismatch")
+
+	// ErrOutOfBounds is returned when drawing outside the area of a
+	// display.
+	ErrOutOfBounds = errors.New("rectangle coordinates outside display area")
+
+	// ErrBufferSize is returned when the length of a buffer does not match
+	// the size of the data to transfer, such as the pixels of a rectangle.
+	ErrBufferSize = errors.New("buffer length does not match with rectangle size")
+)
+
+// WrapError returns an error whose message is context followed by the
+// message of err, and which matches err with errors.Is and errors.As. It
+// returns nil if err is nil, so it can wrap the result of a bus transaction
+// directly:
+//
+//	return drivers.WrapError("bme280: read calibration", err)
+//
+// It is a lighter alternative to fmt.Errorf with the %w verb.
+func WrapError(context string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &wrapError{context: context, err: err}
+}
+
+type wrapError struct {
+	context string
+	err     error
+}
+
+func (e *wrapError) Error() string {
+	return e.context + ": " + e.err.Error()
+}
+
+func (e *wrapError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,25 @@
+package drivers_test
+
+import (
+	"errors"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"tinygo.org/x/drivers"
+)
+
+func TestWrapError(t *testing.T) {
+	c := qt.New(t)
+	c.Assert(drivers.WrapError("bme280: read", nil), qt.IsNil)
+
+	err := drivers.WrapError("bmp388", drivers.ErrNotConnected)
+	c.Assert(err, qt.ErrorMatches, "bmp388: device not connected")
+	c.Assert(errors.Is(err, drivers.ErrNotConnected), qt.IsTrue)
+	c.Assert(errors.Is(err, drivers.ErrTimeout), qt.IsFalse)
+
+	// Wrapping twice keeps the sentinel reachable.
+	err = drivers.WrapError("sensor", err)
+	c.Assert(err, qt.ErrorMatches, "sensor: bmp388: device not connected")
+	c.Assert(errors.Is(err, drivers.ErrNotConnected), qt.IsTrue)
+}
@@ -32,6 +32,10 @@ import (
 type Device struct {
 	bus drivers.UART
 
+	// Clock is used to pause between reads of a response. It is
+	// drivers.SystemClock if nil.
+	Clock drivers.Clock
+
 	// command responses that come back from the ESP8266/ESP32
 	response []byte
 
@@ -44,7 +48,16 @@ var ActiveDevice *Device
 
 // New returns a new espat driver. Pass in a fully configured UART bus.
 func New(b drivers.UART) *Device {
-	return &Device{bus: b, response: make([]byte, 512), socketdata: make([]byte, 0, 1024)}
+	return &Device{bus: b, Clock: drivers.SystemClock, response: make([]byte, 512), socketdata: make([]byte, 0, 1024)}
+}
+
+// clock returns the Clock of the device, or drivers.SystemClock for a
+// Device that was not created by New.
+func (d *Device) clock() drivers.Clock {
+	if d.Clock == nil {
+		return drivers.SystemClock
+	}
+	return d.Clock
 }
 
 // Configure sets up the device for communication.
@@ -190,7 +203,7 @@ func (d *Device) Response(timeout int) ([]byte, error) {
 			return nil, errors.New("response timeout error:" + string(d.response[start:end]))
 		}
 
-		time.Sleep(time.Duration(pause) * time.Millisecond)
+		d.clock().Sleep(time.Duration(pause) * time.Millisecond)
 	}
 }
 
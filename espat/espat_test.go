@@ -0,0 +1,94 @@
+package espat
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"tinygo.org/x/drivers/tester"
+)
+
+// newTestDevice returns a device whose pauses do not wait.
+func newTestDevice(uart *tester.UART) *Device {
+	dev := New(uart)
+	dev.Clock = tester.NewClock()
+	return dev
+}
+
+func TestConnected(t *testing.T) {
+	c := qt.New(t)
+	uart := tester.NewUART(c)
+	uart.Respond([]byte("AT\r\n"), []byte("AT\r\n\r\nOK\r\n"))
+
+	dev := newTestDevice(uart)
+	c.Assert(dev.Connected(), qt.Equals, true)
+	c.Assert(string(uart.Written()), qt.Equals, "AT\r\n")
+}
+
+func TestNotConnected(t *testing.T) {
+	c := qt.New(t)
+	uart := tester.NewUART(c)
+
+	dev := newTestDevice(uart)
+	c.Assert(dev.Connected(), qt.Equals, false)
+}
+
+func TestResponseError(t *testing.T) {
+	c := qt.New(t)
+	uart := tester.NewUART(c)
+	uart.Respond([]byte("AT+CWMODE=4\r\n"), []byte("AT+CWMODE=4\r\n\r\nERROR\r\n"))
+
+	dev := newTestDevice(uart)
+	dev.Set(WifiMode, "4")
+	_, err := dev.Response(100)
+	c.Assert(err, qt.ErrorMatches, `(?s)response error:.*ERROR.*`)
+}
+
+func TestResponseSlow(t *testing.T) {
+	c := qt.New(t)
+	uart := tester.NewUART(c)
+	uart.Respond([]byte("AT+GMR\r\n"), []byte("AT version:1.7.4.0\r\nOK\r\n")).Delay = 2
+
+	dev := newTestDevice(uart)
+	dev.Execute(Version)
+	_, err := dev.Response(200)
+	c.Assert(err, qt.ErrorMatches, `(?s)response timeout error:.*`)
+	c.Assert(dev.Clock.(*tester.Clock).Slept(), qt.Equals, 100*time.Millisecond)
+
+	dev.Execute(Version)
+	r, err := dev.Response(300)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(r), qt.Equals, "AT version:1.7.4.0\r\nOK\r\n")
+}
+
+func TestResponsePartial(t *testing.T) {
+	c := qt.New(t)
+	uart := tester.NewUART(c)
+	uart.Inject([]byte("AT+GMR\r\n"))
+	uart.Gap(1)
+	uart.Inject([]byte("\r\nOK\r\n"))
+
+	dev := newTestDevice(uart)
+	r, err := dev.Response(300)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(r), qt.Equals, "\r\nOK\r\n")
+	uart.AssertDone()
+}
+
+func TestResponseSocketData(t *testing.T) {
+	c := qt.New(t)
+	uart := tester.NewUART(c)
+	uart.Inject([]byte("\r\n+IPD,5:hello"))
+
+	dev := newTestDevice(uart)
+	r, err := dev.Response(100)
+	c.Assert(err, qt.IsNil)
+	c.Assert(r, qt.IsNil)
+	c.Assert(dev.IsSocketDataAvailable(), qt.Equals, true)
+
+	buf := make([]byte, 16)
+	n, err := dev.ReadSocket(buf)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(buf[:n]), qt.Equals, "hello")
+	c.Assert(dev.IsSocketDataAvailable(), qt.Equals, false)
+}
@@ -38,14 +38,14 @@ func main() {
 	}
 
 	for {
-		temp, err := sensor.ReadTemperature() // returns the temperature in centicelsius
-		press, err := sensor.ReadPressure()   // returns the pressure in centipascals
+		temp, err := sensor.ReadTemperature() // returns the temperature in millicelsius
+		press, err := sensor.ReadPressure()   // returns the pressure in millipascals
 
 		if err != nil {
 			println(err)
 		} else {
-			println("Temperature: " + strconv.FormatInt(int64(temp), 10) + " cC")
-			println("Pressure:    " + strconv.FormatInt(int64(press), 10) + " cPa\n")
+			println("Temperature: " + strconv.FormatInt(int64(temp), 10) + " mC")
+			println("Pressure:    " + strconv.FormatInt(int64(press), 10) + " mPa\n")
 		}
 
 		time.Sleep(time.Second)
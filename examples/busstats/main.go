@@ -0,0 +1,34 @@
+package main
+
+import (
+	"machine"
+	"time"
+
+	"tinygo.org/x/drivers/bme280"
+	"tinygo.org/x/drivers/busstats"
+)
+
+func main() {
+	machine.I2C0.Configure(machine.I2CConfig{})
+	bus := busstats.NewI2C(machine.I2C0)
+	// Keep the last transactions, to print them when something goes wrong.
+	ring := busstats.NewRing(16)
+	bus.Sink = ring
+
+	sensor := bme280.New(bus)
+	sensor.Configure()
+
+	for {
+		if _, err := sensor.ReadTemperature(); err != nil {
+			println("read failed, last transactions:")
+			for _, e := range ring.Events() {
+				println(" ", e.String())
+			}
+		}
+
+		stats := bus.Stats(bme280.Address)
+		println("transactions:", stats.Transactions, "errors:", stats.Errors,
+			"p90 latency:", stats.Latency.Percentile(90).String())
+		time.Sleep(2 * time.Second)
+	}
+}
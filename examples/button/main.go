@@ -0,0 +1,33 @@
+package main
+
+import (
+	"time"
+
+	"tinygo.org/x/drivers/button"
+	"tinygo.org/x/drivers/shifter"
+)
+
+var names = [...]string{"left", "up", "down", "right", "select", "start", "A", "B"}
+
+func main() {
+	buttons := shifter.NewButtons()
+	buttons.Configure()
+
+	g := button.New(button.SourceFunc(func() (uint32, error) {
+		v, err := buttons.ReadInput()
+		return uint32(v), err
+	}), len(names))
+	g.Configure(button.Config{
+		RepeatInterval: 100 * time.Millisecond,
+	})
+
+	for {
+		if err := g.Update(); err != nil {
+			println("error:", err.Error())
+		}
+		for e, ok := g.Next(); ok; e, ok = g.Next() {
+			println(names[e.Button], e.Type.String())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
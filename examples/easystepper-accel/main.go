@@ -0,0 +1,42 @@
+package main
+
+import (
+	"machine"
+	"time"
+
+	"tinygo.org/x/drivers/easystepper"
+)
+
+func main() {
+	motors := easystepper.NewDual(machine.P13, machine.P15, machine.P14, machine.P16,
+		machine.P8, machine.P9, machine.P12, machine.P1, 200, 75)
+	motors.Configure()
+
+	left, right := motors.Motor(0), motors.Motor(1)
+	left.SetMaxSpeed(400)
+	left.SetAcceleration(200)
+	right.SetMode(easystepper.HalfStep)
+	right.SetMaxSpeed(600)
+	right.SetAcceleration(800)
+
+	lastReport := time.Now()
+
+	for {
+		// Swap the direction of each motor when it reaches its target.
+		if left.DistanceToGo() == 0 {
+			left.MoveTo(2000 - left.Position())
+		}
+		if right.DistanceToGo() == 0 {
+			right.MoveTo(4000 - right.Position())
+		}
+		left.Run()
+		right.Run()
+
+		// Other work can be done while the motors turn, as long as Run is
+		// called often enough.
+		if time.Since(lastReport) > time.Second {
+			println("left:", left.Position(), "right:", right.Position())
+			lastReport = time.Now()
+		}
+	}
+}
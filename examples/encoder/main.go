@@ -0,0 +1,31 @@
+package main
+
+import (
+	"machine"
+	"time"
+
+	"tinygo.org/x/drivers/encoder"
+)
+
+func main() {
+	enc := encoder.New(machine.D2, machine.D3, machine.D4)
+	enc.Configure(encoder.Config{
+		Acceleration: 50 * time.Millisecond,
+	})
+
+	// Track the position on pin changes, and only poll the button.
+	if err := enc.EnableInterrupts(); err != nil {
+		println("polling the encoder:", err.Error())
+	}
+
+	for {
+		enc.Update()
+		if delta := enc.Delta(); delta != 0 {
+			println("position:", enc.Position())
+		}
+		for e, ok := enc.Button.Next(); ok; e, ok = enc.Button.Next() {
+			println("button:", e.Type.String())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"image/color"
+	"machine"
+
+	"tinygo.org/x/drivers/graphics"
+	"tinygo.org/x/drivers/st7789"
+)
+
+func main() {
+	machine.SPI0.Configure(machine.SPIConfig{
+		Frequency: 8000000,
+		Mode:      0,
+	})
+	display := st7789.New(machine.SPI0,
+		machine.P6, // TFT_RESET
+		machine.P7, // TFT_DC
+		machine.P8, // TFT_CS
+		machine.P9) // TFT_LITE
+
+	display.Configure(st7789.Config{
+		Rotation:  st7789.NO_ROTATION,
+		RowOffset: 80,
+	})
+
+	white := color.RGBA{255, 255, 255, 255}
+	red := color.RGBA{255, 0, 0, 255}
+	green := color.RGBA{0, 255, 0, 255}
+	blue := color.RGBA{0, 0, 255, 255}
+	yellow := color.RGBA{255, 255, 0, 255}
+	black := color.RGBA{0, 0, 0, 255}
+
+	display.FillScreen(black)
+
+	// The st7789 fills rectangles itself, so the filled shapes are drawn
+	// with a few bus transactions per line.
+	graphics.FilledRoundedRectangle(&display, 10, 10, 100, 60, 12, blue)
+	graphics.RoundedRectangle(&display, 10, 10, 100, 60, 12, white)
+	graphics.FilledCircle(&display, 180, 40, 30, red)
+	graphics.Circle(&display, 180, 40, 34, white)
+	graphics.FilledEllipse(&display, 60, 120, 50, 25, green)
+	graphics.Arc(&display, 180, 120, 30, 180, 0, yellow)
+	graphics.FilledTriangle(&display, 20, 220, 60, 160, 100, 220, yellow)
+	graphics.FilledPolygon(&display, []graphics.Point{
+		{X: 140, Y: 170}, {X: 230, Y: 170}, {X: 200, Y: 200},
+		{X: 230, Y: 230}, {X: 140, Y: 230}, {X: 170, Y: 200},
+	}, red)
+
+	for x := int16(0); x < 240; x += 20 {
+		graphics.Line(&display, x, 239, 239-x, 0, white)
+	}
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"machine"
+	"time"
+
+	"tinygo.org/x/drivers/i2cscan"
+)
+
+func main() {
+	machine.I2C0.Configure(machine.I2CConfig{})
+
+	for {
+		println("scanning...")
+		for _, dev := range i2cscan.Scan(machine.I2C0) {
+			println(dev.String())
+		}
+		time.Sleep(5 * time.Second)
+	}
+}
@@ -26,9 +26,18 @@ func main() {
 	}
 
 	for {
-		microvolts := dev.Voltage()
-		microamps := dev.Current()
-		microwatts := dev.Power()
+		microvolts, err := dev.Voltage()
+		if err != nil {
+			println("failed to read voltage:", err.Error())
+		}
+		microamps, err := dev.Current()
+		if err != nil {
+			println("failed to read current:", err.Error())
+		}
+		microwatts, err := dev.Power()
+		if err != nil {
+			println("failed to read power:", err.Error())
+		}
 
 		println(fmtD(microvolts, 4, 3), "mV,", fmtD(microamps, 4, 3), "mA,", fmtD(microwatts, 4, 3), "mW")
 
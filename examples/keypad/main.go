@@ -0,0 +1,30 @@
+package main
+
+import (
+	"machine"
+	"time"
+
+	"tinygo.org/x/drivers"
+	"tinygo.org/x/drivers/keypad"
+)
+
+// Labels of a 4x4 membrane keypad, indexed by Event.Key.
+const labels = "123A456B789C*0#D"
+
+func main() {
+	kp := keypad.New(
+		[]drivers.Pin{machine.D2, machine.D3, machine.D4, machine.D5},
+		[]drivers.Pin{machine.D6, machine.D7, machine.D8, machine.D9},
+	)
+	kp.Configure(keypad.Config{
+		RepeatInterval: 200 * time.Millisecond,
+	})
+
+	for {
+		kp.Scan()
+		for e, ok := kp.Next(); ok; e, ok = kp.Next() {
+			println(e.Type.String(), string(labels[e.Key]))
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -21,7 +21,7 @@ func main() {
 	compass.Configure(lis2mdl.Configuration{}) //default settings
 
 	for {
-		heading := compass.ReadCompass()
+		heading, _ := compass.ReadCompass()
 		println("Heading:", heading)
 
 		time.Sleep(time.Millisecond * 100)
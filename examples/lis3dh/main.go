@@ -24,7 +24,7 @@ func main() {
 		x, y, z, _ := accel.ReadAcceleration()
 		println("X:", x, "Y:", y, "Z:", z)
 
-		rx, ry, rz := accel.ReadRawAcceleration()
+		rx, ry, rz, _ := accel.ReadRawAcceleration()
 		println("X (raw):", rx, "Y (raw):", ry, "Z (raw):", rz)
 
 		time.Sleep(time.Millisecond * 100)
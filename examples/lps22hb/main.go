@@ -27,7 +27,7 @@ func main() {
 	for {
 		p, _ := sensor.ReadPressure()
 		t, _ := sensor.ReadTemperature()
-		println("p =", float32(p)/1000.0, "hPa / t =", float32(t)/1000.0, "*C")
+		println("p =", float32(p)/100000.0, "hPa / t =", float32(t)/1000.0, "*C")
 		time.Sleep(time.Second)
 		// note: the device would power down itself after each query
 	}
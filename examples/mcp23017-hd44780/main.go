@@ -0,0 +1,49 @@
+package main
+
+import (
+	"machine"
+
+	"tinygo.org/x/drivers"
+	"tinygo.org/x/drivers/hd44780"
+	"tinygo.org/x/drivers/mcp23017"
+)
+
+func main() {
+	err := machine.I2C0.Configure(machine.I2CConfig{
+		Frequency: machine.TWI_FREQ_400KHZ,
+	})
+	if err != nil {
+		panic(err)
+	}
+	dev, err := mcp23017.NewI2C(machine.I2C0, 0x20)
+	if err != nil {
+		panic(err)
+	}
+
+	// The LCD is wired to pins 0-5 of the expander and is write only.
+	onError := func(err error) {
+		println("mcp23017:", err.Error())
+	}
+	pin := func(n int) drivers.Pin {
+		return drivers.AsPin(dev.Pin(n), onError)
+	}
+	lcd, err := hd44780.NewGPIO4Bit(
+		[]drivers.Pin{pin(0), pin(1), pin(2), pin(3)},
+		pin(4),
+		pin(5),
+		nil,
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	lcd.Configure(hd44780.Config{
+		Width:  16,
+		Height: 2,
+	})
+	lcd.Write([]byte("Hello from an I2C expander"))
+	lcd.Display()
+
+	for {
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"machine"
+	"time"
+
+	"tinygo.org/x/drivers/mcp23017"
+)
+
+// The mirrored INT pins of the expander are wired to this pin.
+var intPin = machine.D2
+
+func main() {
+	err := machine.I2C0.Configure(machine.I2CConfig{
+		Frequency: machine.TWI_FREQ_400KHZ,
+	})
+	if err != nil {
+		panic(err)
+	}
+	dev, err := mcp23017.NewI2C(machine.I2C0, 0x20)
+	if err != nil {
+		panic(err)
+	}
+
+	// 16 buttons to ground, one on each pin of the expander.
+	if err := dev.SetModes([]mcp23017.PinMode{mcp23017.Input | mcp23017.Pullup}); err != nil {
+		panic(err)
+	}
+	if err := dev.ConfigureInterrupt(mcp23017.InterruptConfig{Mirror: true}); err != nil {
+		panic(err)
+	}
+	if err := dev.SetInterrupts(mcp23017.InterruptOnChange, 0xffff); err != nil {
+		panic(err)
+	}
+
+	pending := false
+	intPin.Configure(machine.PinConfig{Mode: machine.PinInputPullup})
+	intPin.SetInterrupt(machine.PinFalling, func(machine.Pin) {
+		pending = true
+	})
+
+	// Clear any interrupt that happened during the configuration.
+	dev.ReadInterrupt()
+	for {
+		if !pending {
+			time.Sleep(10 * time.Millisecond)
+			continue
+		}
+		pending = false
+		flags, captured, err := dev.ReadInterrupt()
+		if err != nil {
+			println("error:", err.Error())
+			continue
+		}
+		for i := 0; i < mcp23017.PinCount; i++ {
+			if flags.Get(i) {
+				// Buttons pull the pins low when pressed.
+				println("button", i, "pressed:", !captured.Get(i))
+			}
+		}
+	}
+}
@@ -15,7 +15,7 @@ func main() {
 	accel.Configure()
 
 	for {
-		x, y, z := accel.ReadAcceleration()
+		x, y, z, _ := accel.ReadAcceleration()
 		println(x, y, z)
 		time.Sleep(time.Millisecond * 100)
 	}
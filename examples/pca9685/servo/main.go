@@ -0,0 +1,40 @@
+package main
+
+import (
+	"machine"
+	"time"
+
+	"tinygo.org/x/drivers/pca9685"
+	"tinygo.org/x/drivers/servo"
+)
+
+func main() {
+	err := machine.I2C0.Configure(machine.I2CConfig{})
+	if err != nil {
+		panic(err.Error())
+	}
+	d := pca9685.New(machine.I2C0, 0x40)
+	err = d.Configure(pca9685.PWMConfig{})
+	if err != nil {
+		panic(err.Error())
+	}
+
+	// Servos on channels 0 and 1, sharing the 20ms period of the PCA9685.
+	s0, err := servo.New(d.Channel(0))
+	if err != nil {
+		panic(err.Error())
+	}
+	s1, err := servo.New(d.Channel(1))
+	if err != nil {
+		panic(err.Error())
+	}
+
+	for {
+		s0.SetMicroseconds(1000)
+		s1.SetMicroseconds(2000)
+		time.Sleep(time.Second)
+		s0.SetMicroseconds(2000)
+		s1.SetMicroseconds(1000)
+		time.Sleep(time.Second)
+	}
+}
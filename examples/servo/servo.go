@@ -4,6 +4,7 @@ import (
 	"machine"
 	"time"
 
+	"tinygo.org/x/drivers"
 	"tinygo.org/x/drivers/servo"
 )
 
@@ -16,13 +17,13 @@ var (
 )
 
 func main() {
-	s, err := servo.New(pwm, pin)
+	ch, err := drivers.NewPWMChannel(pwm, pin)
 	if err != nil {
-		for {
-			println("could not configure servo")
-			time.Sleep(time.Second)
-		}
-		return
+		failed()
+	}
+	s, err := servo.New(ch)
+	if err != nil {
+		failed()
 	}
 
 	println("setting to 0°")
@@ -41,3 +42,10 @@ func main() {
 		time.Sleep(time.Second)
 	}
 }
+
+func failed() {
+	for {
+		println("could not configure servo")
+		time.Sleep(time.Second)
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"image/color"
+	"machine"
+	"time"
+
+	"tinygo.org/x/drivers/bme280"
+	"tinygo.org/x/drivers/shared"
+	"tinygo.org/x/drivers/ssd1306"
+)
+
+func main() {
+	machine.I2C0.Configure(machine.I2CConfig{
+		Frequency: machine.TWI_FREQ_400KHZ,
+	})
+	bus := shared.NewI2C(machine.I2C0)
+
+	sensor := bme280.New(bus)
+	sensor.Configure()
+
+	display := ssd1306.NewI2C(bus)
+	display.Configure(ssd1306.Config{
+		Address: ssd1306.Address_128_32,
+		Width:   128,
+		Height:  32,
+	})
+	display.ClearDisplay()
+
+	// The sensor is read in its own goroutine while the main goroutine
+	// refreshes the display, both on the same bus.
+	temperatures := make(chan int32, 1)
+	go func() {
+		for {
+			temp, err := sensor.ReadTemperature()
+			if err == nil {
+				temperatures <- temp
+			}
+			time.Sleep(time.Second)
+		}
+	}()
+
+	white := color.RGBA{255, 255, 255, 255}
+	black := color.RGBA{0, 0, 0, 255}
+	for temp := range temperatures {
+		// Draw the temperature as a bar, one pixel per 0.5°C.
+		width := int16(temp / 500)
+		for x := int16(0); x < 128; x++ {
+			c := black
+			if x < width {
+				c = white
+			}
+			for y := int16(12); y < 20; y++ {
+				display.SetPixel(x, y, c)
+			}
+		}
+		display.Display()
+	}
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"machine"
+	"time"
+
+	"tinygo.org/x/drivers/softpwm"
+)
+
+func main() {
+	pwm := softpwm.New(machine.LED)
+	go pwm.Run()
+
+	// Fade the LED in and out.
+	for {
+		for value := uint32(0); value <= pwm.Top(); value += 10 {
+			pwm.Set(value)
+			time.Sleep(10 * time.Millisecond)
+		}
+		for value := pwm.Top(); value > 0; value -= 10 {
+			pwm.Set(value)
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+}
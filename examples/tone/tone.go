@@ -4,6 +4,7 @@ import (
 	"machine"
 	"time"
 
+	"tinygo.org/x/drivers"
 	"tinygo.org/x/drivers/tone"
 )
 
@@ -14,7 +15,12 @@ var (
 )
 
 func main() {
-	speaker, err := tone.New(pwm, pin)
+	ch, err := drivers.NewPWMChannel(pwm, pin)
+	if err != nil {
+		println("failed to configure PWM")
+		return
+	}
+	speaker, err := tone.New(ch)
 	if err != nil {
 		println("failed to configure PWM")
 		return
@@ -2,6 +2,8 @@ package flash
 
 import (
 	"time"
+
+	"tinygo.org/x/drivers"
 )
 
 const (
@@ -404,3 +406,16 @@ func (err Error) Error() string {
 		return "flash: unspecified error"
 	}
 }
+
+// Is reports whether err matches target, so that errors.Is matches
+// ErrInvalidClockSpeed with drivers.ErrInvalidConfig and ErrWaitExpired with
+// drivers.ErrTimeout.
+func (err Error) Is(target error) bool {
+	switch err {
+	case ErrInvalidClockSpeed:
+		return target == drivers.ErrInvalidConfig
+	case ErrWaitExpired:
+		return target == drivers.ErrTimeout
+	}
+	return false
+}
@@ -134,8 +134,9 @@ func validSentence(sentence string) error {
 	for i := 1; i < len(sentence)-3; i++ {
 		cs ^= sentence[i]
 	}
+	// NMEA 0183 specifies upper case hex digits, but accept either case.
 	checksum := hex.EncodeToString([]byte{cs})
-	if (checksum[0] != sentence[len(sentence)-2]) || (checksum[1] != sentence[len(sentence)-1]) {
+	if !strings.EqualFold(checksum, sentence[len(sentence)-2:]) {
 		return errInvalidNMEAChecksum
 	}
 
@@ -0,0 +1,63 @@
+package gps
+
+import (
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"tinygo.org/x/drivers/tester"
+)
+
+const (
+	ggaSentence = "$GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*47"
+	rmcSentence = "$GPRMC,123519,A,4807.038,N,01131.000,E,022.4,084.4,230394,003.1,W*6A"
+)
+
+// nmea returns the sentences as a burst of NMEA data, padded to a multiple
+// of the read buffer size as the driver only reads whole buffers.
+func nmea(sentences ...string) []byte {
+	s := strings.Join(sentences, "\r\n") + "\r\n"
+	if n := len(s) % bufferSize; n != 0 {
+		s += strings.Repeat(" ", bufferSize-n)
+	}
+	return []byte(s)
+}
+
+func TestNextSentence(t *testing.T) {
+	c := qt.New(t)
+	uart := tester.NewUART(c)
+	uart.Inject(nmea("garbage,*00", ggaSentence, rmcSentence))
+
+	dev := NewUART(uart)
+	s, err := dev.NextSentence()
+	c.Assert(err, qt.IsNil)
+	c.Assert(s, qt.Equals, ggaSentence)
+
+	s, err = dev.NextSentence()
+	c.Assert(err, qt.IsNil)
+	c.Assert(s, qt.Equals, rmcSentence)
+}
+
+func TestNextSentenceSplit(t *testing.T) {
+	c := qt.New(t)
+	data := nmea(ggaSentence, rmcSentence)
+	uart := tester.NewUART(c)
+	uart.Inject(data[:40])
+	uart.Gap(1)
+	uart.Inject(data[40:])
+
+	dev := NewUART(uart)
+	s, err := dev.NextSentence()
+	c.Assert(err, qt.IsNil)
+	c.Assert(s, qt.Equals, ggaSentence)
+}
+
+func TestNextSentenceChecksum(t *testing.T) {
+	c := qt.New(t)
+	uart := tester.NewUART(c)
+	uart.Inject(nmea(strings.Replace(ggaSentence, "*47", "*48", 1)))
+
+	dev := NewUART(uart)
+	_, err := dev.NextSentence()
+	c.Assert(err, qt.Equals, errInvalidNMEAChecksum)
+}
@@ -0,0 +1,229 @@
+package graphics
+
+import (
+	"image/color"
+	"math"
+
+	"tinygo.org/x/drivers"
+)
+
+// circlePoints calls f for the points of the first octant of a circle of
+// radius r centered on the origin, from (0, r) to the diagonal, with the
+// midpoint circle algorithm.
+func circlePoints(r int16, f func(x, y int16)) {
+	x, y := int16(0), r
+	e := 1 - int32(r)
+	for x <= y {
+		f(x, y)
+		x++
+		if e < 0 {
+			e += 2*int32(x) + 1
+		} else {
+			y--
+			e += 2*int32(x-y) + 1
+		}
+	}
+}
+
+// Circle draws the outline of a circle.
+func Circle(d drivers.Displayer, x0, y0, r int16, c color.RGBA) error {
+	if r < 0 {
+		return nil
+	}
+	corners(d, x0, y0, x0, y0, r, c)
+	return nil
+}
+
+// FilledCircle fills a circle, including its outline.
+func FilledCircle(d drivers.Displayer, x0, y0, r int16, c color.RGBA) error {
+	if r < 0 {
+		return nil
+	}
+	if err := hline(d, x0-r, x0+r, y0, c); err != nil {
+		return err
+	}
+	return filledCorners(d, x0, y0, x0, y0, r, c)
+}
+
+// corners draws the quarter circles of radius r at the corners of a
+// rectangle, given the centers of the top left and bottom right corners.
+func corners(d drivers.Displayer, left, top, right, bottom, r int16, c color.RGBA) {
+	circlePoints(r, func(x, y int16) {
+		pixel(d, right+x, bottom+y, c)
+		pixel(d, right+y, bottom+x, c)
+		pixel(d, left-x, bottom+y, c)
+		pixel(d, left-y, bottom+x, c)
+		pixel(d, right+x, top-y, c)
+		pixel(d, right+y, top-x, c)
+		pixel(d, left-x, top-y, c)
+		pixel(d, left-y, top-x, c)
+	})
+}
+
+// filledCorners fills the rows of the rounded corners of a rectangle above
+// top and below bottom, given the centers of the top left and bottom right
+// corners.
+func filledCorners(d drivers.Displayer, left, top, right, bottom, r int16, c color.RGBA) error {
+	var err error
+	span := func(dy, dx int16) {
+		if err == nil {
+			err = hline(d, left-dx, right+dx, top-dy, c)
+		}
+		if err == nil {
+			err = hline(d, left-dx, right+dx, bottom+dy, c)
+		}
+	}
+	// Each row is drawn once, with the widest span of the octants: the rows
+	// at x from 1 to the diagonal span to y, and the rows at y, beyond the
+	// diagonal, span to the last x of that row.
+	lastX, lastY := int16(-1), r
+	circlePoints(r, func(x, y int16) {
+		if y != lastY {
+			if lastY > lastX {
+				span(lastY, lastX)
+			}
+			lastY = y
+		}
+		if x > 0 {
+			span(x, y)
+		}
+		lastX = x
+	})
+	if lastY > lastX {
+		span(lastY, lastX)
+	}
+	return err
+}
+
+// ellipseRows calls f for the points of the bottom right quarter of an
+// ellipse with radii rx and ry centered on the origin, with the midpoint
+// ellipse algorithm. The decision variables are scaled by 4 to stay in
+// integers.
+func ellipseRows(rx, ry int16, f func(x, y int16)) {
+	rx2, ry2 := int64(rx)*int64(rx), int64(ry)*int64(ry)
+	x, y := int64(0), int64(ry)
+	dx, dy := int64(0), 2*rx2*y
+
+	// Region 1: the slope is below 1, x changes at every step.
+	p := 4*ry2 - 4*rx2*int64(ry) + rx2
+	for dx < dy {
+		f(int16(x), int16(y))
+		x++
+		dx += 2 * ry2
+		if p < 0 {
+			p += 4 * (dx + ry2)
+		} else {
+			y--
+			dy -= 2 * rx2
+			p += 4 * (dx - dy + ry2)
+		}
+	}
+
+	// Region 2: the slope is above 1, y changes at every step.
+	p = ry2*(2*x+1)*(2*x+1) + 4*rx2*(y-1)*(y-1) - 4*rx2*ry2
+	for y >= 0 {
+		f(int16(x), int16(y))
+		y--
+		dy -= 2 * rx2
+		if p > 0 {
+			p += 4 * (rx2 - dy)
+		} else {
+			x++
+			dx += 2 * ry2
+			p += 4 * (dx - dy + rx2)
+		}
+	}
+}
+
+// Ellipse draws the outline of an ellipse with the horizontal radius rx and
+// the vertical radius ry.
+func Ellipse(d drivers.Displayer, x0, y0, rx, ry int16, c color.RGBA) error {
+	if rx < 0 || ry < 0 {
+		return nil
+	}
+	ellipseRows(rx, ry, func(x, y int16) {
+		pixel(d, x0+x, y0+y, c)
+		pixel(d, x0-x, y0+y, c)
+		pixel(d, x0+x, y0-y, c)
+		pixel(d, x0-x, y0-y, c)
+	})
+	return nil
+}
+
+// FilledEllipse fills an ellipse with the horizontal radius rx and the
+// vertical radius ry, including its outline.
+func FilledEllipse(d drivers.Displayer, x0, y0, rx, ry int16, c color.RGBA) error {
+	if rx < 0 || ry < 0 {
+		return nil
+	}
+	// Keep the widest point of each row.
+	widths := make([]int16, ry+1)
+	ellipseRows(rx, ry, func(x, y int16) {
+		if x > widths[y] {
+			widths[y] = x
+		}
+	})
+	for y, w := range widths {
+		if err := hline(d, x0-w, x0+w, y0+int16(y), c); err != nil {
+			return err
+		}
+		if y == 0 {
+			continue
+		}
+		if err := hline(d, x0-w, x0+w, y0-int16(y), c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Arc draws the part of the outline of a circle between two angles, in
+// degrees. Angles start from the right of the center and grow clockwise, as
+// the y axis points down: 90 is below the center. The arc goes clockwise
+// from start to end, so that Arc(d, x, y, r, 270, 90, c) draws the right half
+// of the circle.
+func Arc(d drivers.Displayer, x0, y0, r, start, end int16, c color.RGBA) error {
+	if r < 0 {
+		return nil
+	}
+	full := end-start >= 360 || start-end >= 360
+	start, end = mod360(start), mod360(end)
+	inArc := func(x, y int16) bool {
+		if full {
+			return true
+		}
+		a := math.Atan2(float64(y), float64(x)) * 180 / math.Pi
+		if a < 0 {
+			a += 360
+		}
+		if start <= end {
+			return a >= float64(start) && a <= float64(end)
+		}
+		return a >= float64(start) || a <= float64(end)
+	}
+	plot := func(x, y int16) {
+		if inArc(x, y) {
+			pixel(d, x0+x, y0+y, c)
+		}
+	}
+	circlePoints(r, func(x, y int16) {
+		plot(x, y)
+		plot(y, x)
+		plot(-x, y)
+		plot(-y, x)
+		plot(x, -y)
+		plot(y, -x)
+		plot(-x, -y)
+		plot(-y, -x)
+	})
+	return nil
+}
+
+// mod360 returns an angle between 0 and 359 degrees.
+func mod360(a int16) int16 {
+	a %= 360
+	if a < 0 {
+		a += 360
+	}
+	return a
+}
@@ -0,0 +1,62 @@
+package graphics
+
+import (
+	"image/color"
+
+	"tinygo.org/x/drivers"
+)
+
+// FloodFill fills the area of connected pixels around (x, y) for which
+// inside returns true, with a scanline algorithm. Pixels are connected
+// horizontally and vertically.
+//
+// Most displays cannot be read back, so inside must be provided by the
+// caller. It must return false for the pixels that have been filled, for
+// example by reading the buffer of a monochrome display:
+//
+//	graphics.FloodFill(&display, x, y, white, func(x, y int16) bool {
+//		return !display.GetPixel(x, y)
+//	})
+func FloodFill(d drivers.Displayer, x, y int16, c color.RGBA, inside func(x, y int16) bool) error {
+	w, h := d.Size()
+	if x < 0 || y < 0 || x >= w || y >= h {
+		return nil
+	}
+	stack := []Point{{x, y}}
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !inside(p.X, p.Y) {
+			continue
+		}
+
+		// Fill the whole run of the seed.
+		left, right := p.X, p.X
+		for left > 0 && inside(left-1, p.Y) {
+			left--
+		}
+		for right < w-1 && inside(right+1, p.Y) {
+			right++
+		}
+		if err := hline(d, left, right, p.Y, c); err != nil {
+			return err
+		}
+
+		// Push a seed for each run of the rows above and below.
+		for _, y := range [2]int16{p.Y - 1, p.Y + 1} {
+			if y < 0 || y >= h {
+				continue
+			}
+			inRun := false
+			for x := left; x <= right; x++ {
+				if !inside(x, y) {
+					inRun = false
+				} else if !inRun {
+					stack = append(stack, Point{x, y})
+					inRun = true
+				}
+			}
+		}
+	}
+	return nil
+}
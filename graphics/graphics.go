@@ -0,0 +1,145 @@
+// Package graphics draws lines, shapes and fills on any drivers.Displayer.
+//
+// Shapes are drawn with horizontal and vertical spans wherever possible. When
+// the display implements FillRectangle or DrawFastHLine, such as the TFT and
+// OLED color displays, the spans are sent to the display at once instead of
+// pixel by pixel:
+//
+//	graphics.FilledRoundedRectangle(&display, 10, 10, 100, 40, 8, blue)
+//	graphics.Circle(&display, 60, 30, 16, white)
+//
+// Everything is clipped to the size of the display. The functions return the
+// first error of the display, if any; SetPixel does not report errors.
+package graphics // import "tinygo.org/x/drivers/graphics"
+
+import (
+	"image/color"
+
+	"tinygo.org/x/drivers"
+)
+
+// Point is a position on a display.
+type Point struct {
+	X, Y int16
+}
+
+// rectangleFiller is implemented by displays that can fill a rectangle
+// faster than with SetPixel, such as drivers.AcceleratedDisplayer.
+type rectangleFiller interface {
+	FillRectangle(x, y, width, height int16, c color.RGBA) error
+}
+
+// hLineDrawer is implemented by displays that can draw a horizontal line
+// faster than with SetPixel.
+type hLineDrawer interface {
+	DrawFastHLine(x0, x1, y int16, c color.RGBA) error
+}
+
+// fill fills a rectangle, clipped to the display, with the fastest method
+// available.
+func fill(d drivers.Displayer, x, y, width, height int16, c color.RGBA) error {
+	w, h := d.Size()
+	if x < 0 {
+		width += x
+		x = 0
+	}
+	if y < 0 {
+		height += y
+		y = 0
+	}
+	if x+width > w {
+		width = w - x
+	}
+	if y+height > h {
+		height = h - y
+	}
+	if width <= 0 || height <= 0 {
+		return nil
+	}
+
+	if f, ok := d.(rectangleFiller); ok {
+		return f.FillRectangle(x, y, width, height, c)
+	}
+	if f, ok := d.(hLineDrawer); ok {
+		for i := y; i < y+height; i++ {
+			if err := f.DrawFastHLine(x, x+width-1, i, c); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for j := y; j < y+height; j++ {
+		for i := x; i < x+width; i++ {
+			d.SetPixel(i, j, c)
+		}
+	}
+	return nil
+}
+
+// hline draws a horizontal line between x0 and x1 included.
+func hline(d drivers.Displayer, x0, x1, y int16, c color.RGBA) error {
+	if x0 > x1 {
+		x0, x1 = x1, x0
+	}
+	return fill(d, x0, y, x1-x0+1, 1, c)
+}
+
+// vline draws a vertical line between y0 and y1 included.
+func vline(d drivers.Displayer, x, y0, y1 int16, c color.RGBA) error {
+	if y0 > y1 {
+		y0, y1 = y1, y0
+	}
+	return fill(d, x, y0, 1, y1-y0+1, c)
+}
+
+// pixel sets a single pixel, if it is on the display.
+func pixel(d drivers.Displayer, x, y int16, c color.RGBA) {
+	w, h := d.Size()
+	if x >= 0 && y >= 0 && x < w && y < h {
+		d.SetPixel(x, y, c)
+	}
+}
+
+// Line draws a line between two points, both included, with the Bresenham
+// algorithm.
+func Line(d drivers.Displayer, x0, y0, x1, y1 int16, c color.RGBA) error {
+	if y0 == y1 {
+		return hline(d, x0, x1, y0, c)
+	}
+	if x0 == x1 {
+		return vline(d, x0, y0, y1, c)
+	}
+
+	dx := abs(x1 - x0)
+	dy := -abs(y1 - y0)
+	sx, sy := int16(1), int16(1)
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	e := int32(dx) + int32(dy)
+	for {
+		pixel(d, x0, y0, c)
+		if x0 == x1 && y0 == y1 {
+			return nil
+		}
+		e2 := 2 * e
+		if e2 >= int32(dy) {
+			e += int32(dy)
+			x0 += sx
+		}
+		if e2 <= int32(dx) {
+			e += int32(dx)
+			y0 += sy
+		}
+	}
+}
+
+func abs(x int16) int16 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,406 @@
+package graphics
+
+import (
+	"errors"
+	"image/color"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+var (
+	black = color.RGBA{0, 0, 0, 255}
+	white = color.RGBA{255, 255, 255, 255}
+)
+
+// display is an in-memory display, drawn with SetPixel only.
+type display struct {
+	w, h   int16
+	pix    []color.RGBA
+	pixels int
+}
+
+func newDisplay(w, h int16) *display {
+	return &display{w: w, h: h, pix: make([]color.RGBA, int(w)*int(h))}
+}
+
+func (d *display) Size() (int16, int16) {
+	return d.w, d.h
+}
+
+func (d *display) SetPixel(x, y int16, c color.RGBA) {
+	if x < 0 || y < 0 || x >= d.w || y >= d.h {
+		panic("pixel outside display")
+	}
+	d.pix[int(y)*int(d.w)+int(x)] = c
+	d.pixels++
+}
+
+func (d *display) Display() error {
+	return nil
+}
+
+func (d *display) get(x, y int16) color.RGBA {
+	return d.pix[int(y)*int(d.w)+int(x)]
+}
+
+// String returns the display as text, with # for the pixels set to white.
+func (d *display) String() string {
+	var b strings.Builder
+	for y := int16(0); y < d.h; y++ {
+		b.WriteByte('\n')
+		for x := int16(0); x < d.w; x++ {
+			if d.get(x, y) == white {
+				b.WriteByte('#')
+			} else {
+				b.WriteByte('.')
+			}
+		}
+	}
+	return b.String()
+}
+
+// fastDisplay implements FillRectangle like the color displays.
+type fastDisplay struct {
+	*display
+	fills int
+	err   error
+}
+
+func (d *fastDisplay) FillRectangle(x, y, width, height int16, c color.RGBA) error {
+	if x < 0 || y < 0 || width <= 0 || height <= 0 || x+width > d.w || y+height > d.h {
+		return errors.New("rectangle coordinates outside display area")
+	}
+	if d.err != nil {
+		return d.err
+	}
+	d.fills++
+	for j := y; j < y+height; j++ {
+		for i := x; i < x+width; i++ {
+			d.pix[int(j)*int(d.w)+int(i)] = c
+		}
+	}
+	return nil
+}
+
+// hLineDisplay only implements DrawFastHLine.
+type hLineDisplay struct {
+	*display
+	lines int
+}
+
+func (d *hLineDisplay) DrawFastHLine(x0, x1, y int16, c color.RGBA) error {
+	d.lines++
+	for x := x0; x <= x1; x++ {
+		d.pix[int(y)*int(d.w)+int(x)] = c
+	}
+	return nil
+}
+
+func TestLine(t *testing.T) {
+	c := qt.New(t)
+	d := newDisplay(7, 4)
+	c.Assert(Line(d, 0, 0, 6, 3, white), qt.IsNil)
+	c.Assert(d.String(), qt.Equals, `
+#......
+.##....
+...##..
+.....##`)
+
+	// The line has a pixel per column in both directions.
+	r := newDisplay(7, 4)
+	c.Assert(Line(r, 6, 3, 0, 0, white), qt.IsNil)
+	c.Assert(r.pixels, qt.Equals, 7)
+
+	d = newDisplay(3, 5)
+	c.Assert(Line(d, 2, 0, 0, 4, white), qt.IsNil)
+	c.Assert(d.String(), qt.Equals, `
+..#
+.#.
+.#.
+#..
+#..`)
+}
+
+func TestFastPath(t *testing.T) {
+	c := qt.New(t)
+	d := &fastDisplay{display: newDisplay(8, 8)}
+	c.Assert(FilledRectangle(d, 1, 1, 4, 3, white), qt.IsNil)
+	c.Assert(Line(d, 0, 7, 7, 7, white), qt.IsNil)
+	c.Assert(Line(d, 7, 0, 7, 5, white), qt.IsNil)
+	c.Assert(d.fills, qt.Equals, 3)
+	c.Assert(d.pixels, qt.Equals, 0)
+	c.Assert(d.String(), qt.Equals, `
+.......#
+.####..#
+.####..#
+.####..#
+.......#
+.......#
+........
+########`)
+
+	// The errors of the display are returned.
+	d.err = errors.New("spi error")
+	c.Assert(FilledCircle(d, 4, 4, 2, white), qt.ErrorMatches, "spi error")
+
+	h := &hLineDisplay{display: newDisplay(8, 8)}
+	c.Assert(FilledRectangle(h, 1, 1, 4, 3, white), qt.IsNil)
+	c.Assert(h.lines, qt.Equals, 3)
+	c.Assert(h.pixels, qt.Equals, 0)
+}
+
+func TestClipping(t *testing.T) {
+	c := qt.New(t)
+	d := &fastDisplay{display: newDisplay(6, 4)}
+	c.Assert(FilledRectangle(d, -2, 2, 20, 5, white), qt.IsNil)
+	c.Assert(FilledRectangle(d, 10, 0, 2, 2, white), qt.IsNil)
+	c.Assert(Line(d, -3, -3, 8, 8, white), qt.IsNil)
+	c.Assert(Circle(d, 0, 0, 3, white), qt.IsNil)
+	c.Assert(d.String(), qt.Equals, `
+#..#..
+.#.#..
+######
+######`)
+}
+
+func TestRectangle(t *testing.T) {
+	c := qt.New(t)
+	d := newDisplay(6, 5)
+	c.Assert(Rectangle(d, 1, 1, 5, 4, white), qt.IsNil)
+	c.Assert(d.String(), qt.Equals, `
+......
+.#####
+.#...#
+.#...#
+.#####`)
+
+	d = newDisplay(9, 7)
+	c.Assert(RoundedRectangle(d, 0, 0, 9, 7, 2, white), qt.IsNil)
+	c.Assert(d.String(), qt.Equals, `
+.#######.
+#.......#
+#.......#
+#.......#
+#.......#
+#.......#
+.#######.`)
+
+	d = newDisplay(9, 7)
+	c.Assert(FilledRoundedRectangle(d, 0, 0, 9, 7, 2, white), qt.IsNil)
+	c.Assert(d.String(), qt.Equals, `
+.#######.
+#########
+#########
+#########
+#########
+#########
+.#######.`)
+
+	d = newDisplay(9, 7)
+	c.Assert(RoundedRectangle(d, 0, 0, 9, 7, 3, white), qt.IsNil)
+	c.Assert(d.String(), qt.Equals, `
+..#####..
+.#.....#.
+#.......#
+#.......#
+#.......#
+.#.....#.
+..#####..`)
+}
+
+func TestCircle(t *testing.T) {
+	c := qt.New(t)
+	d := newDisplay(11, 11)
+	c.Assert(Circle(d, 5, 5, 5, white), qt.IsNil)
+	c.Assert(d.String(), qt.Equals, `
+...#####...
+..#.....#..
+.#.......#.
+#.........#
+#.........#
+#.........#
+#.........#
+#.........#
+.#.......#.
+..#.....#..
+...#####...`)
+
+	f := newDisplay(11, 11)
+	c.Assert(FilledCircle(f, 5, 5, 5, white), qt.IsNil)
+	c.Assert(f.String(), qt.Equals, `
+...#####...
+..#######..
+.#########.
+###########
+###########
+###########
+###########
+###########
+.#########.
+..#######..
+...#####...`)
+
+	// The filled circle covers the outline exactly, for any radius.
+	for r := int16(0); r < 20; r++ {
+		d, f := newDisplay(41, 41), newDisplay(41, 41)
+		Circle(d, 20, 20, r, white)
+		FilledCircle(f, 20, 20, r, white)
+		for y := int16(0); y < 41; y++ {
+			var first, last int16 = -1, -1
+			for x := int16(0); x < 41; x++ {
+				if d.get(x, y) == white {
+					if first < 0 {
+						first = x
+					}
+					last = x
+				}
+			}
+			for x := int16(0); x < 41; x++ {
+				want := first >= 0 && x >= first && x <= last
+				c.Assert(f.get(x, y) == white, qt.Equals, want, qt.Commentf("r=%d x=%d y=%d", r, x, y))
+			}
+		}
+	}
+}
+
+func TestEllipse(t *testing.T) {
+	c := qt.New(t)
+	d := newDisplay(11, 7)
+	c.Assert(Ellipse(d, 5, 3, 5, 3, white), qt.IsNil)
+	c.Assert(d.String(), qt.Equals, `
+...#####...
+.##.....##.
+#.........#
+#.........#
+#.........#
+.##.....##.
+...#####...`)
+
+	f := newDisplay(11, 7)
+	c.Assert(FilledEllipse(f, 5, 3, 5, 3, white), qt.IsNil)
+	c.Assert(f.String(), qt.Equals, `
+...#####...
+.#########.
+###########
+###########
+###########
+.#########.
+...#####...`)
+
+	// An ellipse with equal radii is a circle.
+	e, o := newDisplay(21, 21), newDisplay(21, 21)
+	Ellipse(e, 10, 10, 10, 10, white)
+	Circle(o, 10, 10, 10, white)
+	c.Assert(e.String(), qt.Equals, o.String())
+}
+
+func TestArc(t *testing.T) {
+	c := qt.New(t)
+	d := newDisplay(11, 11)
+	c.Assert(Arc(d, 5, 5, 5, 270, 90, white), qt.IsNil)
+	c.Assert(d.String(), qt.Equals, `
+.....###...
+........#..
+.........#.
+..........#
+..........#
+..........#
+..........#
+..........#
+.........#.
+........#..
+.....###...`)
+
+	// A full turn draws the whole circle.
+	a, o := newDisplay(11, 11), newDisplay(11, 11)
+	Arc(a, 5, 5, 5, 45, 405, white)
+	Circle(o, 5, 5, 5, white)
+	c.Assert(a.String(), qt.Equals, o.String())
+}
+
+func TestTriangle(t *testing.T) {
+	c := qt.New(t)
+	d := newDisplay(9, 5)
+	c.Assert(Triangle(d, 0, 4, 4, 0, 8, 4, white), qt.IsNil)
+	c.Assert(d.String(), qt.Equals, `
+....#....
+...#.#...
+..#...#..
+.#.....#.
+#########`)
+
+	f := newDisplay(9, 5)
+	c.Assert(FilledTriangle(f, 8, 4, 4, 0, 0, 4, white), qt.IsNil)
+	c.Assert(f.String(), qt.Equals, `
+....#....
+...###...
+..#####..
+.#######.
+#########`)
+
+	// Flat triangles are lines.
+	l := newDisplay(9, 5)
+	c.Assert(FilledTriangle(l, 1, 2, 7, 2, 4, 2, white), qt.IsNil)
+	c.Assert(l.pixels, qt.Equals, 7)
+}
+
+func TestPolygon(t *testing.T) {
+	c := qt.New(t)
+	// A concave arrow.
+	points := []Point{{0, 0}, {8, 3}, {0, 6}, {3, 3}}
+	d := newDisplay(9, 7)
+	c.Assert(Polygon(d, points, white), qt.IsNil)
+	c.Assert(d.String(), qt.Equals, `
+##.......
+.###.....
+..#.###..
+...#...##
+..#..##..
+.####....
+##.......`)
+
+	f := newDisplay(9, 7)
+	c.Assert(FilledPolygon(f, points, white), qt.IsNil)
+	c.Assert(f.String(), qt.Equals, `
+##.......
+.###.....
+..#####..
+...######
+..#####..
+.####....
+##.......`)
+}
+
+func TestFloodFill(t *testing.T) {
+	c := qt.New(t)
+	d := newDisplay(8, 6)
+	Rectangle(d, 0, 0, 8, 6, white)
+	Line(d, 4, 0, 4, 3, white)
+	unlit := func(x, y int16) bool { return d.get(x, y) != white }
+
+	c.Assert(FloodFill(d, 1, 1, white, unlit), qt.IsNil)
+	c.Assert(d.String(), qt.Equals, `
+########
+########
+########
+########
+########
+########`)
+
+	// The fill stops at the outline.
+	d = newDisplay(8, 6)
+	Rectangle(d, 1, 1, 5, 4, white)
+	c.Assert(FloodFill(d, 2, 2, white, unlit), qt.IsNil)
+	c.Assert(d.String(), qt.Equals, `
+........
+.#####..
+.#####..
+.#####..
+.#####..
+........`)
+
+	// Nothing is filled outside the display.
+	c.Assert(FloodFill(d, 8, 0, black, unlit), qt.IsNil)
+}
@@ -0,0 +1,210 @@
+package graphics
+
+import (
+	"image/color"
+	"sort"
+
+	"tinygo.org/x/drivers"
+)
+
+// Rectangle draws the outline of a rectangle of the given size, with its top
+// left corner at (x, y).
+func Rectangle(d drivers.Displayer, x, y, width, height int16, c color.RGBA) error {
+	if width <= 0 || height <= 0 {
+		return nil
+	}
+	if width <= 2 || height <= 2 {
+		return fill(d, x, y, width, height, c)
+	}
+	if err := hline(d, x, x+width-1, y, c); err != nil {
+		return err
+	}
+	if err := hline(d, x, x+width-1, y+height-1, c); err != nil {
+		return err
+	}
+	if err := vline(d, x, y+1, y+height-2, c); err != nil {
+		return err
+	}
+	return vline(d, x+width-1, y+1, y+height-2, c)
+}
+
+// FilledRectangle fills a rectangle of the given size, with its top left
+// corner at (x, y).
+func FilledRectangle(d drivers.Displayer, x, y, width, height int16, c color.RGBA) error {
+	return fill(d, x, y, width, height, c)
+}
+
+// RoundedRectangle draws the outline of a rectangle with corners rounded by
+// quarter circles of radius r.
+func RoundedRectangle(d drivers.Displayer, x, y, width, height, r int16, c color.RGBA) error {
+	r = clampRadius(width, height, r)
+	if r == 0 {
+		return Rectangle(d, x, y, width, height, c)
+	}
+	if err := hline(d, x+r, x+width-r-1, y, c); err != nil {
+		return err
+	}
+	if err := hline(d, x+r, x+width-r-1, y+height-1, c); err != nil {
+		return err
+	}
+	if err := vline(d, x, y+r, y+height-r-1, c); err != nil {
+		return err
+	}
+	if err := vline(d, x+width-1, y+r, y+height-r-1, c); err != nil {
+		return err
+	}
+	corners(d, x+r, y+r, x+width-r-1, y+height-r-1, r, c)
+	return nil
+}
+
+// FilledRoundedRectangle fills a rectangle with corners rounded by quarter
+// circles of radius r.
+func FilledRoundedRectangle(d drivers.Displayer, x, y, width, height, r int16, c color.RGBA) error {
+	r = clampRadius(width, height, r)
+	if err := fill(d, x, y+r, width, height-2*r, c); err != nil {
+		return err
+	}
+	return filledCorners(d, x+r, y+r, x+width-r-1, y+height-r-1, r, c)
+}
+
+// clampRadius limits the radius of the corners to half the smallest side of
+// a rectangle.
+func clampRadius(width, height, r int16) int16 {
+	if r > width/2 {
+		r = width / 2
+	}
+	if r > height/2 {
+		r = height / 2
+	}
+	if r < 0 {
+		r = 0
+	}
+	return r
+}
+
+// Triangle draws the outline of a triangle.
+func Triangle(d drivers.Displayer, x0, y0, x1, y1, x2, y2 int16, c color.RGBA) error {
+	if err := Line(d, x0, y0, x1, y1, c); err != nil {
+		return err
+	}
+	if err := Line(d, x1, y1, x2, y2, c); err != nil {
+		return err
+	}
+	return Line(d, x2, y2, x0, y0, c)
+}
+
+// FilledTriangle fills a triangle, including its outline.
+func FilledTriangle(d drivers.Displayer, x0, y0, x1, y1, x2, y2 int16, c color.RGBA) error {
+	// Sort the vertices by y: y0 <= y1 <= y2.
+	if y0 > y1 {
+		x0, y0, x1, y1 = x1, y1, x0, y0
+	}
+	if y1 > y2 {
+		x1, y1, x2, y2 = x2, y2, x1, y1
+	}
+	if y0 > y1 {
+		x0, y0, x1, y1 = x1, y1, x0, y0
+	}
+
+	if y0 == y2 {
+		// All the vertices are on the same line.
+		return hline(d, min(x0, min(x1, x2)), max(x0, max(x1, x2)), y0, c)
+	}
+
+	// Each line spans from the long edge (0-2) to one of the short edges:
+	// 0-1 for the upper part, including y1, and 1-2 for the lower part.
+	for y := y0; y <= y2; y++ {
+		a := interpolate(x0, y0, x2, y2, y)
+		var b int16
+		if y < y1 || (y == y1 && y0 != y1) {
+			b = interpolate(x0, y0, x1, y1, y)
+		} else {
+			b = interpolate(x1, y1, x2, y2, y)
+		}
+		if err := hline(d, a, b, y, c); err != nil {
+			return err
+		}
+	}
+	// The spans round the edges, so draw them exactly.
+	return Triangle(d, x0, y0, x1, y1, x2, y2, c)
+}
+
+// interpolate returns the x coordinate of the line between (x0, y0) and
+// (x1, y1) at y, rounded to the nearest integer.
+func interpolate(x0, y0, x1, y1, y int16) int16 {
+	if y1 == y0 {
+		return x0
+	}
+	num := int32(x1-x0) * int32(y-y0)
+	den := int32(y1 - y0)
+	// Round half away from zero.
+	if (num < 0) != (den < 0) {
+		return x0 + int16((num-den/2)/den)
+	}
+	return x0 + int16((num+den/2)/den)
+}
+
+// Polygon draws the outline of a closed polygon through the given points.
+func Polygon(d drivers.Displayer, points []Point, c color.RGBA) error {
+	if len(points) == 1 {
+		pixel(d, points[0].X, points[0].Y, c)
+	}
+	for i := range points {
+		p, q := points[i], points[(i+1)%len(points)]
+		if err := Line(d, p.X, p.Y, q.X, q.Y, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FilledPolygon fills a closed polygon through the given points, including
+// its outline. Self-intersecting polygons are filled with the even-odd rule.
+func FilledPolygon(d drivers.Displayer, points []Point, c color.RGBA) error {
+	if len(points) < 3 {
+		return Polygon(d, points, c)
+	}
+	top, bottom := points[0].Y, points[0].Y
+	for _, p := range points[1:] {
+		top = min(top, p.Y)
+		bottom = max(bottom, p.Y)
+	}
+	_, h := d.Size()
+	top = max(top, 0)
+	bottom = min(bottom, h-1)
+
+	xs := make([]int16, 0, len(points))
+	for y := top; y <= bottom; y++ {
+		// Find where the edges cross the line, with the upper vertex of
+		// each edge included and the lower one excluded, so that shared
+		// vertices are counted once.
+		xs = xs[:0]
+		for i := range points {
+			p, q := points[i], points[(i+1)%len(points)]
+			if (p.Y <= y && y < q.Y) || (q.Y <= y && y < p.Y) {
+				xs = append(xs, interpolate(p.X, p.Y, q.X, q.Y, y))
+			}
+		}
+		sort.Slice(xs, func(i, j int) bool { return xs[i] < xs[j] })
+		for i := 0; i+1 < len(xs); i += 2 {
+			if err := hline(d, xs[i], xs[i+1], y, c); err != nil {
+				return err
+			}
+		}
+	}
+	return Polygon(d, points, c)
+}
+
+func min(a, b int16) int16 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int16) int16 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -3,29 +3,31 @@ package hd44780
 import (
 	"errors"
 
-	"machine"
+	"tinygo.org/x/drivers"
 )
 
 type GPIO struct {
-	dataPins []machine.Pin
-	en       machine.Pin
-	rw       machine.Pin
-	rs       machine.Pin
+	dataPins []drivers.Pin
+	en       drivers.Pin
+	rw       drivers.Pin
+	rs       drivers.Pin
 
 	write func(data byte)
 	read  func() byte
 }
 
-func newGPIO(dataPins []machine.Pin, en, rs, rw machine.Pin, mode byte) Device {
-	pins := make([]machine.Pin, len(dataPins))
+func newGPIO(dataPins []drivers.Pin, en, rs, rw drivers.Pin, mode byte) Device {
+	pins := make([]drivers.Pin, len(dataPins))
 	for i := 0; i < len(dataPins); i++ {
-		dataPins[i].Configure(machine.PinConfig{Mode: machine.PinOutput})
+		drivers.ConfigurePin(dataPins[i], drivers.PinOutput)
 		pins[i] = dataPins[i]
 	}
-	en.Configure(machine.PinConfig{Mode: machine.PinOutput})
-	rs.Configure(machine.PinConfig{Mode: machine.PinOutput})
-	rw.Configure(machine.PinConfig{Mode: machine.PinOutput})
-	rw.Low()
+	drivers.ConfigurePin(en, drivers.PinOutput)
+	drivers.ConfigurePin(rs, drivers.PinOutput)
+	if !drivers.IsNoPin(rw) {
+		drivers.ConfigurePin(rw, drivers.PinOutput)
+		rw.Low()
+	}
 
 	gpio := GPIO{
 		dataPins: pins,
@@ -57,9 +59,9 @@ func (g *GPIO) SetCommandMode(set bool) {
 	}
 }
 
-// WriteOnly is true if you passed rw in as machine.NoPin
+// WriteOnly is true if you passed rw in as nil or machine.NoPin
 func (g *GPIO) WriteOnly() bool {
-	return g.rw == machine.NoPin
+	return drivers.IsNoPin(g.rw)
 }
 
 // Write writes len(data) bytes from data to display driver
@@ -100,13 +102,13 @@ func (g *GPIO) Read(data []byte) (n int, err error) {
 		return 0, errors.New("Read not supported if RW not wired")
 	}
 	g.rw.High()
-	g.reconfigureGPIOMode(machine.PinInput)
+	g.reconfigureGPIOMode(drivers.PinInput)
 	for i := 0; i < len(data); i++ {
 		data[i] = g.read()
 		n++
 	}
 	g.rw.Low()
-	g.reconfigureGPIOMode(machine.PinOutput)
+	g.reconfigureGPIOMode(drivers.PinOutput)
 	return n, nil
 }
 
@@ -128,9 +130,9 @@ func (g *GPIO) read8BitMode() byte {
 	return data
 }
 
-func (g *GPIO) reconfigureGPIOMode(mode machine.PinMode) {
+func (g *GPIO) reconfigureGPIOMode(mode drivers.PinMode) {
 	for i := 0; i < len(g.dataPins); i++ {
-		g.dataPins[i].Configure(machine.PinConfig{Mode: mode})
+		drivers.ConfigurePin(g.dataPins[i], mode)
 	}
 }
 
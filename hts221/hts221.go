@@ -19,6 +19,7 @@ type Device struct {
 	humidityZero     float32
 	temperatureSlope float32
 	temperatureZero  float32
+	sleeping         bool
 }
 
 // New creates a new HTS221 connection. The I2C bus must already be
@@ -39,11 +40,35 @@ func (d *Device) Connected() bool {
 
 // Power is for turn on/off the HTS221 device
 func (d *Device) Power(status bool) {
+	d.setPower(status)
+}
+
+// Sleep turns off the HTS221 device. It implements drivers.Sleeper.
+func (d *Device) Sleep() error {
+	return d.setPower(false)
+}
+
+// Wake turns on the HTS221 device. It implements drivers.Sleeper.
+func (d *Device) Wake() error {
+	return d.setPower(true)
+}
+
+// Sleeping returns whether the device has been turned off.
+func (d *Device) Sleeping() bool {
+	return d.sleeping
+}
+
+func (d *Device) setPower(status bool) error {
 	data := []byte{0}
 	if status {
 		data[0] = 0x84
 	}
-	d.bus.WriteRegister(d.Address, HTS221_CTRL1_REG, data)
+	err := d.bus.WriteRegister(d.Address, HTS221_CTRL1_REG, data)
+	if err != nil {
+		return drivers.WrapError("hts221: power", err)
+	}
+	d.sleeping = !status
+	return nil
 }
 
 // ReadHumidity returns the relative humidity in percent * 100.
@@ -56,8 +81,10 @@ func (d *Device) ReadHumidity() (humidity int32, err error) {
 
 	// read data and calibrate
 	data := []byte{0, 0}
-	d.bus.ReadRegister(d.Address, HTS221_HUMID_OUT_REG, data[:1])
-	d.bus.ReadRegister(d.Address, HTS221_HUMID_OUT_REG+1, data[1:])
+	err = d.readRegisters(HTS221_HUMID_OUT_REG, data)
+	if err != nil {
+		return 0, drivers.WrapError("hts221: read humidity", err)
+	}
 	hValue := readInt(data[1], data[0])
 	hValueCalib := float32(hValue)*d.humiditySlope + d.humidityZero
 
@@ -74,8 +101,10 @@ func (d *Device) ReadTemperature() (temperature int32, err error) {
 
 	// read data and calibrate
 	data := []byte{0, 0}
-	d.bus.ReadRegister(d.Address, HTS221_TEMP_OUT_REG, data[:1])
-	d.bus.ReadRegister(d.Address, HTS221_TEMP_OUT_REG+1, data[1:])
+	err = d.readRegisters(HTS221_TEMP_OUT_REG, data)
+	if err != nil {
+		return 0, drivers.WrapError("hts221: read temperature", err)
+	}
 	tValue := readInt(data[1], data[0])
 	tValueCalib := float32(tValue)*d.temperatureSlope + d.temperatureZero
 
@@ -140,7 +169,10 @@ func (d *Device) waitForOneShot(filter uint8) error {
 	data := []byte{0}
 
 	// check if the device is on
-	d.bus.ReadRegister(d.Address, HTS221_CTRL1_REG, data)
+	err := d.bus.ReadRegister(d.Address, HTS221_CTRL1_REG, data)
+	if err != nil {
+		return drivers.WrapError("hts221: read control", err)
+	}
 	if data[0]&0x80 == 0 {
 		return errors.New("device is off, unable to query")
 	}
@@ -148,19 +180,28 @@ func (d *Device) waitForOneShot(filter uint8) error {
 	// wait until one shot (one conversion) is ready to go
 	data[0] = 1
 	for {
-		d.bus.ReadRegister(d.Address, HTS221_CTRL2_REG, data)
+		err = d.bus.ReadRegister(d.Address, HTS221_CTRL2_REG, data)
+		if err != nil {
+			return drivers.WrapError("hts221: read control", err)
+		}
 		if data[0]&0x01 == 0 {
 			break
 		}
 	}
 
 	// trigger one shot
-	d.bus.WriteRegister(d.Address, HTS221_CTRL2_REG, []byte{0x01})
+	err = d.bus.WriteRegister(d.Address, HTS221_CTRL2_REG, []byte{0x01})
+	if err != nil {
+		return drivers.WrapError("hts221: trigger one shot", err)
+	}
 
 	// wait until conversion completed
 	data[0] = 0
 	for {
-		d.bus.ReadRegister(d.Address, HTS221_STATUS_REG, data)
+		err = d.bus.ReadRegister(d.Address, HTS221_STATUS_REG, data)
+		if err != nil {
+			return drivers.WrapError("hts221: read status", err)
+		}
 		if data[0]&filter == filter {
 			break
 		}
@@ -169,6 +210,17 @@ func (d *Device) waitForOneShot(filter uint8) error {
 	return nil
 }
 
+// read consecutive registers, one at a time
+func (d *Device) readRegisters(reg uint8, data []byte) error {
+	for i := range data {
+		err := d.bus.ReadRegister(d.Address, reg+uint8(i), data[i:i+1])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func readUint(msb byte, lsb byte) uint16 {
 	return uint16(msb)<<8 | uint16(lsb)
 }
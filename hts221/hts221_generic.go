@@ -3,8 +3,6 @@
 
 package hts221
 
-import "tinygo.org/x/drivers"
-
 // Configure sets up the HTS221 device for communication.
 func (d *Device) Configure() {
 	// read calibration data
@@ -7,7 +7,6 @@ package hub75 // import "tinygo.org/x/drivers/hub75"
 
 import (
 	"image/color"
-	"machine"
 	"time"
 
 	"tinygo.org/x/drivers"
@@ -24,12 +23,12 @@ type Config struct {
 
 type Device struct {
 	bus               drivers.SPI
-	a                 machine.Pin
-	b                 machine.Pin
-	c                 machine.Pin
-	d                 machine.Pin
-	oe                machine.Pin
-	lat               machine.Pin
+	a                 drivers.Pin
+	b                 drivers.Pin
+	c                 drivers.Pin
+	d                 drivers.Pin
+	oe                drivers.Pin
+	lat               drivers.Pin
 	width             int16
 	height            int16
 	brightness        uint8
@@ -54,13 +53,13 @@ type Device struct {
 }
 
 // New returns a new HUB75 driver. Pass in a fully configured SPI bus.
-func New(b drivers.SPI, latPin, oePin, aPin, bPin, cPin, dPin machine.Pin) Device {
-	aPin.Configure(machine.PinConfig{Mode: machine.PinOutput})
-	bPin.Configure(machine.PinConfig{Mode: machine.PinOutput})
-	cPin.Configure(machine.PinConfig{Mode: machine.PinOutput})
-	dPin.Configure(machine.PinConfig{Mode: machine.PinOutput})
-	oePin.Configure(machine.PinConfig{Mode: machine.PinOutput})
-	latPin.Configure(machine.PinConfig{Mode: machine.PinOutput})
+func New(b drivers.SPI, latPin, oePin, aPin, bPin, cPin, dPin drivers.Pin) Device {
+	drivers.ConfigurePin(aPin, drivers.PinOutput)
+	drivers.ConfigurePin(bPin, drivers.PinOutput)
+	drivers.ConfigurePin(cPin, drivers.PinOutput)
+	drivers.ConfigurePin(dPin, drivers.PinOutput)
+	drivers.ConfigurePin(oePin, drivers.PinOutput)
+	drivers.ConfigurePin(latPin, drivers.PinOutput)
 
 	return Device{
 		bus: b,
@@ -0,0 +1,105 @@
+// Package i2cscan finds the devices on an I2C bus and identifies them.
+//
+// Scan tries every address of the bus, then runs the probes registered for
+// each responding address. A probe usually reads an identification
+// register, such as WHO_AM_I or a chip ID, in the same way as the Connected
+// method of a driver. The probes of the drivers in this repository are
+// registered by default, and probes for other devices can be added with
+// Register.
+package i2cscan // import "tinygo.org/x/drivers/i2cscan"
+
+import (
+	"fmt"
+	"strings"
+
+	"tinygo.org/x/drivers"
+)
+
+// Addresses outside this range are reserved by the I2C specification.
+const (
+	firstAddress = 0x08
+	lastAddress  = 0x77
+)
+
+// Probe identifies one kind of device.
+type Probe struct {
+	// Driver is the name of the driver package for the device, such as
+	// "bme280".
+	Driver string
+
+	// Addresses are the addresses the device can answer at.
+	Addresses []uint16
+
+	// Identify returns whether the device answering at addr is of this
+	// kind.
+	Identify func(bus drivers.I2C, addr uint16) bool
+}
+
+var probes []Probe
+
+// Register adds a probe to the ones run by Scan and Identify.
+func Register(p Probe) {
+	probes = append(probes, p)
+}
+
+// Device is a device found on the bus.
+type Device struct {
+	Address uint16
+
+	// Drivers lists the drivers whose probe identified the device. It is
+	// empty for a device that could not be identified, and may hold
+	// several drivers for devices that share an address and a chip ID.
+	Drivers []string
+}
+
+// String returns the address of the device followed by its drivers.
+func (d Device) String() string {
+	if len(d.Drivers) == 0 {
+		return fmt.Sprintf("%#02x: unknown", d.Address)
+	}
+	return fmt.Sprintf("%#02x: %s", d.Address, strings.Join(d.Drivers, ", "))
+}
+
+// Responds returns whether a device acknowledges the given address, by
+// reading a single byte from it.
+func Responds(bus drivers.I2C, addr uint16) bool {
+	var buf [1]byte
+	return bus.Tx(addr, nil, buf[:]) == nil
+}
+
+// Identify runs the probes registered for the given address and returns
+// the drivers of the probes that identified the device.
+func Identify(bus drivers.I2C, addr uint16) []string {
+	var found []string
+	for _, p := range probes {
+		if hasAddress(p.Addresses, addr) && p.Identify(bus, addr) {
+			found = append(found, p.Driver)
+		}
+	}
+	return found
+}
+
+// Scan returns the devices that respond on the bus, in address order, with
+// the drivers that identified them.
+func Scan(bus drivers.I2C) []Device {
+	var devices []Device
+	for addr := uint16(firstAddress); addr <= lastAddress; addr++ {
+		if !Responds(bus, addr) {
+			continue
+		}
+		devices = append(devices, Device{
+			Address: addr,
+			Drivers: Identify(bus, addr),
+		})
+	}
+	return devices
+}
+
+func hasAddress(addresses []uint16, addr uint16) bool {
+	for _, a := range addresses {
+		if a == addr {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+package i2cscan
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"tinygo.org/x/drivers"
+	"tinygo.org/x/drivers/tester"
+)
+
+func TestScan(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewI2CBus(c)
+	bus.NACKUnknown = true
+	bus.NewDevice(0x76).Registers[0xd0] = 0x60
+	bus.NewDevice(0x77).Registers[0xd0] = 0x58
+	bus.NewDevice(0x68).Registers[0x75] = 0x68
+	bus.NewDevice(0x50)
+
+	devices := Scan(bus)
+	c.Assert(devices, qt.DeepEquals, []Device{
+		{Address: 0x50},
+		{Address: 0x68, Drivers: []string{"mpu6050"}},
+		{Address: 0x76, Drivers: []string{"bme280"}},
+		{Address: 0x77, Drivers: []string{"bmp280"}},
+	})
+	c.Assert(devices[0].String(), qt.Equals, "0x50: unknown")
+	c.Assert(devices[1].String(), qt.Equals, "0x68: mpu6050")
+
+	registered := probes
+	t.Cleanup(func() { probes = registered })
+	Register(Probe{
+		Driver:    "at24cx",
+		Addresses: []uint16{0x50},
+		Identify: func(bus drivers.I2C, addr uint16) bool {
+			return true
+		},
+	})
+	c.Assert(Identify(bus, 0x50), qt.DeepEquals, []string{"at24cx"})
+}
@@ -0,0 +1,50 @@
+package i2cscan
+
+import (
+	"tinygo.org/x/drivers/adt7410"
+	"tinygo.org/x/drivers/apds9960"
+	"tinygo.org/x/drivers/bme280"
+	"tinygo.org/x/drivers/bmp180"
+	"tinygo.org/x/drivers/bmp280"
+	"tinygo.org/x/drivers/bmp388"
+	"tinygo.org/x/drivers/ina260"
+	"tinygo.org/x/drivers/lis2mdl"
+	"tinygo.org/x/drivers/lis3dh"
+	"tinygo.org/x/drivers/lsm6ds3"
+	"tinygo.org/x/drivers/lsm6dsox"
+	"tinygo.org/x/drivers/mag3110"
+	"tinygo.org/x/drivers/mma8653"
+	"tinygo.org/x/drivers/mpu6050"
+	"tinygo.org/x/drivers/tmp102"
+	"tinygo.org/x/drivers/vl53l1x"
+)
+
+// The probes of the drivers in this repository, with all the addresses that
+// their devices can be configured for.
+func init() {
+	Register(Probe{"adt7410", []uint16{0x48, 0x49, 0x4a, 0x4b}, adt7410.Probe})
+	Register(Probe{"apds9960", []uint16{0x39}, apds9960.Probe})
+	Register(Probe{"bme280", []uint16{0x76, 0x77}, bme280.Probe})
+	Register(Probe{"bmp180", []uint16{0x77}, bmp180.Probe})
+	Register(Probe{"bmp280", []uint16{0x76, 0x77}, bmp280.Probe})
+	Register(Probe{"bmp388", []uint16{0x76, 0x77}, bmp388.Probe})
+	Register(Probe{"ina260", addressRange(0x40, 0x4f), ina260.Probe})
+	Register(Probe{"lis2mdl", []uint16{0x1e}, lis2mdl.Probe})
+	Register(Probe{"lis3dh", []uint16{0x18, 0x19}, lis3dh.Probe})
+	Register(Probe{"lsm6ds3", []uint16{0x6a, 0x6b}, lsm6ds3.Probe})
+	Register(Probe{"lsm6dsox", []uint16{0x6a, 0x6b}, lsm6dsox.Probe})
+	Register(Probe{"mag3110", []uint16{0x0e}, mag3110.Probe})
+	Register(Probe{"mma8653", []uint16{0x1d}, mma8653.Probe})
+	Register(Probe{"mpu6050", []uint16{0x68, 0x69}, mpu6050.Probe})
+	Register(Probe{"tmp102", []uint16{0x48, 0x49, 0x4a, 0x4b}, tmp102.Probe})
+	Register(Probe{"vl53l1x", []uint16{0x29}, vl53l1x.Probe})
+}
+
+// addressRange returns the addresses from first to last included.
+func addressRange(first, last uint16) []uint16 {
+	var addresses []uint16
+	for addr := first; addr <= last; addr++ {
+		addresses = append(addresses, addr)
+	}
+	return addresses
+}
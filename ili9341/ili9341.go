@@ -1,10 +1,11 @@
 package ili9341
 
 import (
-	"errors"
 	"image/color"
-	"machine"
 	"time"
+
+	"tinygo.org/x/drivers"
+	"tinygo.org/x/drivers/pixel"
 )
 
 type Config struct {
@@ -23,10 +24,12 @@ type Device struct {
 	x0, x1 int16 // cached address window; prevents useless/expensive
 	y0, y1 int16 // syscalls to PASET and CASET
 
-	dc  machine.Pin
-	cs  machine.Pin
-	rst machine.Pin
-	rd  machine.Pin
+	dc  drivers.Pin
+	cs  drivers.Pin
+	rst drivers.Pin
+	rd  drivers.Pin
+
+	sleeping bool
 }
 
 var cmdBuf [6]byte
@@ -73,29 +76,27 @@ func (d *Device) Configure(config Config) {
 	d.x0, d.x1 = -(d.width + 1), d.x0
 	d.y0, d.y1 = -(d.height + 1), d.y0
 
-	output := machine.PinConfig{machine.PinOutput}
-
 	// configure chip select if there is one
-	if d.cs != machine.NoPin {
-		d.cs.Configure(output)
+	if !drivers.IsNoPin(d.cs) {
+		drivers.ConfigurePin(d.cs, drivers.PinOutput)
 		d.cs.High() // deselect
 	}
 
-	d.dc.Configure(output)
+	drivers.ConfigurePin(d.dc, drivers.PinOutput)
 	d.dc.High() // data mode
 
 	// driver-specific configuration
 	d.driver.configure(&config)
 
-	if d.rd != machine.NoPin {
-		d.rd.Configure(output)
+	if !drivers.IsNoPin(d.rd) {
+		drivers.ConfigurePin(d.rd, drivers.PinOutput)
 		d.rd.High()
 	}
 
 	// reset the display
-	if d.rst != machine.NoPin {
+	if !drivers.IsNoPin(d.rst) {
 		// configure hardware reset if there is one
-		d.rst.Configure(output)
+		drivers.ConfigurePin(d.rst, drivers.PinOutput)
 		d.rst.High()
 		delay(100)
 		d.rst.Low()
@@ -136,7 +137,7 @@ func (d *Device) Configure(config Config) {
 
 // Size returns the current size of the display.
 func (d *Device) Size() (x, y int16) {
-	if d.rotation == 1 || d.rotation == 3 {
+	if d.rotation&1 == 1 { // 90 and 270 degrees, mirrored or not
 		return d.height, d.width
 	}
 	return d.width, d.height
@@ -145,7 +146,7 @@ func (d *Device) Size() (x, y int16) {
 // SetPixel modifies the internal buffer.
 func (d *Device) SetPixel(x, y int16, c color.RGBA) {
 	d.setWindow(x, y, 1, 1)
-	c565 := RGBATo565(c)
+	c565 := pixel.RGB565(c)
 	d.startWrite()
 	d.driver.write16(c565)
 	d.endWrite()
@@ -161,7 +162,10 @@ func (d *Device) DrawRGBBitmap(x, y int16, data []uint16, w, h int16) error {
 	k, i := d.Size()
 	if x < 0 || y < 0 || w <= 0 || h <= 0 ||
 		x >= k || (x+w) > k || y >= i || (y+h) > i {
-		return errors.New("rectangle coordinates outside display area")
+		return drivers.ErrOutOfBounds
+	}
+	if int32(w)*int32(h) != int32(len(data)) {
+		return drivers.ErrBufferSize
 	}
 	d.setWindow(x, y, w, h)
 	d.startWrite()
@@ -175,7 +179,10 @@ func (d *Device) DrawRGBBitmap8(x, y int16, data []uint8, w, h int16) error {
 	k, i := d.Size()
 	if x < 0 || y < 0 || w <= 0 || h <= 0 ||
 		x >= k || (x+w) > k || y >= i || (y+h) > i {
-		return errors.New("rectangle coordinates outside display area")
+		return drivers.ErrOutOfBounds
+	}
+	if int32(w)*int32(h)*2 != int32(len(data)) {
+		return drivers.ErrBufferSize
 	}
 	d.setWindow(x, y, w, h)
 	d.startWrite()
@@ -189,16 +196,38 @@ func (d *Device) FillRectangle(x, y, width, height int16, c color.RGBA) error {
 	k, i := d.Size()
 	if x < 0 || y < 0 || width <= 0 || height <= 0 ||
 		x >= k || (x+width) > k || y >= i || (y+height) > i {
-		return errors.New("rectangle coordinates outside display area")
+		return drivers.ErrOutOfBounds
 	}
 	d.setWindow(x, y, width, height)
-	c565 := RGBATo565(c)
+	c565 := pixel.RGB565(c)
 	d.startWrite()
 	d.driver.write16n(c565, int(width)*int(height))
 	d.endWrite()
 	return nil
 }
 
+// FillRectangleWithBuffer fills a rectangle at given coordinates with a buffer
+func (d *Device) FillRectangleWithBuffer(x, y, width, height int16, buffer []color.RGBA) error {
+	k, i := d.Size()
+	if x < 0 || y < 0 || width <= 0 || height <= 0 ||
+		x >= k || (x+width) > k || y >= i || (y+height) > i {
+		return drivers.ErrOutOfBounds
+	}
+	if int32(width)*int32(height) != int32(len(buffer)) {
+		return drivers.ErrBufferSize
+	}
+	d.setWindow(x, y, width, height)
+	var line [64]uint16
+	d.startWrite()
+	for len(buffer) > 0 {
+		n := pixel.ToRGB565(line[:], buffer)
+		d.driver.write16sl(line[:n])
+		buffer = buffer[n:]
+	}
+	d.endWrite()
+	return nil
+}
+
 // DrawRectangle draws a rectangle at given coordinates with a color
 func (d *Device) DrawRectangle(x, y, w, h int16, c color.RGBA) error {
 	if err := d.DrawFastHLine(x, x+w-1, y, c); err != nil {
@@ -233,23 +262,30 @@ func (d *Device) DrawFastHLine(x0, x1, y int16, c color.RGBA) error {
 }
 
 // FillScreen fills the screen with a given color
-func (d *Device) FillScreen(c color.RGBA) {
-	if d.rotation == Rotation0 || d.rotation == Rotation180 {
-		d.FillRectangle(0, 0, d.width, d.height, c)
-	} else {
-		d.FillRectangle(0, 0, d.height, d.width, c)
-	}
+func (d *Device) FillScreen(c color.RGBA) error {
+	w, h := d.Size()
+	return d.FillRectangle(0, 0, w, h, c)
+}
+
+// Rotation returns the current rotation of the device.
+func (d *Device) Rotation() Rotation {
+	return d.rotation
 }
 
 // GetRotation returns the current rotation of the device
+//
+// Deprecated: use Rotation.
 func (d *Device) GetRotation() Rotation {
 	return d.rotation
 }
 
 // SetRotation changes the rotation of the device (clock-wise)
-func (d *Device) SetRotation(rotation Rotation) {
+func (d *Device) SetRotation(rotation Rotation) error {
+	if rotation > Rotation270Mirror {
+		return drivers.ErrInvalidConfig
+	}
 	madctl := uint8(0)
-	switch rotation % 8 {
+	switch rotation {
 	case Rotation0:
 		madctl = MADCTL_MX | MADCTL_BGR
 	case Rotation90:
@@ -270,30 +306,34 @@ func (d *Device) SetRotation(rotation Rotation) {
 	cmdBuf[0] = madctl
 	d.sendCommand(MADCTL, cmdBuf[:1])
 	d.rotation = rotation
+	return nil
 }
 
 // SetScrollArea sets an area to scroll with fixed top/bottom or left/right parts of the display
 // Rotation affects scroll direction
-func (d *Device) SetScrollArea(topFixedArea, bottomFixedArea int16) {
+func (d *Device) SetScrollArea(topFixedArea, bottomFixedArea int16) error {
 	cmdBuf[0] = uint8(topFixedArea >> 8)
 	cmdBuf[1] = uint8(topFixedArea)
-	cmdBuf[2] = uint8(d.height - topFixedArea - bottomFixedArea>>8)
+	cmdBuf[2] = uint8((d.height - topFixedArea - bottomFixedArea) >> 8)
 	cmdBuf[3] = uint8(d.height - topFixedArea - bottomFixedArea)
 	cmdBuf[4] = uint8(bottomFixedArea >> 8)
 	cmdBuf[5] = uint8(bottomFixedArea)
 	d.sendCommand(VSCRDEF, cmdBuf[:6])
+	return nil
 }
 
 // SetScroll sets the vertical scroll address of the display.
-func (d *Device) SetScroll(line int16) {
+func (d *Device) SetScroll(line int16) error {
 	cmdBuf[0] = uint8(line >> 8)
 	cmdBuf[1] = uint8(line)
 	d.sendCommand(VSCRSADD, cmdBuf[:2])
+	return nil
 }
 
 // StopScroll returns the display to its normal state
-func (d *Device) StopScroll() {
+func (d *Device) StopScroll() error {
 	d.sendCommand(NORON, nil)
+	return nil
 }
 
 // setWindow prepares the screen to be modified at a given rectangle
@@ -323,18 +363,43 @@ func (d *Device) setWindow(x, y, w, h int16) {
 
 //go:inline
 func (d *Device) startWrite() {
-	if d.cs != machine.NoPin {
+	if !drivers.IsNoPin(d.cs) {
 		d.cs.Low()
 	}
 }
 
 //go:inline
 func (d *Device) endWrite() {
-	if d.cs != machine.NoPin {
+	if !drivers.IsNoPin(d.cs) {
 		d.cs.High()
 	}
 }
 
+// Sleep turns the display off and puts the controller in sleep mode. The
+// content of the display memory is kept. It implements drivers.Sleeper.
+func (d *Device) Sleep() error {
+	d.sendCommand(DISPOFF, nil)
+	d.sendCommand(SLPIN, nil)
+	time.Sleep(5 * time.Millisecond)
+	d.sleeping = true
+	return nil
+}
+
+// Wake takes the controller out of sleep mode and turns the display back on.
+// It implements drivers.Sleeper.
+func (d *Device) Wake() error {
+	d.sendCommand(SLPOUT, nil)
+	time.Sleep(5 * time.Millisecond) // required before the next command
+	d.sendCommand(DISPON, nil)
+	d.sleeping = false
+	return nil
+}
+
+// Sleeping returns whether the display has been put to sleep.
+func (d *Device) Sleeping() bool {
+	return d.sleeping
+}
+
 func (d *Device) sendCommand(cmd byte, data []byte) {
 	d.startWrite()
 	d.dc.Low()
@@ -363,9 +428,8 @@ func delay(m int) {
 }
 
 // RGBATo565 converts a color.RGBA to uint16 used in the display
+//
+// Deprecated: use pixel.RGB565.
 func RGBATo565(c color.RGBA) uint16 {
-	r, g, b, _ := c.RGBA()
-	return uint16((r & 0xF800) +
-		((g & 0xFC00) >> 5) +
-		((b & 0xF800) >> 11))
+	return pixel.RGB565(c)
 }
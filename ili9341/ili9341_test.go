@@ -0,0 +1,86 @@
+package ili9341
+
+import (
+	"image/color"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"tinygo.org/x/drivers"
+	"tinygo.org/x/drivers/tester"
+)
+
+// newEmulatedDevice returns a device of the given size connected to an
+// emulated controller with the same memory size, in 16 bits per pixel.
+func newEmulatedDevice(c *qt.C, width, height int16) (*Device, *tester.DisplayController) {
+	ctrl := tester.NewDisplayController(c, width, height)
+	ctrl.BGR = true
+	d := NewSPI(ctrl, ctrl.DC, tester.NewPin(), tester.NewPin())
+	d.width, d.height = width, height
+	// No address window is cached yet.
+	d.x0, d.x1 = -1, -1
+	d.y0, d.y1 = -1, -1
+	d.sendCommand(PIXFMT, []byte{0x55})
+	return d, ctrl
+}
+
+type bufferDisplayer interface {
+	drivers.AcceleratedDisplayer
+	FillRectangleWithBuffer(x, y, width, height int16, buffer []color.RGBA) error
+}
+
+// drawScene draws with all the drawing methods, close to the corners of the
+// display.
+func drawScene(c *qt.C, d bufferDisplayer) {
+	w, h := d.Size()
+	buffer := make([]color.RGBA, 8*5)
+	for i := range buffer {
+		buffer[i] = color.RGBA{uint8(i * 6), 255 - uint8(i*6), 0x80, 255}
+	}
+	c.Assert(d.FillRectangle(1, 2, 5, 3, color.RGBA{255, 0, 0, 255}), qt.IsNil)
+	c.Assert(d.FillRectangleWithBuffer(w-8, h-5, 8, 5, buffer), qt.IsNil)
+	c.Assert(d.DrawRGBBitmap(0, h-2, []uint16{0xf800, 0x07e0, 0x001f, 0xffff, 0x0000, 0x1234}, 3, 2), qt.IsNil)
+	d.SetPixel(w-1, 0, color.RGBA{255, 255, 255, 255})
+}
+
+// mirrored returns the memory of a display mirrored horizontally.
+func mirrored(d *tester.Display) []uint8 {
+	m := d.Memory()
+	w, h := m.Rect.Dx(), m.Rect.Dy()
+	mirror := tester.NewDisplay(int16(w), int16(h), nil).Memory()
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			mirror.SetRGBA(w-1-x, y, m.RGBAAt(x, y))
+		}
+	}
+	return mirror.Pix
+}
+
+func TestRotationsOnController(t *testing.T) {
+	c := qt.New(t)
+	for rotation := Rotation0; rotation <= Rotation270; rotation++ {
+		d, ctrl := newEmulatedDevice(c, 24, 32)
+		c.Assert(d.SetRotation(rotation), qt.IsNil)
+		drawScene(c, d)
+
+		// The panel shows the memory mirrored horizontally.
+		want := tester.NewDisplay(24, 32, tester.RGB565Model)
+		c.Assert(want.SetRotation(rotation), qt.IsNil)
+		drawScene(c, want)
+		c.Assert(ctrl.Display.Memory().Pix, qt.DeepEquals, mirrored(want), qt.Commentf("rotation %d", rotation))
+	}
+}
+
+func TestScrollOnController(t *testing.T) {
+	c := qt.New(t)
+	d, ctrl := newEmulatedDevice(c, 24, 32)
+	c.Assert(d.SetRotation(Rotation0), qt.IsNil)
+	c.Assert(d.FillRectangle(0, 10, 24, 1, color.RGBA{255, 255, 255, 255}), qt.IsNil)
+
+	c.Assert(d.SetScrollArea(4, 4), qt.IsNil)
+	c.Assert(d.SetScroll(10), qt.IsNil)
+	c.Assert(ctrl.Display.Frame().RGBAAt(0, 4), qt.Equals, color.RGBA{255, 255, 255, 255})
+	c.Assert(ctrl.Display.Frame().RGBAAt(0, 10), qt.Equals, color.RGBA{0, 0, 0, 255})
+
+	c.Assert(d.StopScroll(), qt.IsNil)
+	c.Assert(ctrl.Display.Frame().RGBAAt(0, 10), qt.Equals, color.RGBA{255, 255, 255, 255})
+}
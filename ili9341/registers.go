@@ -1,6 +1,10 @@
 package ili9341
 
-type Rotation uint8
+import "tinygo.org/x/drivers"
+
+// Rotation controls the rotation used by the display. The mirrored
+// rotations are specific to the ILI9341.
+type Rotation = drivers.Rotation
 
 const (
 
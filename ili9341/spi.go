@@ -3,11 +3,7 @@
 
 package ili9341
 
-import (
-	"machine"
-
-	"tinygo.org/x/drivers"
-)
+import "tinygo.org/x/drivers"
 
 var buf [64]byte
 
@@ -15,12 +11,11 @@ type spiDriver struct {
 	bus drivers.SPI
 }
 
-func NewSPI(bus drivers.SPI, dc, cs, rst machine.Pin) *Device {
+func NewSPI(bus drivers.SPI, dc, cs, rst drivers.Pin) *Device {
 	return &Device{
 		dc:  dc,
 		cs:  cs,
 		rst: rst,
-		rd:  machine.NoPin,
 		driver: &spiDriver{
 			bus: bus,
 		},
@@ -62,7 +57,7 @@ func (pd *spiDriver) write16n(data uint16, n int) {
 		pd.bus.Tx(buf[:], nil)
 	}
 
-	pd.bus.Tx(buf[:n%64], nil)
+	pd.bus.Tx(buf[:(n%32)*2], nil)
 }
 
 func (pd *spiDriver) write16sl(data []uint16) {
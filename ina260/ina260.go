@@ -44,7 +44,7 @@ func New(bus drivers.I2C) Device {
 // * CurrentConvTime = CONVTIME_1100USEC
 // * Mode = MODE_CONTINUOUS | MODE_VOLTAGE | MODE_CURRENT
 //
-func (d *Device) Configure(cfg Config) {
+func (d *Device) Configure(cfg Config) error {
 	var val uint16
 
 	val = uint16(cfg.AverageMode&0x7) << 9
@@ -52,61 +52,83 @@ func (d *Device) Configure(cfg Config) {
 	val |= uint16(cfg.CurrentConvTime&0x7) << 3
 	val |= uint16(cfg.Mode & 0x7)
 
-	d.WriteRegister(REG_CONFIG, val)
+	return d.WriteRegister(REG_CONFIG, val)
 }
 
 // Resets the device, setting all registers to default values
-func (d *Device) Reset() {
-	d.WriteRegister(REG_CONFIG, 0x8000)
+func (d *Device) Reset() error {
+	return d.WriteRegister(REG_CONFIG, 0x8000)
 }
 
 // Connected returns whether an INA260 has been found.
 func (d *Device) Connected() bool {
-	return d.ReadRegister(REG_MANF_ID) == MANF_ID &&
-		(d.ReadRegister(REG_DIE_ID)&DEVICE_ID_MASK) == DEVICE_ID
+	manfID, err := d.ReadRegister(REG_MANF_ID)
+	if err != nil || manfID != MANF_ID {
+		return false
+	}
+	dieID, err := d.ReadRegister(REG_DIE_ID)
+	return err == nil && dieID&DEVICE_ID_MASK == DEVICE_ID
+}
+
+// Probe returns whether an INA260 answers at addr.
+func Probe(bus drivers.I2C, addr uint16) bool {
+	d := New(bus)
+	d.Address = addr
+	return d.Connected()
 }
 
 // Gets the measured current in µA (max resolution 1.25mA)
-func (d *Device) Current() int32 {
-	val := d.ReadRegister(REG_CURRENT)
+func (d *Device) Current() (int32, error) {
+	val, err := d.ReadRegister(REG_CURRENT)
+	if err != nil {
+		return 0, err
+	}
 
 	if val&0x8000 == 0 {
-		return int32(val) * 1250
+		return int32(val) * 1250, nil
 	}
 
 	// Two's complement, convert to signed int
-	return -(int32(^val) + 1) * 1250
+	return -(int32(^val) + 1) * 1250, nil
 }
 
 // Gets the measured voltage in µV (max resolution 1.25mV)
-func (d *Device) Voltage() int32 {
-	val := d.ReadRegister(REG_BUSVOLTAGE)
+func (d *Device) Voltage() (int32, error) {
+	val, err := d.ReadRegister(REG_BUSVOLTAGE)
+	if err != nil {
+		return 0, err
+	}
 
 	if val&0x8000 == 0 {
-		return int32(val) * 1250
+		return int32(val) * 1250, nil
 	}
 
 	// Two's complement, convert to signed int
-	return -(int32(^val) + 1) * 1250
+	return -(int32(^val) + 1) * 1250, nil
 }
 
 // Gets the measured power in µW (max resolution 10mW)
-func (d *Device) Power() int32 {
-	return int32(d.ReadRegister(REG_POWER)) * 10000
+func (d *Device) Power() (int32, error) {
+	val, err := d.ReadRegister(REG_POWER)
+	return int32(val) * 10000, err
 }
 
 // Read a register
-func (d *Device) ReadRegister(reg uint8) uint16 {
+func (d *Device) ReadRegister(reg uint8) (uint16, error) {
 	data := []byte{0, 0}
-	d.bus.ReadRegister(uint8(d.Address), reg, data)
-	return (uint16(data[0]) << 8) | uint16(data[1])
+	err := d.bus.ReadRegister(uint8(d.Address), reg, data)
+	if err != nil {
+		return 0, drivers.WrapError("ina260: read register", err)
+	}
+	return (uint16(data[0]) << 8) | uint16(data[1]), nil
 }
 
 // Write to a register
-func (d *Device) WriteRegister(reg uint8, v uint16) {
+func (d *Device) WriteRegister(reg uint8, v uint16) error {
 	data := []byte{0, 0}
 	data[0] = byte(v >> 8)
 	data[1] = byte(v & 0xff)
 
-	d.bus.WriteRegister(uint8(d.Address), reg, data)
+	err := d.bus.WriteRegister(uint8(d.Address), reg, data)
+	return drivers.WrapError("ina260: write register", err)
 }
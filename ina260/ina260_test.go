@@ -1,6 +1,7 @@
 package ina260
 
 import (
+	"errors"
 	"testing"
 
 	qt "github.com/frankban/quicktest"
@@ -35,7 +36,9 @@ func TestVoltage(t *testing.T) {
 
 	dev := New(bus)
 	// Datasheet: 2570h = 11.98V = 11980mV = 11980000uV
-	c.Assert(dev.Voltage(), qt.Equals, int32(11980000))
+	v, err := dev.Voltage()
+	c.Assert(err, qt.IsNil)
+	c.Assert(v, qt.Equals, int32(11980000))
 }
 
 func TestCurrent(t *testing.T) {
@@ -48,7 +51,9 @@ func TestCurrent(t *testing.T) {
 
 	dev := New(bus)
 	// Datasheet: 2710h = 12.5A = 12500mA = 12500000uA
-	c.Assert(dev.Current(), qt.Equals, int32(12500000))
+	v, err := dev.Current()
+	c.Assert(err, qt.IsNil)
+	c.Assert(v, qt.Equals, int32(12500000))
 }
 
 func TestPower(t *testing.T) {
@@ -61,7 +66,25 @@ func TestPower(t *testing.T) {
 
 	dev := New(bus)
 	// 3A7Fh = 149.75W = 149750mW = 149750000uW
-	c.Assert(dev.Power(), qt.Equals, int32(149750000))
+	v, err := dev.Power()
+	c.Assert(err, qt.IsNil)
+	c.Assert(v, qt.Equals, int32(149750000))
+}
+
+func TestBusError(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewI2CBus(c)
+	fake := tester.NewI2CDevice16(c, Address)
+	fake.Registers = defaultRegisters()
+	bus.AddDevice(fake)
+	bus.NACK(Address)
+
+	dev := New(bus)
+	c.Assert(dev.Connected(), qt.IsFalse)
+	_, err := dev.Current()
+	c.Assert(err, qt.ErrorMatches, "ina260: read register: .*")
+	c.Assert(errors.Is(err, tester.ErrNACK), qt.IsTrue)
+	c.Assert(errors.Is(dev.Configure(Config{}), tester.ErrNACK), qt.IsTrue)
 }
 
 // defaultRegisters returns the default values for all of the device's registers.
@@ -0,0 +1,79 @@
+// Package events implements the event queue of the input drivers, such as
+// the button and keypad packages.
+package events // import "tinygo.org/x/drivers/internal/events"
+
+import "time"
+
+// DefaultQueueSize is the size of a Queue reset with a size of zero.
+const DefaultQueueSize = 16
+
+// Never is a time that is never reached, for the events that are not
+// scheduled.
+const Never = time.Duration(1<<63 - 1)
+
+// Event is an event of an input driver: Type is the kind of event, as
+// defined by the driver, and Index the button or key it happened to.
+type Event struct {
+	Type  uint8
+	Index int
+}
+
+// Queue is a fixed size queue of input events, filled as the inputs are
+// polled and emptied by the application. Events pushed while the queue is
+// full are dropped and counted. The zero value is a queue that drops every
+// event until Reset is called.
+type Queue struct {
+	events  []Event
+	head    int
+	n       int
+	dropped int
+}
+
+// Reset empties the queue, clears the count of dropped events and sets the
+// size of the queue, which is DefaultQueueSize if size is zero.
+func (q *Queue) Reset(size int) {
+	if size == 0 {
+		size = DefaultQueueSize
+	}
+	if cap(q.events) >= size {
+		q.events = q.events[:size]
+	} else {
+		q.events = make([]Event, size)
+	}
+	q.head, q.n, q.dropped = 0, 0, 0
+}
+
+// Push queues an event, or drops it if the queue is full.
+func (q *Queue) Push(t uint8, index int) {
+	if q.n == len(q.events) {
+		q.dropped++
+		return
+	}
+	q.events[(q.head+q.n)%len(q.events)] = Event{Type: t, Index: index}
+	q.n++
+}
+
+// Next returns the oldest queued event. It returns false if there is none.
+func (q *Queue) Next() (Event, bool) {
+	if q.n == 0 {
+		return Event{}, false
+	}
+	e := q.events[q.head]
+	q.head = (q.head + 1) % len(q.events)
+	q.n--
+	return e, true
+}
+
+// Dropped returns the number of events dropped because the queue was full.
+func (q *Queue) Dropped() int {
+	return q.dropped
+}
+
+// Name returns the name of an event type from the names defined by a
+// driver, or "unknown" if there is none.
+func Name(names []string, t uint8) string {
+	if int(t) < len(names) {
+		return names[t]
+	}
+	return "unknown"
+}
@@ -0,0 +1,46 @@
+package events
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestQueue(t *testing.T) {
+	c := qt.New(t)
+	var q Queue
+	q.Push(1, 2)
+	c.Assert(q.Dropped(), qt.Equals, 1)
+
+	q.Reset(2)
+	q.Push(0, 1)
+	q.Push(1, 2)
+	q.Push(2, 3)
+	c.Assert(q.Dropped(), qt.Equals, 1)
+	e, ok := q.Next()
+	c.Assert(ok, qt.IsTrue)
+	c.Assert(e, qt.Equals, Event{Type: 0, Index: 1})
+
+	// The queue wraps around.
+	q.Push(3, 4)
+	for _, want := range []Event{{1, 2}, {3, 4}} {
+		e, ok = q.Next()
+		c.Assert(ok, qt.IsTrue)
+		c.Assert(e, qt.Equals, want)
+	}
+	_, ok = q.Next()
+	c.Assert(ok, qt.IsFalse)
+
+	q.Reset(0)
+	for i := 0; i < DefaultQueueSize+1; i++ {
+		q.Push(0, i)
+	}
+	c.Assert(q.Dropped(), qt.Equals, 1)
+}
+
+func TestName(t *testing.T) {
+	c := qt.New(t)
+	names := []string{"press", "release"}
+	c.Assert(Name(names, 1), qt.Equals, "release")
+	c.Assert(Name(names, 2), qt.Equals, "unknown")
+}
@@ -0,0 +1,299 @@
+// Package keypad scans key matrices of any size, such as membrane keypads
+// and macro pads, and reports debounced key events.
+//
+// The rows of the matrix are driven low one at a time, and the columns are
+// read with pull-ups: a pressed key pulls its column low while its row is
+// scanned. Scan must be called regularly, for example every millisecond,
+// and queues the events that Next returns:
+//
+//	kp := keypad.New(rows, columns)
+//	kp.Configure(keypad.Config{RepeatInterval: 100 * time.Millisecond})
+//	for {
+//		kp.Scan()
+//		for e, ok := kp.Next(); ok; e, ok = kp.Next() {
+//			println(e.Type.String(), e.Row, e.Column)
+//		}
+//		time.Sleep(time.Millisecond)
+//	}
+package keypad // import "tinygo.org/x/drivers/keypad"
+
+import (
+	"math/bits"
+	"time"
+
+	"tinygo.org/x/drivers"
+	"tinygo.org/x/drivers/internal/events"
+)
+
+// Defaults used for the zero values of Config.
+const (
+	defaultDebounce  = 10 * time.Millisecond
+	defaultHoldDelay = 500 * time.Millisecond
+)
+
+// MaxColumns is the maximum number of columns of a matrix.
+const MaxColumns = 64
+
+// EventType is the kind of an Event.
+type EventType uint8
+
+const (
+	// Press is sent when a key is pressed.
+	Press EventType = iota
+	// Release is sent when a key is released.
+	Release
+	// Hold is sent once when a key has been held for Config.HoldDelay.
+	Hold
+	// Repeat is sent every Config.RepeatInterval after Hold while the key
+	// is held.
+	Repeat
+)
+
+var eventTypeNames = []string{"press", "release", "hold", "repeat"}
+
+// String returns the name of the event type.
+func (t EventType) String() string {
+	return events.Name(eventTypeNames, uint8(t))
+}
+
+// Event is a change of a key.
+type Event struct {
+	Type   EventType
+	Row    int
+	Column int
+	// Key is the index of the key in the matrix: Row*columns + Column.
+	Key int
+}
+
+// Config holds the settings of a keypad.
+type Config struct {
+	// Debounce is how long a key must be stable before its change is
+	// reported. It is 10ms if zero; use a negative duration to disable
+	// debouncing.
+	Debounce time.Duration
+
+	// HoldDelay is how long a key must be held before a Hold event is
+	// sent. It is 500ms if zero.
+	HoldDelay time.Duration
+
+	// RepeatInterval is the time between the Repeat events of a held key.
+	// No Repeat events are sent if it is zero.
+	RepeatInterval time.Duration
+
+	// QueueSize is the number of events that can be queued before Next
+	// is called. Further events are dropped. It is 16 if zero.
+	QueueSize int
+
+	// Diodes must be set when each key has a diode, so that any number of
+	// keys can be pressed at once (n-key rollover). Without diodes, three
+	// keys at the corners of a rectangle make the fourth one look pressed:
+	// such scans are ignored and reported by Ghosting.
+	Diodes bool
+}
+
+// key is the state of a single key.
+type key struct {
+	raw     bool          // level read by the last scan
+	pressed bool          // debounced state
+	held    bool          // Hold has been sent
+	changed time.Duration // time of the last change of raw
+	next    time.Duration // time of the next Hold or Repeat event
+}
+
+// Device is a key matrix.
+type Device struct {
+	rows    []drivers.Pin
+	columns []drivers.Pin
+	config  Config
+	keys    []key
+	rowBits []uint64
+	start   time.Time
+	events  events.Queue
+
+	ghosting bool
+
+	// Clock is used to time the debouncing and the Hold and Repeat events.
+	// It is drivers.SystemClock by default.
+	Clock drivers.Clock
+}
+
+// New returns a keypad for the matrix wired to the given row and column
+// pins. It panics if there are more than MaxColumns columns.
+func New(rows, columns []drivers.Pin) *Device {
+	if len(columns) > MaxColumns {
+		panic("keypad: too many columns")
+	}
+	return &Device{
+		rows:    rows,
+		columns: columns,
+		keys:    make([]key, len(rows)*len(columns)),
+		rowBits: make([]uint64, len(rows)),
+		Clock:   drivers.SystemClock,
+	}
+}
+
+// Configure sets the row pins as outputs and the column pins as inputs with
+// pull-ups, and applies the settings of config. It clears the state of the
+// keys and the event queue.
+func (d *Device) Configure(config Config) {
+	if config.Debounce == 0 {
+		config.Debounce = defaultDebounce
+	}
+	if config.HoldDelay == 0 {
+		config.HoldDelay = defaultHoldDelay
+	}
+	if config.QueueSize == 0 {
+		config.QueueSize = events.DefaultQueueSize
+	}
+	d.config = config
+
+	for _, pin := range d.columns {
+		drivers.ConfigurePin(pin, drivers.PinInputPullup)
+	}
+	for _, pin := range d.rows {
+		drivers.ConfigurePin(pin, drivers.PinOutput)
+		pin.High()
+	}
+
+	for i := range d.keys {
+		d.keys[i] = key{}
+	}
+	d.events.Reset(config.QueueSize)
+	d.ghosting = false
+	d.start = d.Clock.Now()
+}
+
+// Rows returns the number of rows of the matrix.
+func (d *Device) Rows() int {
+	return len(d.rows)
+}
+
+// Columns returns the number of columns of the matrix.
+func (d *Device) Columns() int {
+	return len(d.columns)
+}
+
+// Scan reads the whole matrix once, and queues the events of the keys that
+// changed.
+func (d *Device) Scan() {
+	now := d.Clock.Now().Sub(d.start)
+
+	for r, row := range d.rows {
+		row.Low()
+		var b uint64
+		for c, column := range d.columns {
+			if !column.Get() {
+				b |= 1 << uint(c)
+			}
+		}
+		row.High()
+		d.rowBits[r] = b
+	}
+
+	d.ghosting = !d.config.Diodes && d.ghosted()
+	for r := range d.rows {
+		for c := range d.columns {
+			i := r*len(d.columns) + c
+			k := &d.keys[i]
+			if !d.ghosting {
+				raw := d.rowBits[r]&(1<<uint(c)) != 0
+				if raw != k.raw {
+					k.raw = raw
+					k.changed = now
+				}
+			}
+			d.update(k, r, c, now)
+		}
+	}
+}
+
+// ghosted returns whether two rows share two pressed columns: these four
+// keys form a rectangle, and any of them may be a ghost of the others.
+func (d *Device) ghosted() bool {
+	for i := range d.rowBits {
+		if d.rowBits[i] == 0 {
+			continue
+		}
+		for j := i + 1; j < len(d.rowBits); j++ {
+			if bits.OnesCount64(d.rowBits[i]&d.rowBits[j]) >= 2 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// update debounces a key and queues its events.
+func (d *Device) update(k *key, row, column int, now time.Duration) {
+	if k.raw != k.pressed && now-k.changed >= d.config.Debounce {
+		k.pressed = k.raw
+		k.held = false
+		if k.pressed {
+			k.next = now + d.config.HoldDelay
+			d.push(Press, row, column)
+		} else {
+			d.push(Release, row, column)
+		}
+		return
+	}
+	if !k.pressed || now < k.next {
+		return
+	}
+	if !k.held {
+		k.held = true
+		d.push(Hold, row, column)
+	} else {
+		d.push(Repeat, row, column)
+	}
+	if d.config.RepeatInterval > 0 {
+		k.next += d.config.RepeatInterval
+	} else {
+		// No more events until the key is released.
+		k.next = events.Never
+	}
+}
+
+func (d *Device) push(t EventType, row, column int) {
+	d.events.Push(uint8(t), row*len(d.columns)+column)
+}
+
+// Next returns the oldest queued event. It returns false if there is none.
+func (d *Device) Next() (Event, bool) {
+	e, ok := d.events.Next()
+	if !ok {
+		return Event{}, false
+	}
+	return Event{
+		Type:   EventType(e.Type),
+		Row:    e.Index / len(d.columns),
+		Column: e.Index % len(d.columns),
+		Key:    e.Index,
+	}, true
+}
+
+// Dropped returns the number of events dropped because the queue was full.
+func (d *Device) Dropped() int {
+	return d.events.Dropped()
+}
+
+// Pressed returns whether the given key is pressed, after debouncing.
+func (d *Device) Pressed(row, column int) bool {
+	return d.keys[row*len(d.columns)+column].pressed
+}
+
+// AppendPressed appends the keys that are pressed, as in Event.Key, to dst
+// and returns it.
+func (d *Device) AppendPressed(dst []int) []int {
+	for i := range d.keys {
+		if d.keys[i].pressed {
+			dst = append(dst, i)
+		}
+	}
+	return dst
+}
+
+// Ghosting returns whether the last scan was ignored because of ghosting.
+// It is always false when Config.Diodes is set.
+func (d *Device) Ghosting() bool {
+	return d.ghosting
+}
@@ -0,0 +1,254 @@
+package keypad
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"tinygo.org/x/drivers"
+	"tinygo.org/x/drivers/tester"
+)
+
+// matrix simulates the wiring of a key matrix on mock pins.
+type matrix struct {
+	rows    []*tester.Pin
+	columns []*tester.Pin
+	pressed map[[2]int]bool
+}
+
+func newMatrix(rows, columns int) *matrix {
+	m := &matrix{pressed: make(map[[2]int]bool)}
+	for r := 0; r < rows; r++ {
+		m.rows = append(m.rows, tester.NewPin())
+	}
+	for c := 0; c < columns; c++ {
+		c := c
+		pin := tester.NewPin()
+		pin.Input = func() bool {
+			// Without diodes, current flows through pressed keys in
+			// any direction: a column is low if it is connected to the
+			// scanned row through a chain of pressed keys.
+			return !m.reaches(c)
+		}
+		m.columns = append(m.columns, pin)
+	}
+	return m
+}
+
+// reaches returns whether column c is connected to a low row.
+func (m *matrix) reaches(c int) bool {
+	rows := make([]bool, len(m.rows))
+	cols := make([]bool, len(m.columns))
+	for r, pin := range m.rows {
+		rows[r] = !pin.Get()
+	}
+	for changed := true; changed; {
+		changed = false
+		for k := range m.pressed {
+			r, col := k[0], k[1]
+			if rows[r] != cols[col] {
+				rows[r], cols[col] = true, true
+				changed = true
+			}
+		}
+	}
+	return cols[c]
+}
+
+func (m *matrix) pins() (rows, columns []drivers.Pin) {
+	for _, p := range m.rows {
+		rows = append(rows, p)
+	}
+	for _, p := range m.columns {
+		columns = append(columns, p)
+	}
+	return rows, columns
+}
+
+func (m *matrix) set(row, column int, pressed bool) {
+	if pressed {
+		m.pressed[[2]int{row, column}] = true
+	} else {
+		delete(m.pressed, [2]int{row, column})
+	}
+}
+
+func newTestDevice(m *matrix, config Config) (*Device, *tester.Clock) {
+	clock := tester.NewClock()
+	d := New(m.pins())
+	d.Clock = clock
+	d.Configure(config)
+	return d, clock
+}
+
+// scanFor scans every millisecond for the given duration.
+func scanFor(d *Device, clock *tester.Clock, duration time.Duration) {
+	for end := clock.Elapsed() + duration; clock.Elapsed() < end; {
+		d.Scan()
+		clock.Advance(time.Millisecond)
+	}
+}
+
+// drain returns the queued events.
+func drain(d *Device) []Event {
+	var events []Event
+	for e, ok := d.Next(); ok; e, ok = d.Next() {
+		events = append(events, e)
+	}
+	return events
+}
+
+func TestConfigure(t *testing.T) {
+	c := qt.New(t)
+	m := newMatrix(5, 12)
+	d, _ := newTestDevice(m, Config{})
+	c.Assert(d.Rows(), qt.Equals, 5)
+	c.Assert(d.Columns(), qt.Equals, 12)
+	c.Assert(m.rows[4].Mode(), qt.Equals, drivers.PinOutput)
+	c.Assert(m.rows[4].Get(), qt.IsTrue)
+	c.Assert(m.columns[11].Mode(), qt.Equals, drivers.PinInputPullup)
+}
+
+func TestDebounce(t *testing.T) {
+	c := qt.New(t)
+	m := newMatrix(5, 12)
+	d, clock := newTestDevice(m, Config{Debounce: 5 * time.Millisecond})
+
+	// A short glitch is ignored.
+	m.set(4, 11, true)
+	scanFor(d, clock, 2*time.Millisecond)
+	m.set(4, 11, false)
+	scanFor(d, clock, 10*time.Millisecond)
+	c.Assert(drain(d), qt.HasLen, 0)
+
+	m.set(4, 11, true)
+	scanFor(d, clock, 4*time.Millisecond)
+	c.Assert(d.Pressed(4, 11), qt.IsFalse)
+	scanFor(d, clock, 2*time.Millisecond)
+	c.Assert(d.Pressed(4, 11), qt.IsTrue)
+	m.set(4, 11, false)
+	scanFor(d, clock, 10*time.Millisecond)
+	c.Assert(drain(d), qt.DeepEquals, []Event{
+		{Type: Press, Row: 4, Column: 11, Key: 59},
+		{Type: Release, Row: 4, Column: 11, Key: 59},
+	})
+}
+
+func TestMultipleKeys(t *testing.T) {
+	c := qt.New(t)
+	m := newMatrix(5, 12)
+	d, clock := newTestDevice(m, Config{})
+
+	m.set(0, 0, true)
+	m.set(1, 5, true)
+	m.set(3, 7, true)
+	scanFor(d, clock, 20*time.Millisecond)
+	c.Assert(d.AppendPressed(nil), qt.DeepEquals, []int{0, 17, 43})
+	c.Assert(drain(d), qt.HasLen, 3)
+	c.Assert(d.Ghosting(), qt.IsFalse)
+}
+
+func TestGhosting(t *testing.T) {
+	c := qt.New(t)
+	m := newMatrix(4, 4)
+	d, clock := newTestDevice(m, Config{})
+
+	m.set(0, 0, true)
+	m.set(0, 1, true)
+	scanFor(d, clock, 20*time.Millisecond)
+	c.Assert(drain(d), qt.HasLen, 2)
+
+	// The third corner of a rectangle makes (1, 1) look pressed: the scans
+	// are ignored until the rectangle is gone.
+	m.set(1, 0, true)
+	scanFor(d, clock, 20*time.Millisecond)
+	c.Assert(d.Ghosting(), qt.IsTrue)
+	c.Assert(drain(d), qt.HasLen, 0)
+	c.Assert(d.Pressed(1, 1), qt.IsFalse)
+
+	m.set(0, 1, false)
+	scanFor(d, clock, 20*time.Millisecond)
+	c.Assert(d.Ghosting(), qt.IsFalse)
+	c.Assert(drain(d), qt.DeepEquals, []Event{
+		{Type: Release, Row: 0, Column: 1, Key: 1},
+		{Type: Press, Row: 1, Column: 0, Key: 4},
+	})
+}
+
+func TestDiodes(t *testing.T) {
+	c := qt.New(t)
+	m := newMatrix(2, 2)
+	// With diodes, the mock must not propagate through pressed keys.
+	for col, pin := range m.columns {
+		col := col
+		pin.Input = func() bool {
+			for r, row := range m.rows {
+				if !row.Get() && m.pressed[[2]int{r, col}] {
+					return false
+				}
+			}
+			return true
+		}
+	}
+	d, clock := newTestDevice(m, Config{Diodes: true})
+
+	m.set(0, 0, true)
+	m.set(0, 1, true)
+	m.set(1, 0, true)
+	m.set(1, 1, true)
+	scanFor(d, clock, 20*time.Millisecond)
+	c.Assert(d.Ghosting(), qt.IsFalse)
+	c.Assert(d.AppendPressed(nil), qt.DeepEquals, []int{0, 1, 2, 3})
+}
+
+func TestHoldRepeat(t *testing.T) {
+	c := qt.New(t)
+	m := newMatrix(2, 2)
+	d, clock := newTestDevice(m, Config{
+		Debounce:       -1,
+		HoldDelay:      100 * time.Millisecond,
+		RepeatInterval: 50 * time.Millisecond,
+	})
+
+	m.set(1, 0, true)
+	scanFor(d, clock, 210*time.Millisecond)
+	m.set(1, 0, false)
+	scanFor(d, clock, time.Millisecond)
+	var types []EventType
+	for _, e := range drain(d) {
+		types = append(types, e.Type)
+	}
+	c.Assert(types, qt.DeepEquals, []EventType{Press, Hold, Repeat, Repeat, Release})
+}
+
+func TestHoldWithoutRepeat(t *testing.T) {
+	c := qt.New(t)
+	m := newMatrix(1, 1)
+	d, clock := newTestDevice(m, Config{Debounce: -1})
+
+	m.set(0, 0, true)
+	scanFor(d, clock, 2*time.Second)
+	e := drain(d)
+	c.Assert(e, qt.HasLen, 2)
+	c.Assert(e[1].Type, qt.Equals, Hold)
+}
+
+func TestQueueFull(t *testing.T) {
+	c := qt.New(t)
+	m := newMatrix(1, 4)
+	d, clock := newTestDevice(m, Config{Debounce: -1, QueueSize: 2})
+
+	for col := 0; col < 4; col++ {
+		m.set(0, col, true)
+	}
+	scanFor(d, clock, time.Millisecond)
+	c.Assert(drain(d), qt.HasLen, 2)
+	c.Assert(d.Dropped(), qt.Equals, 2)
+}
+
+func TestEventTypeString(t *testing.T) {
+	c := qt.New(t)
+	c.Assert(Repeat.String(), qt.Equals, "repeat")
+	c.Assert(EventType(10).String(), qt.Equals, "unknown")
+}
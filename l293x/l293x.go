@@ -7,18 +7,20 @@ package l293x // import "tinygo.org/x/drivers/l293x"
 
 import (
 	"machine"
+
+	"tinygo.org/x/drivers"
 )
 
 // Device is a motor without speed control.
 // a1 and a2 are the directional pins.
 // en is the pin turns the motor on/off.
 type Device struct {
-	a1, a2 machine.Pin
-	en     machine.Pin
+	a1, a2 drivers.Pin
+	en     drivers.Pin
 }
 
 // New returns a new Motor driver for GPIO-only operation.
-func New(direction1, direction2, enablePin machine.Pin) Device {
+func New(direction1, direction2, enablePin drivers.Pin) Device {
 	return Device{
 		a1: direction1,
 		a2: direction2,
@@ -28,9 +30,9 @@ func New(direction1, direction2, enablePin machine.Pin) Device {
 
 // Configure configures the Device.
 func (d *Device) Configure() {
-	d.a1.Configure(machine.PinConfig{Mode: machine.PinOutput})
-	d.a2.Configure(machine.PinConfig{Mode: machine.PinOutput})
-	d.en.Configure(machine.PinConfig{Mode: machine.PinOutput})
+	drivers.ConfigurePin(d.a1, drivers.PinOutput)
+	drivers.ConfigurePin(d.a2, drivers.PinOutput)
+	drivers.ConfigurePin(d.en, drivers.PinOutput)
 
 	d.Stop()
 }
@@ -69,14 +71,14 @@ type PWM interface {
 // a1 and a2 are the directional GPIO pins.
 // en is the PWM pin that controls the motor speed.
 type PWMDevice struct {
-	a1, a2 machine.Pin
+	a1, a2 drivers.Pin
 	spc    uint8
 	pwm    PWM
 }
 
 // NewWithSpeed returns a new PWMMotor driver that uses an already configured PWM channel
 // to control speed.
-func NewWithSpeed(direction1, direction2 machine.Pin, spc uint8, pwm PWM) PWMDevice {
+func NewWithSpeed(direction1, direction2 drivers.Pin, spc uint8, pwm PWM) PWMDevice {
 	return PWMDevice{
 		a1:  direction1,
 		a2:  direction2,
@@ -88,8 +90,8 @@ func NewWithSpeed(direction1, direction2 machine.Pin, spc uint8, pwm PWM) PWMDev
 // Configure configures the PWMDevice. Note that the PWM interface and
 // channel must already be configured, this function will not do it for you.
 func (d *PWMDevice) Configure() error {
-	d.a1.Configure(machine.PinConfig{Mode: machine.PinOutput})
-	d.a2.Configure(machine.PinConfig{Mode: machine.PinOutput})
+	drivers.ConfigurePin(d.a1, drivers.PinOutput)
+	drivers.ConfigurePin(d.a2, drivers.PinOutput)
 
 	d.Stop()
 
@@ -1,10 +1,10 @@
 package l3gd20
 
-import "errors"
+import "tinygo.org/x/drivers"
 
 var (
-	ErrBadIdentity = errors.New("got unexpected identity from WHOMAI")
-	ErrBadRange    = errors.New("bad range configuration value")
+	ErrBadIdentity = drivers.WrapError("got unexpected identity from WHOMAI", drivers.ErrNotConnected)
+	ErrBadRange    = drivers.WrapError("bad range configuration value", drivers.ErrInvalidConfig)
 )
 
 // Sensitivity factors
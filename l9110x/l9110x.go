@@ -7,16 +7,18 @@ package l9110x // import "tinygo.org/x/drivers/l9110x"
 
 import (
 	"machine"
+
+	"tinygo.org/x/drivers"
 )
 
 // Device is a motor without speed control.
 // ia and ib are the directional pins.
 type Device struct {
-	ia, ib machine.Pin
+	ia, ib drivers.Pin
 }
 
 // New returns a new Motor driver for GPIO-only operation.
-func New(direction1, direction2 machine.Pin) Device {
+func New(direction1, direction2 drivers.Pin) Device {
 	return Device{
 		ia: direction1,
 		ib: direction2,
@@ -25,8 +27,8 @@ func New(direction1, direction2 machine.Pin) Device {
 
 // Configure configures the Device.
 func (d *Device) Configure() {
-	d.ia.Configure(machine.PinConfig{Mode: machine.PinOutput})
-	d.ib.Configure(machine.PinConfig{Mode: machine.PinOutput})
+	drivers.ConfigurePin(d.ia, drivers.PinOutput)
+	drivers.ConfigurePin(d.ib, drivers.PinOutput)
 
 	d.Stop()
 }
@@ -43,6 +43,13 @@ func (d *Device) Connected() bool {
 	return data[0] == 0x40
 }
 
+// Probe returns whether an LIS2MDL answers at addr.
+func Probe(bus drivers.I2C, addr uint16) bool {
+	d := New(bus)
+	d.Address = uint8(addr)
+	return d.Connected()
+}
+
 // Configure sets up the LIS2MDL device for communication.
 func (d *Device) Configure(cfg Configuration) {
 	if cfg.PowerMode != 0 {
@@ -89,20 +96,27 @@ func (d *Device) Configure(cfg Configuration) {
 }
 
 // ReadMagneticField reads the current magnetic field from the device and returns
-// it in mG (milligauss). 1 mG = 0.1 µT (microtesla).
-func (d *Device) ReadMagneticField() (x int32, y int32, z int32) {
+// it in nT (nanotesla). 1 G (gauss) = 100_000 nT (nanotesla).
+func (d *Device) ReadMagneticField() (x int32, y int32, z int32, err error) {
 	// turn back on read mode, even though it is supposed to be continuous?
 	cmd := []byte{0}
 	cmd[0] = byte(0x80 | d.PowerMode<<4 | d.DataRate<<2 | d.SystemMode)
-	d.bus.WriteRegister(uint8(d.Address), CFG_REG_A, cmd)
+	err = d.bus.WriteRegister(uint8(d.Address), CFG_REG_A, cmd)
+	if err != nil {
+		return
+	}
 	time.Sleep(10 * time.Millisecond)
 
 	data := make([]byte, 6)
-	d.bus.ReadRegister(uint8(d.Address), OUTX_L_REG, data)
+	err = d.bus.ReadRegister(uint8(d.Address), OUTX_L_REG, data)
+	if err != nil {
+		return
+	}
 
-	x = int32(int16((uint16(data[0]) << 8) | uint16(data[1])))
-	y = int32(int16((uint16(data[2]) << 8) | uint16(data[3])))
-	z = int32(int16((uint16(data[4]) << 8) | uint16(data[5])))
+	// The sensitivity is 1.5 mG/LSB, or 150 nT/LSB.
+	x = int32(int16((uint16(data[0])<<8)|uint16(data[1]))) * 150
+	y = int32(int16((uint16(data[2])<<8)|uint16(data[3]))) * 150
+	z = int32(int16((uint16(data[4])<<8)|uint16(data[5]))) * 150
 
 	return
 }
@@ -113,14 +127,17 @@ func (d *Device) ReadMagneticField() (x int32, y int32, z int32) {
 //
 // However, the heading may be off due to electronic compasses would be effected
 // by strong magnetic fields and require constant calibration.
-func (d *Device) ReadCompass() (h int32) {
-	x, y, _ := d.ReadMagneticField()
-	xf, yf := float64(x)*0.15, float64(y)*0.15
+func (d *Device) ReadCompass() (h int32, err error) {
+	x, y, _, err := d.ReadMagneticField()
+	if err != nil {
+		return
+	}
+	xf, yf := float64(x), float64(y)
 
 	rh := (math.Atan2(yf, xf) * 180) / math.Pi
 	if rh < 0 {
 		rh = 360 + rh
 	}
 
-	return int32(rh)
+	return int32(rh), nil
 }
@@ -21,18 +21,27 @@ func New(bus drivers.I2C) Device {
 }
 
 // Configure sets up the device for communication
-func (d *Device) Configure() {
+func (d *Device) Configure() error {
 	// enable all axes, normal mode
-	d.bus.WriteRegister(uint8(d.Address), REG_CTRL1, []byte{0x07})
+	err := d.bus.WriteRegister(uint8(d.Address), REG_CTRL1, []byte{0x07})
+	if err != nil {
+		return drivers.WrapError("lis3dh: configure", err)
+	}
 
 	// 400Hz rate
-	d.SetDataRate(DATARATE_400_HZ)
+	if err := d.SetDataRate(DATARATE_400_HZ); err != nil {
+		return err
+	}
 
 	// High res & BDU enabled
-	d.bus.WriteRegister(uint8(d.Address), REG_CTRL4, []byte{0x88})
+	err = d.bus.WriteRegister(uint8(d.Address), REG_CTRL4, []byte{0x88})
+	if err != nil {
+		return drivers.WrapError("lis3dh: configure", err)
+	}
 
 	// get current range
-	d.r = d.ReadRange()
+	d.r, err = d.ReadRange()
+	return err
 }
 
 // Connected returns whether a LIS3DH has been found.
@@ -46,47 +55,59 @@ func (d *Device) Connected() bool {
 	return data[0] == 0x33
 }
 
+// Probe returns whether an LIS3DH answers at addr.
+func Probe(bus drivers.I2C, addr uint16) bool {
+	d := New(bus)
+	d.Address = addr
+	return d.Connected()
+}
+
 // SetDataRate sets the speed of data collected by the LIS3DH.
-func (d *Device) SetDataRate(rate DataRate) {
+func (d *Device) SetDataRate(rate DataRate) error {
 	ctl1 := []byte{0}
 	err := d.bus.ReadRegister(uint8(d.Address), REG_CTRL1, ctl1)
 	if err != nil {
-		println(err.Error())
+		return drivers.WrapError("lis3dh: set data rate", err)
 	}
 	// mask off bits
 	ctl1[0] &^= 0xf0
 	ctl1[0] |= (byte(rate) << 4)
-	d.bus.WriteRegister(uint8(d.Address), REG_CTRL1, ctl1)
+	err = d.bus.WriteRegister(uint8(d.Address), REG_CTRL1, ctl1)
+	return drivers.WrapError("lis3dh: set data rate", err)
 }
 
 // SetRange sets the G range for LIS3DH.
-func (d *Device) SetRange(r Range) {
+func (d *Device) SetRange(r Range) error {
 	ctl := []byte{0}
 	err := d.bus.ReadRegister(uint8(d.Address), REG_CTRL4, ctl)
 	if err != nil {
-		println(err.Error())
+		return drivers.WrapError("lis3dh: set range", err)
 	}
 	// mask off bits
 	ctl[0] &^= 0x30
 	ctl[0] |= (byte(r) << 4)
-	d.bus.WriteRegister(uint8(d.Address), REG_CTRL4, ctl)
+	err = d.bus.WriteRegister(uint8(d.Address), REG_CTRL4, ctl)
+	if err != nil {
+		return drivers.WrapError("lis3dh: set range", err)
+	}
 
 	// store the new range
 	d.r = r
+	return nil
 }
 
 // ReadRange returns the current G range for LIS3DH.
-func (d *Device) ReadRange() (r Range) {
+func (d *Device) ReadRange() (r Range, err error) {
 	ctl := []byte{0}
-	err := d.bus.ReadRegister(uint8(d.Address), REG_CTRL4, ctl)
+	err = d.bus.ReadRegister(uint8(d.Address), REG_CTRL4, ctl)
 	if err != nil {
-		println(err.Error())
+		return 0, drivers.WrapError("lis3dh: read range", err)
 	}
 	// mask off bits
 	r = Range(ctl[0] >> 4)
 	r &= 0x03
 
-	return r
+	return r, nil
 }
 
 // ReadAcceleration reads the current acceleration from the device and returns
@@ -94,7 +115,10 @@ func (d *Device) ReadRange() (r Range) {
 // and the sensor is not moving the returned value will be around 1000000 or
 // -1000000.
 func (d *Device) ReadAcceleration() (int32, int32, int32, error) {
-	x, y, z := d.ReadRawAcceleration()
+	x, y, z, err := d.ReadRawAcceleration()
+	if err != nil {
+		return 0, 0, 0, err
+	}
 	divider := float32(1)
 	switch d.r {
 	case RANGE_16_G:
@@ -111,11 +135,17 @@ func (d *Device) ReadAcceleration() (int32, int32, int32, error) {
 }
 
 // ReadRawAcceleration returns the raw x, y and z axis from the LIS3DH
-func (d *Device) ReadRawAcceleration() (x int16, y int16, z int16) {
-	d.bus.WriteRegister(uint8(d.Address), REG_OUT_X_L|0x80, nil)
+func (d *Device) ReadRawAcceleration() (x int16, y int16, z int16, err error) {
+	err = d.bus.WriteRegister(uint8(d.Address), REG_OUT_X_L|0x80, nil)
+	if err != nil {
+		return 0, 0, 0, drivers.WrapError("lis3dh: read acceleration", err)
+	}
 
 	data := []byte{0, 0, 0, 0, 0, 0}
-	d.bus.Tx(d.Address, nil, data)
+	err = d.bus.Tx(d.Address, nil, data)
+	if err != nil {
+		return 0, 0, 0, drivers.WrapError("lis3dh: read acceleration", err)
+	}
 
 	x = int16((uint16(data[1]) << 8) | uint16(data[0]))
 	y = int16((uint16(data[3]) << 8) | uint16(data[2]))
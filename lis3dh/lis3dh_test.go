@@ -0,0 +1,40 @@
+package lis3dh
+
+import (
+	"errors"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"tinygo.org/x/drivers/tester"
+)
+
+func TestReadAcceleration(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewI2CReplay(c, []tester.I2COp{
+		{Kind: tester.I2CRead, Addr: Address0, Reg: REG_CTRL4, R: []byte{0x88}},
+		{Kind: tester.I2CWrite, Addr: Address0, Reg: REG_CTRL4, W: []byte{0xa8}},
+		{Kind: tester.I2CWrite, Addr: Address0, Reg: REG_OUT_X_L | 0x80},
+		{Kind: tester.I2CTx, Addr: Address0, R: []byte{0x00, 0x10, 0x00, 0xf0, 0x00, 0x00}},
+	})
+
+	dev := New(bus)
+	c.Assert(dev.SetRange(RANGE_8_G), qt.IsNil)
+	x, y, z, err := dev.ReadAcceleration()
+	c.Assert(err, qt.IsNil)
+	c.Assert([]int32{x, y, z}, qt.DeepEquals, []int32{1000000, -1000000, 0})
+	bus.AssertDone()
+}
+
+func TestReadAccelerationBusFault(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewI2CReplay(c, []tester.I2COp{
+		{Kind: tester.I2CWrite, Addr: Address0, Reg: REG_OUT_X_L | 0x80},
+		{Kind: tester.I2CTx, Addr: Address0, R: []byte{0, 0, 0, 0, 0, 0}, Err: tester.ErrBusFault.Error()},
+	})
+
+	dev := New(bus)
+	_, _, _, err := dev.ReadAcceleration()
+	c.Assert(err, qt.ErrorMatches, "lis3dh: read acceleration: i2c: bus fault")
+	c.Assert(errors.Is(err, tester.ErrBusFault), qt.IsTrue)
+	bus.AssertDone()
+}
@@ -24,16 +24,20 @@ func New(bus drivers.I2C) Device {
 
 // ReadPressure returns the pressure in milli pascals (mPa).
 func (d *Device) ReadPressure() (pressure int32, err error) {
-	d.waitForOneShot()
+	err = d.waitForOneShot()
+	if err != nil {
+		return
+	}
 
 	// read data
 	data := []byte{0, 0, 0}
-	d.bus.ReadRegister(d.Address, LPS22HB_PRESS_OUT_REG, data[:1])
-	d.bus.ReadRegister(d.Address, LPS22HB_PRESS_OUT_REG+1, data[1:2])
-	d.bus.ReadRegister(d.Address, LPS22HB_PRESS_OUT_REG+2, data[2:])
+	err = d.readRegisters(LPS22HB_PRESS_OUT_REG, data)
+	if err != nil {
+		return 0, drivers.WrapError("lps22hb: read pressure", err)
+	}
 	pValue := float32(uint32(data[2])<<16|uint32(data[1])<<8|uint32(data[0])) / 4096.0
 
-	return int32(pValue * 1000), nil
+	return int32(pValue * 100000), nil
 }
 
 // Connected returns whether LPS22HB has been found.
@@ -46,12 +50,17 @@ func (d *Device) Connected() bool {
 
 // ReadTemperature returns the temperature in celsius milli degrees (°C/1000).
 func (d *Device) ReadTemperature() (temperature int32, err error) {
-	d.waitForOneShot()
+	err = d.waitForOneShot()
+	if err != nil {
+		return
+	}
 
 	// read data
 	data := []byte{0, 0}
-	d.bus.ReadRegister(d.Address, LPS22HB_TEMP_OUT_REG, data[:1])
-	d.bus.ReadRegister(d.Address, LPS22HB_TEMP_OUT_REG+1, data[1:])
+	err = d.readRegisters(LPS22HB_TEMP_OUT_REG, data)
+	if err != nil {
+		return 0, drivers.WrapError("lps22hb: read temperature", err)
+	}
 	tValue := float32(int16(uint16(data[1])<<8|uint16(data[0]))) / 100.0
 
 	return int32(tValue * 1000), nil
@@ -60,16 +69,34 @@ func (d *Device) ReadTemperature() (temperature int32, err error) {
 // private functions
 
 // wait and trigger one shot in block update
-func (d *Device) waitForOneShot() {
+func (d *Device) waitForOneShot() error {
 	// trigger one shot
-	d.bus.WriteRegister(d.Address, LPS22HB_CTRL2_REG, []byte{0x01})
+	err := d.bus.WriteRegister(d.Address, LPS22HB_CTRL2_REG, []byte{0x01})
+	if err != nil {
+		return drivers.WrapError("lps22hb: trigger one shot", err)
+	}
 
 	// wait until one shot is cleared
 	data := []byte{1}
 	for {
-		d.bus.ReadRegister(d.Address, LPS22HB_CTRL2_REG, data)
+		err = d.bus.ReadRegister(d.Address, LPS22HB_CTRL2_REG, data)
+		if err != nil {
+			return drivers.WrapError("lps22hb: read control", err)
+		}
 		if data[0]&0x01 == 0 {
 			break
 		}
 	}
+	return nil
+}
+
+// read consecutive registers, one at a time
+func (d *Device) readRegisters(reg uint8, data []byte) error {
+	for i := range data {
+		err := d.bus.ReadRegister(d.Address, reg+uint8(i), data[i:i+1])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -3,8 +3,6 @@
 
 package lps22hb
 
-import "tinygo.org/x/drivers"
-
 // Configure sets up the LPS22HB device for communication.
 func (d *Device) Configure() {
 	// set to block update mode
@@ -0,0 +1,30 @@
+package lps22hb
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"tinygo.org/x/drivers/tester"
+)
+
+func TestReadPressure(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewI2CBus(c)
+	fake := tester.NewI2CDevice(c, LPS22HB_ADDRESS)
+	// The one shot measurement completes at once.
+	fake.Stuck = map[uint8]uint8{LPS22HB_CTRL2_REG: 0}
+	bus.AddDevice(fake)
+
+	// 0x3F8000 / 4096 is 1016 hPa.
+	copy(fake.Registers[LPS22HB_PRESS_OUT_REG:], []uint8{0x00, 0x80, 0x3F})
+	dev := New(bus)
+	pressure, err := dev.ReadPressure()
+	c.Assert(err, qt.IsNil)
+	c.Assert(pressure, qt.Equals, int32(101600000))
+
+	// 0x0A28 is 26 °C.
+	copy(fake.Registers[LPS22HB_TEMP_OUT_REG:], []uint8{0x28, 0x0A})
+	temperature, err := dev.ReadTemperature()
+	c.Assert(err, qt.IsNil)
+	c.Assert(temperature, qt.Equals, int32(26000))
+}
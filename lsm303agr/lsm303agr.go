@@ -6,7 +6,6 @@
 package lsm303agr // import "tinygo.org/x/drivers/lsm303agr"
 
 import (
-	"errors"
 	"math"
 
 	"tinygo.org/x/drivers"
@@ -36,7 +35,7 @@ type Configuration struct {
 	MagDataRate    uint8
 }
 
-var errNotConnected = errors.New("lsm303agr: failed to communicate with either acel or magnet sensor")
+var errNotConnected = drivers.WrapError("lsm303agr: failed to communicate with either acel or magnet sensor", drivers.ErrNotConnected)
 
 // New creates a new LSM303AGR connection. The I2C bus must already be configured.
 //
@@ -178,7 +177,7 @@ func (d *Device) ReadPitchRoll() (pitch, roll int32, err error) {
 }
 
 // ReadMagneticField reads the current magnetic field from the device and returns
-// it in mG (milligauss). 1 mG = 0.1 µT (microtesla).
+// it in nT (nanotesla). 1 G (gauss) = 100_000 nT (nanotesla).
 func (d *Device) ReadMagneticField() (x, y, z int32, err error) {
 
 	if d.MagSystemMode == MAG_SYSTEM_SINGLE {
@@ -191,11 +190,15 @@ func (d *Device) ReadMagneticField() (x, y, z int32, err error) {
 	}
 
 	data := d.buf[0:6]
-	d.bus.ReadRegister(uint8(d.MagAddress), MAG_OUT_X_L_M, data)
+	err = d.bus.ReadRegister(uint8(d.MagAddress), MAG_OUT_X_L_M, data)
+	if err != nil {
+		return
+	}
 
-	x = int32(int16((uint16(data[1])<<8 | uint16(data[0]))))
-	y = int32(int16((uint16(data[3])<<8 | uint16(data[2]))))
-	z = int32(int16((uint16(data[5])<<8 | uint16(data[4]))))
+	// The sensitivity is 1.5 mG/LSB, or 150 nT/LSB.
+	x = int32(int16((uint16(data[1])<<8 | uint16(data[0])))) * 150
+	y = int32(int16((uint16(data[3])<<8 | uint16(data[2])))) * 150
+	z = int32(int16((uint16(data[5])<<8 | uint16(data[4])))) * 150
 	return
 }
 
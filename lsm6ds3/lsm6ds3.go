@@ -6,8 +6,6 @@
 package lsm6ds3 // import "tinygo.org/x/drivers/lsm6ds3"
 
 import (
-	"errors"
-
 	"tinygo.org/x/drivers"
 )
 
@@ -41,7 +39,7 @@ type Configuration struct {
 	ResetStepCounter bool
 }
 
-var errNotConnected = errors.New("lsm6ds3: failed to communicate with acel/gyro sensor")
+var errNotConnected = drivers.WrapError("lsm6ds3: failed to communicate with acel/gyro sensor", drivers.ErrNotConnected)
 
 // New creates a new LSM6DS3 connection. The I2C bus must already be configured.
 //
@@ -156,6 +154,13 @@ func (d *Device) Connected() bool {
 	return data[0] == 0x69
 }
 
+// Probe returns whether an LSM6DS3 answers at addr.
+func Probe(bus drivers.I2C, addr uint16) bool {
+	d := New(bus)
+	d.Address = addr
+	return d.Connected()
+}
+
 // ReadAcceleration reads the current acceleration from the device and returns
 // it in µg (micro-gravity). When one of the axes is pointing straight to Earth
 // and the sensor is not moving the returned value will be around 1000000 or
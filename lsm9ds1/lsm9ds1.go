@@ -5,8 +5,6 @@
 package lsm9ds1 // import "tinygo.org/x/drivers/lsm9ds1"
 
 import (
-	"errors"
-
 	"tinygo.org/x/drivers"
 )
 
@@ -42,7 +40,7 @@ type Configuration struct {
 	MagSampleRate   MagSampleRate
 }
 
-var errNotConnected = errors.New("lsm9ds1: failed to communicate with either acel/gyro or magnet sensor")
+var errNotConnected = drivers.WrapError("lsm9ds1: failed to communicate with either acel/gyro or magnet sensor", drivers.ErrNotConnected)
 
 // New creates a new LSM9DS1 connection. The I2C bus must already be configured.
 //
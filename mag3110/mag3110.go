@@ -29,6 +29,13 @@ func (d Device) Connected() bool {
 	return data[0] == 0xC4
 }
 
+// Probe returns whether an MAG3110 answers at addr.
+func Probe(bus drivers.I2C, addr uint16) bool {
+	d := New(bus)
+	d.Address = addr
+	return d.Connected()
+}
+
 // Configure sets up the device for communication.
 func (d Device) Configure() {
 	d.bus.WriteRegister(uint8(d.Address), CTRL_REG2, []uint8{0x80}) // Power down when not used
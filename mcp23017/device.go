@@ -1,6 +1,7 @@
 // Package mcp23017 implements a driver for the MCP23017
 // I2C port expander chip. See https://www.microchip.com/wwwproducts/en/MCP23017
-// for details of the interface.
+// for details of the interface. The MCP23S17, its SPI variant, is
+// supported too (see NewSPI).
 //
 // It also provides a way of joining several such devices into one logical
 // device (see the Devices type).
@@ -8,6 +9,8 @@ package mcp23017
 
 import (
 	"errors"
+
+	"tinygo.org/x/drivers"
 )
 
 const (
@@ -91,9 +94,16 @@ func NewI2C(bus I2C, address uint8) (*Device, error) {
 	if address&hwAddressMask != hwAddress {
 		return nil, ErrInvalidHWAddress
 	}
+	return initDevice(bus, address, 0)
+}
+
+// initDevice returns a device whose IOCON register has already been set to
+// iocon.
+func initDevice(bus I2C, address uint8, iocon byte) (*Device, error) {
 	d := &Device{
-		bus:  bus,
-		addr: address,
+		bus:   bus,
+		addr:  address,
+		iocon: iocon,
 	}
 	pins, err := d.GetPins()
 	if err != nil {
@@ -121,6 +131,8 @@ type Device struct {
 	// This enables us to change individual pin values without
 	// doing a read followed by a write.
 	pins Pins
+	// iocon caches the value of the IOCON register.
+	iocon byte
 }
 
 // GetPins reads all 16 pins from ports A and B.
@@ -333,6 +345,22 @@ func (p Pin) SetMode(mode PinMode) error {
 	return p.dev.SetModes(modes)
 }
 
+// Configure sets the mode of the pin from a drivers.PinConfig, so that
+// the pin implements drivers.ConfigurableErrorPin and can be used by other
+// drivers through drivers.AsPin. The MCP23017 has no pull-down resistors, so
+// drivers.PinInputPulldown is not supported.
+func (p Pin) Configure(config drivers.PinConfig) error {
+	switch config.Mode {
+	case drivers.PinOutput:
+		return p.SetMode(Output)
+	case drivers.PinInput:
+		return p.SetMode(Input)
+	case drivers.PinInputPullup:
+		return p.SetMode(Input | Pullup)
+	}
+	return drivers.WrapError("mcp23017: unsupported pin mode", drivers.ErrInvalidConfig)
+}
+
 // GetMode returns the mode of the pin.
 func (p Pin) GetMode() (PinMode, error) {
 	modes := make([]PinMode, PinCount)
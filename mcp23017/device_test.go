@@ -1,11 +1,13 @@
 package mcp23017
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
 	qt "github.com/frankban/quicktest"
 
+	"tinygo.org/x/drivers"
 	"tinygo.org/x/drivers/tester"
 )
 
@@ -218,3 +220,55 @@ func newDevice(bus *tester.I2CBus, addr uint8) *tester.I2CDevice8 {
 	fdev.Registers[rIODIR|portB] = 0xff
 	return fdev
 }
+
+func TestBusFaults(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewI2CBus(c)
+	fdev := newDevice(bus, 0x20)
+	dev, err := NewI2C(bus, 0x20)
+	c.Assert(err, qt.IsNil)
+
+	fdev.Faults.FailAfter = fdev.Faults.Transferred + 1
+	_, err = dev.GetPins()
+	c.Assert(err, qt.Equals, tester.ErrBusFault)
+
+	fdev.Faults.FailAfter = 0
+	bus.NACK(0x20)
+	err = dev.SetPins(0xffff, 0xffff)
+	c.Assert(err, qt.Equals, tester.ErrNACK)
+	_, err = NewI2C(bus, 0x20)
+	c.Assert(err, qt.ErrorMatches, "cannot initialize mcp23017 device at 0x20: .*")
+}
+
+func TestPinAsDriversPin(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewI2CBus(c)
+	fdev := newDevice(bus, 0x20)
+	dev, err := NewI2C(bus, 0x20)
+	c.Assert(err, qt.IsNil)
+
+	var errs []error
+	pin := drivers.AsPin(dev.Pin(9), func(err error) {
+		errs = append(errs, err)
+	})
+	drivers.ConfigurePin(pin, drivers.PinOutput)
+	c.Assert(fdev.Registers[rIODIR|portB], qt.Equals, uint8(0b11111101))
+	pin.High()
+	c.Assert(fdev.Registers[rGPIO|portB], qt.Equals, uint8(0b10))
+	c.Assert(pin.Get(), qt.IsTrue)
+	pin.Low()
+	c.Assert(fdev.Registers[rGPIO|portB], qt.Equals, uint8(0))
+
+	drivers.ConfigurePin(pin, drivers.PinInputPullup)
+	c.Assert(fdev.Registers[rIODIR|portB], qt.Equals, uint8(0xff))
+	c.Assert(fdev.Registers[rGPPU|portB], qt.Equals, uint8(0b10))
+	c.Assert(errs, qt.HasLen, 0)
+
+	// Errors are reported to the handler.
+	drivers.ConfigurePin(pin, drivers.PinInputPulldown)
+	bus.NACK(0x20)
+	pin.High()
+	c.Assert(errs, qt.HasLen, 2)
+	c.Assert(errors.Is(errs[0], drivers.ErrInvalidConfig), qt.IsTrue)
+	c.Assert(errs[1], qt.Equals, tester.ErrNACK)
+}
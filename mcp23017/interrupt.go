@@ -0,0 +1,152 @@
+package mcp23017
+
+// Bits of the IOCON register.
+const (
+	ioconMIRROR = 1 << 6 // INT pins are internally connected.
+	ioconHAEN   = 1 << 3 // Hardware address enable, MCP23S17 only.
+	ioconODR    = 1 << 2 // INT pins are open-drain.
+	ioconINTPOL = 1 << 1 // INT pins are active-high.
+)
+
+// InterruptMode is the condition that makes a pin trigger an interrupt.
+// The zero value represents the default value after the chip is reset
+// (no interrupt).
+type InterruptMode uint8
+
+const (
+	// InterruptDisabled disables the interrupt of the pin.
+	InterruptDisabled InterruptMode = iota
+
+	// InterruptOnChange triggers an interrupt each time the pin value
+	// changes.
+	InterruptOnChange
+
+	// InterruptOnHigh triggers an interrupt while the pin is high. The
+	// interrupt triggers again as soon as it is cleared if the pin is still
+	// high.
+	InterruptOnHigh
+
+	// InterruptOnLow triggers an interrupt while the pin is low, for
+	// example while a button with a pull-up is pressed.
+	InterruptOnLow
+)
+
+// InterruptConfig configures the INTA and INTB output pins of the device,
+// which signal the interrupts of port A and port B.
+type InterruptConfig struct {
+	// Mirror connects INTA and INTB together, so that both signal the
+	// interrupts of all 16 pins and a single microcontroller pin can watch
+	// the whole device.
+	Mirror bool
+
+	// OpenDrain configures the INT pins as open-drain outputs, so that the
+	// INT pins of several devices can share a pull-up and a single
+	// microcontroller pin. ActiveHigh is ignored when it is set.
+	OpenDrain bool
+
+	// ActiveHigh drives the INT pins high when an interrupt is pending.
+	// They are active-low by default.
+	ActiveHigh bool
+}
+
+// ConfigureInterrupt configures the INT output pins.
+func (d *Device) ConfigureInterrupt(config InterruptConfig) error {
+	iocon := d.iocon &^ (ioconMIRROR | ioconODR | ioconINTPOL)
+	if config.Mirror {
+		iocon |= ioconMIRROR
+	}
+	if config.OpenDrain {
+		iocon |= ioconODR
+	} else if config.ActiveHigh {
+		iocon |= ioconINTPOL
+	}
+	buf := [1]byte{iocon}
+	if err := d.bus.WriteRegister(d.addr, uint8(rIOCON), buf[:]); err != nil {
+		return err
+	}
+	d.iocon = iocon
+	return nil
+}
+
+// SetInterrupts sets the interrupt mode of all the pins for which mask is
+// high. The other pins are left untouched.
+func (d *Device) SetInterrupts(mode InterruptMode, mask Pins) error {
+	enabled, err := d.readRegisterAB(rGPINTEN)
+	if err != nil {
+		return err
+	}
+	compare, err := d.readRegisterAB(rINTCON)
+	if err != nil {
+		return err
+	}
+	defval, err := d.readRegisterAB(rDEFVAL)
+	if err != nil {
+		return err
+	}
+	enabled |= mask
+	switch mode {
+	case InterruptDisabled:
+		enabled &^= mask
+	case InterruptOnChange:
+		compare &^= mask
+	case InterruptOnHigh:
+		// The interrupt triggers when the pin differs from DEFVAL.
+		compare |= mask
+		defval &^= mask
+	case InterruptOnLow:
+		compare |= mask
+		defval |= mask
+	}
+	// Enable the interrupts last, so that they do not trigger with the
+	// previous comparison settings.
+	if err := d.writeRegisterAB(rDEFVAL, defval); err != nil {
+		return err
+	}
+	if err := d.writeRegisterAB(rINTCON, compare); err != nil {
+		return err
+	}
+	return d.writeRegisterAB(rGPINTEN, enabled)
+}
+
+// GetInterrupts returns the pins whose interrupt is enabled.
+func (d *Device) GetInterrupts() (Pins, error) {
+	return d.readRegisterAB(rGPINTEN)
+}
+
+// ReadInterrupt returns the pins that triggered an interrupt (flags) and
+// the values of all the pins captured when it happened, and clears the
+// interrupt. Both are read in a single bus transaction. Flags are zero if
+// no interrupt is pending.
+func (d *Device) ReadInterrupt() (flags, captured Pins, err error) {
+	// INTF and INTCAP are consecutive registers, so a sequential read
+	// returns INTFA, INTFB, INTCAPA and INTCAPB.
+	var buf [4]byte
+	if err := d.bus.ReadRegister(d.addr, uint8(rINTF), buf[:]); err != nil {
+		return 0, 0, err
+	}
+	flags = Pins(buf[0]) | Pins(buf[1])<<8
+	captured = Pins(buf[2]) | Pins(buf[3])<<8
+	return flags, captured, nil
+}
+
+// SetInterrupt sets the interrupt mode of the pin.
+func (p Pin) SetInterrupt(mode InterruptMode) error {
+	return p.dev.SetInterrupts(mode, p.mask)
+}
+
+// ReadInterrupts reads the interrupt flags and captured values of all the
+// devices in devs into flags and captured, and clears their interrupts. It
+// is useful when the INT pins of the devices are wired together.
+func (devs Devices) ReadInterrupts(flags, captured PinSlice) error {
+	for i, dev := range devs {
+		if i >= len(flags) || i >= len(captured) {
+			break
+		}
+		var err error
+		flags[i], captured[i], err = dev.ReadInterrupt()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
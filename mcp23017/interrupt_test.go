@@ -0,0 +1,96 @@
+package mcp23017
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"tinygo.org/x/drivers/tester"
+)
+
+func TestSetInterrupts(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewI2CBus(c)
+	fdev := newDevice(bus, 0x20)
+	dev, err := NewI2C(bus, 0x20)
+	c.Assert(err, qt.IsNil)
+
+	err = dev.SetInterrupts(InterruptOnChange, 0b00000001_00000011)
+	c.Assert(err, qt.IsNil)
+	err = dev.Pin(2).SetInterrupt(InterruptOnLow)
+	c.Assert(err, qt.IsNil)
+	err = dev.Pin(9).SetInterrupt(InterruptOnHigh)
+	c.Assert(err, qt.IsNil)
+	c.Assert(fdev.Registers[rGPINTEN], qt.Equals, uint8(0b00000111))
+	c.Assert(fdev.Registers[rGPINTEN|portB], qt.Equals, uint8(0b00000011))
+	c.Assert(fdev.Registers[rINTCON], qt.Equals, uint8(0b00000100))
+	c.Assert(fdev.Registers[rINTCON|portB], qt.Equals, uint8(0b00000010))
+	c.Assert(fdev.Registers[rDEFVAL], qt.Equals, uint8(0b00000100))
+	c.Assert(fdev.Registers[rDEFVAL|portB], qt.Equals, uint8(0))
+
+	// Changing a pin back to InterruptOnChange leaves the others alone.
+	err = dev.Pin(2).SetInterrupt(InterruptOnChange)
+	c.Assert(err, qt.IsNil)
+	c.Assert(fdev.Registers[rINTCON], qt.Equals, uint8(0))
+	c.Assert(fdev.Registers[rINTCON|portB], qt.Equals, uint8(0b00000010))
+
+	err = dev.SetInterrupts(InterruptDisabled, 0b00000011_00000001)
+	c.Assert(err, qt.IsNil)
+	enabled, err := dev.GetInterrupts()
+	c.Assert(err, qt.IsNil)
+	c.Assert(enabled, qt.Equals, Pins(0b00000000_00000110))
+}
+
+func TestReadInterrupt(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewI2CBus(c)
+	fdev := newDevice(bus, 0x20)
+	dev, err := NewI2C(bus, 0x20)
+	c.Assert(err, qt.IsNil)
+
+	fdev.Registers[rINTF] = 0b00000000
+	fdev.Registers[rINTF|portB] = 0b00010000
+	fdev.Registers[rINTCAP] = 0b11111111
+	fdev.Registers[rINTCAP|portB] = 0b11101111
+	flags, captured, err := dev.ReadInterrupt()
+	c.Assert(err, qt.IsNil)
+	c.Assert(flags, qt.Equals, Pins(0b00010000_00000000))
+	c.Assert(captured, qt.Equals, Pins(0b11101111_11111111))
+
+	bus.NACK(0x20)
+	_, _, err = dev.ReadInterrupt()
+	c.Assert(err, qt.Equals, tester.ErrNACK)
+}
+
+func TestDevicesReadInterrupts(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewI2CBus(c)
+	newDevice(bus, 0x20)
+	fdev1 := newDevice(bus, 0x21)
+	fdev1.Registers[rINTF] = 0b1
+	devs, err := NewI2CDevices(bus, 0x20, 0x21)
+	c.Assert(err, qt.IsNil)
+
+	flags, captured := make(PinSlice, 2), make(PinSlice, 2)
+	err = devs.ReadInterrupts(flags, captured)
+	c.Assert(err, qt.IsNil)
+	c.Assert(flags, qt.DeepEquals, PinSlice{0, 0b1})
+	c.Assert(flags.Get(PinCount), qt.IsTrue)
+}
+
+func TestConfigureInterrupt(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewI2CBus(c)
+	fdev := newDevice(bus, 0x20)
+	dev, err := NewI2C(bus, 0x20)
+	c.Assert(err, qt.IsNil)
+
+	err = dev.ConfigureInterrupt(InterruptConfig{Mirror: true, ActiveHigh: true})
+	c.Assert(err, qt.IsNil)
+	c.Assert(fdev.Registers[rIOCON], qt.Equals, uint8(ioconMIRROR|ioconINTPOL))
+
+	// Open-drain outputs have no polarity.
+	err = dev.ConfigureInterrupt(InterruptConfig{OpenDrain: true, ActiveHigh: true})
+	c.Assert(err, qt.IsNil)
+	c.Assert(fdev.Registers[rIOCON], qt.Equals, uint8(ioconODR))
+}
@@ -0,0 +1,75 @@
+package mcp23017
+
+import (
+	"errors"
+
+	"tinygo.org/x/drivers"
+)
+
+// Opcode of the MCP23S17: the hardware address followed by the read bit.
+const (
+	opcodeWrite = 0
+	opcodeRead  = 1
+)
+
+// NewSPI returns a new MCP23S17 device at the given hardware address on the
+// given SPI bus. The address is formed like the I2C address of an MCP23017,
+// from 0x20 to 0x27 depending on the A0-A2 pins. It returns
+// ErrInvalidHWAddress if the address isn't possible for the device.
+//
+// Up to 8 devices can share the chip select pin cs. NewSPI enables the
+// hardware address pins of all of them, as they all answer at address 0x20
+// until it is done.
+//
+// By default all pins are configured as inputs.
+func NewSPI(bus drivers.SPI, cs drivers.Pin, address uint8) (*Device, error) {
+	if address&hwAddressMask != hwAddress {
+		return nil, ErrInvalidHWAddress
+	}
+	drivers.ConfigurePin(cs, drivers.PinOutput)
+	cs.High()
+	b := &spiBus{
+		bus: bus,
+		cs:  cs,
+	}
+	buf := [1]byte{ioconHAEN}
+	if err := b.WriteRegister(hwAddress, uint8(rIOCON), buf[:]); err != nil {
+		return nil, errors.New("cannot initialize mcp23s17 device at " + hex(address) + ": " + err.Error())
+	}
+	return initDevice(b, address, ioconHAEN)
+}
+
+// spiBus implements I2C for the MCP23S17, which sends the hardware address
+// in the opcode of each transaction.
+type spiBus struct {
+	bus drivers.SPI
+	cs  drivers.Pin
+	tx  [2 + registerCount]byte
+	rx  [2 + registerCount]byte
+}
+
+func (b *spiBus) ReadRegister(addr uint8, r uint8, buf []byte) error {
+	w := b.tx[:2+len(buf)]
+	for i := range w {
+		w[i] = 0
+	}
+	w[0] = addr<<1 | opcodeRead
+	w[1] = r
+	rx := b.rx[:len(w)]
+	b.cs.Low()
+	err := b.bus.Tx(w, rx)
+	b.cs.High()
+	copy(buf, rx[2:])
+	return err
+}
+
+func (b *spiBus) WriteRegister(addr uint8, r uint8, buf []byte) error {
+	w := b.tx[:2+len(buf)]
+	w[0] = addr<<1 | opcodeWrite
+	w[1] = r
+	copy(w[2:], buf)
+	b.cs.Low()
+	err := b.bus.Tx(w, nil)
+	b.cs.High()
+	return err
+}
@@ -0,0 +1,48 @@
+package mcp23017
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"tinygo.org/x/drivers"
+	"tinygo.org/x/drivers/tester"
+)
+
+func TestNewSPI(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewSPIBus(c)
+	cs := tester.NewPin()
+
+	// HAEN is enabled through address 0x20, then the pins are read.
+	bus.Expect([]byte{0x40, byte(rIOCON), ioconHAEN}, nil)
+	bus.Expect([]byte{0x47, byte(rGPIO), 0, 0}, []byte{0, 0, 0b00000101, 0b10000000})
+	dev, err := NewSPI(bus, cs, 0x23)
+	c.Assert(err, qt.IsNil)
+	c.Assert(cs.Mode(), qt.Equals, drivers.PinOutput)
+	c.Assert(cs.Get(), qt.IsTrue)
+	c.Assert(dev.pins, qt.Equals, Pins(0b10000000_00000101))
+
+	bus.Expect([]byte{0x46, byte(rGPIO), 0b00000111, 0b10000000}, nil)
+	c.Assert(dev.Pin(1).High(), qt.IsNil)
+
+	// HAEN is kept when the interrupt outputs are configured.
+	bus.Expect([]byte{0x46, byte(rIOCON), ioconHAEN | ioconMIRROR}, nil)
+	c.Assert(dev.ConfigureInterrupt(InterruptConfig{Mirror: true}), qt.IsNil)
+
+	bus.Expect([]byte{0x47, byte(rINTF), 0, 0, 0, 0}, []byte{0, 0, 0b10, 0, 0b11, 0})
+	flags, captured, err := dev.ReadInterrupt()
+	c.Assert(err, qt.IsNil)
+	c.Assert(flags, qt.Equals, Pins(0b10))
+	c.Assert(captured, qt.Equals, Pins(0b11))
+	bus.AssertDone()
+
+	// Chip select is asserted during each transaction.
+	c.Assert(cs.Levels()[:3], qt.DeepEquals, []bool{true, false, true})
+}
+
+func TestNewSPIInvalidAddress(t *testing.T) {
+	c := qt.New(t)
+	_, err := NewSPI(tester.NewSPIBus(c), tester.NewPin(), 0x40)
+	c.Assert(err, qt.Equals, ErrInvalidHWAddress)
+}
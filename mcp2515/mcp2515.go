@@ -8,7 +8,6 @@ package mcp2515 // import "tinygo.org/x/drivers/mcp2515"
 import (
 	"errors"
 	"fmt"
-	"machine"
 	"time"
 
 	"tinygo.org/x/drivers"
@@ -17,7 +16,7 @@ import (
 // Device wraps MCP2515 SPI CAN Module.
 type Device struct {
 	spi     SPI
-	cs      machine.Pin
+	cs      drivers.Pin
 	msg     *CANMsg
 	mcpMode byte
 }
@@ -36,7 +35,7 @@ const (
 )
 
 // New returns a new MCP2515 driver. Pass in a fully configured SPI bus.
-func New(b drivers.SPI, csPin machine.Pin) *Device {
+func New(b drivers.SPI, csPin drivers.Pin) *Device {
 	d := &Device{
 		spi: SPI{
 			bus: b,
@@ -52,7 +51,7 @@ func New(b drivers.SPI, csPin machine.Pin) *Device {
 
 // Configure sets up the device for communication.
 func (d *Device) Configure() {
-	d.cs.Configure(machine.PinConfig{Mode: machine.PinOutput})
+	drivers.ConfigurePin(d.cs, drivers.PinOutput)
 }
 
 const beginTimeoutValue int = 10
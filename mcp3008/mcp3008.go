@@ -14,7 +14,7 @@ import (
 // Device wraps MCP3008 SPI ADC.
 type Device struct {
 	bus drivers.SPI
-	cs  machine.Pin
+	cs  drivers.Pin
 	tx  []byte
 	rx  []byte
 	CH0 ADCPin
@@ -34,7 +34,7 @@ type ADCPin struct {
 }
 
 // New returns a new MCP3008 driver. Pass in a fully configured SPI bus.
-func New(b drivers.SPI, csPin machine.Pin) *Device {
+func New(b drivers.SPI, csPin drivers.Pin) *Device {
 	d := &Device{bus: b,
 		cs: csPin,
 		tx: make([]byte, 3),
@@ -56,7 +56,7 @@ func New(b drivers.SPI, csPin machine.Pin) *Device {
 
 // Configure sets up the device for communication
 func (d *Device) Configure() {
-	d.cs.Configure(machine.PinConfig{Mode: machine.PinOutput})
+	drivers.ConfigurePin(d.cs, drivers.PinOutput)
 }
 
 // Read analog data from channel
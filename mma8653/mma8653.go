@@ -31,6 +31,13 @@ func (d Device) Connected() bool {
 	return data[0] == 0x5A
 }
 
+// Probe returns whether an MMA8653 answers at addr.
+func Probe(bus drivers.I2C, addr uint16) bool {
+	d := New(bus)
+	d.Address = addr
+	return d.Connected()
+}
+
 // Configure sets up the device for communication.
 func (d *Device) Configure(speed DataRate, sensitivity Sensitivity) error {
 	// Set mode to STANDBY to be able to change the sensitivity.
@@ -31,18 +31,30 @@ func (d Device) Connected() bool {
 	return data[0] == 0x68
 }
 
+// Probe returns whether an MPU6050 answers at addr.
+func Probe(bus drivers.I2C, addr uint16) bool {
+	d := New(bus)
+	d.Address = addr
+	return d.Connected()
+}
+
 // Configure sets up the device for communication.
-func (d Device) Configure() {
-	d.bus.WriteRegister(uint8(d.Address), PWR_MGMT_1, []uint8{0})
+func (d Device) Configure() error {
+	err := d.bus.WriteRegister(uint8(d.Address), PWR_MGMT_1, []uint8{0})
+	return drivers.WrapError("mpu6050: configure", err)
 }
 
 // ReadAcceleration reads the current acceleration from the device and returns
 // it in µg (micro-gravity). When one of the axes is pointing straight to Earth
 // and the sensor is not moving the returned value will be around 1000000 or
 // -1000000.
-func (d Device) ReadAcceleration() (x int32, y int32, z int32) {
+func (d Device) ReadAcceleration() (x int32, y int32, z int32, err error) {
 	data := make([]byte, 6)
-	d.bus.ReadRegister(uint8(d.Address), ACCEL_XOUT_H, data)
+	err = d.bus.ReadRegister(uint8(d.Address), ACCEL_XOUT_H, data)
+	if err != nil {
+		err = drivers.WrapError("mpu6050: read acceleration", err)
+		return
+	}
 	// Now do two things:
 	// 1. merge the two values to a 16-bit number (and cast to a 32-bit integer)
 	// 2. scale the value to bring it in the -1000000..1000000 range.
@@ -61,9 +73,13 @@ func (d Device) ReadAcceleration() (x int32, y int32, z int32) {
 // µ°/s (micro-degrees/sec). This means that if you were to do a complete
 // rotation along one axis and while doing so integrate all values over time,
 // you would get a value close to 360000000.
-func (d Device) ReadRotation() (x int32, y int32, z int32) {
+func (d Device) ReadRotation() (x int32, y int32, z int32, err error) {
 	data := make([]byte, 6)
-	d.bus.ReadRegister(uint8(d.Address), GYRO_XOUT_H, data)
+	err = d.bus.ReadRegister(uint8(d.Address), GYRO_XOUT_H, data)
+	if err != nil {
+		err = drivers.WrapError("mpu6050: read rotation", err)
+		return
+	}
 	// First the value is converted from a pair of bytes to a signed 16-bit
 	// value and then to a signed 32-bit value to avoid integer overflow.
 	// Then the value is scaled to µ°/s (micro-degrees per second).
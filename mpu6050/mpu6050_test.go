@@ -0,0 +1,36 @@
+package mpu6050
+
+import (
+	"errors"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"tinygo.org/x/drivers/tester"
+)
+
+func TestConfigure(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewI2CBus(c)
+	fake := bus.NewDevice(Address)
+	fake.Registers[PWR_MGMT_1] = 0x40
+
+	dev := New(bus)
+	c.Assert(dev.Configure(), qt.IsNil)
+	c.Assert(fake.Registers[PWR_MGMT_1], qt.Equals, uint8(0))
+}
+
+func TestBusErrors(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewI2CBus(c)
+	bus.NACKUnknown = true
+
+	dev := New(bus)
+	err := dev.Configure()
+	c.Assert(err, qt.ErrorMatches, "mpu6050: configure: .*")
+	c.Assert(errors.Is(err, tester.ErrNACK), qt.IsTrue)
+
+	_, _, _, err = dev.ReadAcceleration()
+	c.Assert(errors.Is(err, tester.ErrNACK), qt.IsTrue)
+	_, _, _, err = dev.ReadRotation()
+	c.Assert(errors.Is(err, tester.ErrNACK), qt.IsTrue)
+}
@@ -3,11 +3,13 @@ package net
 import (
 	"errors"
 	"time"
+
+	"tinygo.org/x/drivers"
 )
 
 var (
 	ErrWiFiMissingSSID    = errors.New("missing SSID")
-	ErrWiFiConnectTimeout = errors.New("WiFi connect timeout")
+	ErrWiFiConnectTimeout = drivers.WrapError("WiFi connect", drivers.ErrTimeout)
 )
 
 // Adapter interface is used to communicate with the network adapter.
@@ -11,6 +11,8 @@ import (
 	"fmt"
 	"machine"
 	"time"
+
+	"tinygo.org/x/drivers"
 )
 
 type P1AM struct {
@@ -53,7 +55,7 @@ func (p *P1AM) Initialize() error {
 	time.Sleep(100 * time.Millisecond)
 
 	if err := p.waitAck(5 * time.Second); err != nil {
-		return errors.New("no base controller activity; check external supply connection")
+		return drivers.WrapError("no base controller activity; check external supply connection", drivers.ErrNotConnected)
 	}
 
 	for i := 0; i < 5; i++ {
@@ -294,11 +296,14 @@ func (s *Slot) writeDiscrete(channel byte, value uint32) error {
 	return s.p.dataSync()
 }
 
+// Channel is a single discrete input or output of a module. It implements
+// drivers.ErrorPin, so it can be used by other drivers through drivers.AsPin.
 type Channel struct {
 	s       *Slot
 	channel int
 }
 
+// Channel returns the given channel of the module, numbered from 1.
 func (s *Slot) Channel(channel int) Channel {
 	return Channel{
 		s:       s,
@@ -325,6 +330,26 @@ func (c Channel) WriteDiscrete(value bool) error {
 	return c.s.writeDiscrete(byte(c.channel), data)
 }
 
+// Get is an alias for ReadDiscrete. It implements drivers.ErrorPin.
+func (c Channel) Get() (bool, error) {
+	return c.ReadDiscrete()
+}
+
+// Set is an alias for WriteDiscrete. It implements drivers.ErrorPin.
+func (c Channel) Set(value bool) error {
+	return c.WriteDiscrete(value)
+}
+
+// High is short for c.Set(true).
+func (c Channel) High() error {
+	return c.WriteDiscrete(true)
+}
+
+// Low is short for c.Set(false).
+func (c Channel) Low() error {
+	return c.WriteDiscrete(false)
+}
+
 const ackTimeout = 200 * time.Millisecond
 
 func awaitPin(pin machine.Pin, state bool, timeout time.Duration) bool {
@@ -355,7 +380,7 @@ func awaitPin(pin machine.Pin, state bool, timeout time.Duration) bool {
 	// }
 }
 
-var dataSyncErr = errors.New("base sync timeout")
+var dataSyncErr = drivers.WrapError("base sync", drivers.ErrTimeout)
 
 func (p *P1AM) dataSync() error {
 	if !awaitPin(p.slaveAckPin, true, ackTimeout) {
@@ -406,7 +431,7 @@ func (p *P1AM) waitAck(timeout time.Duration) error {
 	return p.spiTimeout(timeout, 0, 0)
 }
 
-var timeoutErr = errors.New("timeout")
+var timeoutErr = drivers.WrapError("p1am", drivers.ErrTimeout)
 
 func (p *P1AM) spiTimeout(timeout time.Duration, resendMsg byte, retryPeriod time.Duration) error {
 	end := time.Now().Add(timeout)
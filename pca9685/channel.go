@@ -0,0 +1,54 @@
+package pca9685
+
+import "encoding/binary"
+
+// Full ON or OFF bit of the LEDn_ON_H and LEDn_OFF_H registers, as a 16 bit
+// register value.
+const fullBit = 1 << 12
+
+// Channel is a single PWM output of a PCA9685. It implements drivers.PWM, so
+// it can be passed to the servo and tone packages:
+//
+//	s, err := servo.New(d.Channel(0))
+//
+// All the channels share the period of the device.
+type Channel struct {
+	dev Dev
+	ch  uint8
+}
+
+// Channel returns the PWM output of the given channel in the range [0..15],
+// or of all the channels when ch is ALLLED.
+func (d Dev) Channel(ch uint8) Channel {
+	LED(ch) // panics if the channel is out of range
+	return Channel{dev: d, ch: ch}
+}
+
+// SetPeriod sets the period of the device in nanoseconds, which changes the
+// period of all the channels. See Dev.SetPeriod.
+func (c Channel) SetPeriod(period uint64) error {
+	return c.dev.SetPeriod(period)
+}
+
+// Top returns the value for a 100% duty cycle, which is 4096.
+func (c Channel) Top() uint32 {
+	return maxtop + 1
+}
+
+// Set sets the duty cycle of the channel to value/4096. Values of 0 and 4096
+// or more drive the output fully off and fully on.
+func (c Channel) Set(value uint32) error {
+	var on, off uint16
+	switch {
+	case value == 0:
+		off = fullBit
+	case value > maxtop:
+		on = fullBit
+	default:
+		off = uint16(value)
+	}
+	binary.LittleEndian.PutUint16(c.dev.buf[:2], on)
+	binary.LittleEndian.PutUint16(c.dev.buf[2:4], off)
+	onLReg, _, _, _ := LED(c.ch)
+	return c.dev.writeReg(onLReg, c.dev.buf[:4])
+}
@@ -0,0 +1,42 @@
+package pca9685
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"tinygo.org/x/drivers"
+	"tinygo.org/x/drivers/tester"
+)
+
+var _ drivers.PWM = Channel{}
+
+func TestChannelSet(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewI2CBus(c)
+	dev := tester.NewI2CDevice8(c, 0x40)
+	bus.AddDevice(dev)
+	d := New(bus, 0x40)
+	ch := d.Channel(1)
+	onL, _, _, _ := LED(1)
+
+	c.Assert(ch.Top(), qt.Equals, uint32(4096))
+	c.Assert(ch.Set(1024), qt.IsNil)
+	c.Assert(dev.Registers[onL:onL+4], qt.DeepEquals, []byte{0, 0, 0x00, 0x04})
+	c.Assert(ch.Set(0), qt.IsNil)
+	c.Assert(dev.Registers[onL:onL+4], qt.DeepEquals, []byte{0, 0, 0, 0x10})
+	c.Assert(ch.Set(ch.Top()), qt.IsNil)
+	c.Assert(dev.Registers[onL:onL+4], qt.DeepEquals, []byte{0, 0x10, 0, 0})
+}
+
+func TestChannelSetPeriod(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewI2CBus(c)
+	dev := tester.NewI2CDevice8(c, 0x40)
+	bus.AddDevice(dev)
+	ch := New(bus, 0x40).Channel(0)
+
+	c.Assert(ch.SetPeriod(20e6), qt.IsNil)
+	c.Assert(dev.Registers[PRESCALE], qt.Not(qt.Equals), uint8(0))
+	c.Assert(ch.SetPeriod(30e6), qt.Equals, ErrBadPeriod)
+}
@@ -71,7 +71,7 @@ func (d Dev) SetPeriod(period uint64) error {
 	// Note: 0.96 was empirically determined to be closer. Should follow up to understand what is happening here.
 	freq := 96 * 1e9 / (100 * period)
 	prescale := byte(oscclock/(div*freq) - 1)
-	err := d.Sleep(true) // Enable sleep to write to PRESCALE register
+	err := d.SetSleep(true) // Enable sleep to write to PRESCALE register
 	if err != nil {
 		return err
 	}
@@ -80,7 +80,7 @@ func (d Dev) SetPeriod(period uint64) error {
 	if err != nil {
 		return err
 	}
-	return d.Sleep(false)
+	return d.SetSleep(false)
 }
 
 // Top returns max value PWM can take.
@@ -155,12 +155,26 @@ func (d Dev) SetDrive(outdrv bool) error {
 	return d.writeReg(MODE2, d.buf[:1])
 }
 
-// Sleep sets/unsets SLEEP bit in MODE1.
+// Sleep stops the oscillator and all PWM outputs, putting the PCA9685 in
+// its low power mode. It implements drivers.Sleeper.
+func (d Dev) Sleep() error {
+	return d.SetSleep(true)
+}
+
+// Wake restarts the oscillator and resumes PWM. It implements
+// drivers.Sleeper.
+func (d Dev) Wake() error {
+	return d.SetSleep(false)
+}
+
+// SetSleep sets/unsets SLEEP bit in MODE1. It was named Sleep before Dev
+// implemented drivers.Sleeper: calls to Sleep(true) and Sleep(false) become
+// SetSleep(true) and SetSleep(false), or Sleep() and Wake().
 //  if sleepEnabled
 //    Stops PWM. Allows writing to PRE_SCALE register.
 //  else
 //    wakes PCA9685. Resumes PWM.
-func (d Dev) Sleep(sleepEnabled bool) error {
+func (d Dev) SetSleep(sleepEnabled bool) error {
 	err := d.readReg(MODE1, d.buf[:1])
 	if err != nil {
 		return err
@@ -0,0 +1,32 @@
+package pca9685
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"tinygo.org/x/drivers"
+	"tinygo.org/x/drivers/tester"
+)
+
+var _ drivers.Sleeper = Dev{}
+
+func TestSleep(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewI2CBus(c)
+	dev := tester.NewI2CDevice8(c, 0x40)
+	bus.AddDevice(dev)
+	d := New(bus, 0x40)
+	dev.Registers[MODE1] = AI
+
+	c.Assert(d.Sleep(), qt.IsNil)
+	c.Assert(dev.Registers[MODE1], qt.Equals, uint8(AI|SLEEP))
+	c.Assert(d.Wake(), qt.IsNil)
+	c.Assert(dev.Registers[MODE1], qt.Equals, uint8(AI))
+
+	// SetSleep is the former Sleep(bool).
+	c.Assert(d.SetSleep(true), qt.IsNil)
+	c.Assert(dev.Registers[MODE1], qt.Equals, uint8(AI|SLEEP))
+	c.Assert(d.SetSleep(false), qt.IsNil)
+	c.Assert(dev.Registers[MODE1], qt.Equals, uint8(AI))
+}
@@ -0,0 +1,129 @@
+package drivers
+
+// PinMode is the mode of a digital pin.
+type PinMode uint8
+
+// Pin modes supported by ConfigurePin.
+const (
+	PinOutput PinMode = iota
+	PinInput
+	PinInputPullup
+	PinInputPulldown
+)
+
+// PinConfig holds the configuration of a digital pin.
+type PinConfig struct {
+	Mode PinMode
+}
+
+// Pin represents a digital GPIO pin. It is implemented by the machine.Pin
+// type, and by types that do not depend on the machine package such as
+// tester.Pin. Use ConfigurePin to configure it.
+type Pin interface {
+	// Get returns the current level of the pin: true when it is high.
+	Get() bool
+
+	// Set drives the pin high (true) or low (false).
+	Set(high bool)
+
+	// High drives the pin high.
+	High()
+
+	// Low drives the pin low.
+	Low()
+}
+
+// ConfigurablePin is implemented by pins that can be configured without
+// depending on the machine package.
+type ConfigurablePin interface {
+	Pin
+
+	// Configure sets the mode of the pin.
+	Configure(config PinConfig)
+}
+
+// ConfigurePin sets the mode of the given pin. It supports machine.Pin when
+// built with TinyGo and any ConfigurablePin. Other pins are assumed to be
+// configured already and are left untouched.
+func ConfigurePin(p Pin, mode PinMode) {
+	if cp, ok := p.(ConfigurablePin); ok {
+		cp.Configure(PinConfig{Mode: mode})
+		return
+	}
+	configureMachinePin(p, mode)
+}
+
+// IsNoPin returns whether p is not connected: it is true for nil and for
+// machine.NoPin.
+func IsNoPin(p Pin) bool {
+	return p == nil || isMachineNoPin(p)
+}
+
+// ErrorPin is a digital pin whose operations can fail, such as a pin of a
+// GPIO expander on an I2C or SPI bus. Use AsPin to pass it to drivers that
+// take a Pin.
+type ErrorPin interface {
+	// Get returns the current level of the pin: true when it is high.
+	Get() (bool, error)
+
+	// Set drives the pin high (true) or low (false).
+	Set(high bool) error
+
+	// High drives the pin high.
+	High() error
+
+	// Low drives the pin low.
+	Low() error
+}
+
+// ConfigurableErrorPin is implemented by an ErrorPin that can be configured.
+type ConfigurableErrorPin interface {
+	ErrorPin
+
+	// Configure sets the mode of the pin. It returns an error wrapping
+	// ErrInvalidConfig for modes the pin does not support.
+	Configure(config PinConfig) error
+}
+
+// AsPin returns p as a ConfigurablePin, so that a pin of a GPIO expander can
+// drive a character LCD or a motor driver. As the methods of Pin cannot
+// return errors, they are passed to onError, which may be nil to ignore them.
+// Configure has no effect if p does not implement ConfigurableErrorPin.
+func AsPin(p ErrorPin, onError func(err error)) ConfigurablePin {
+	return &errorPin{p, onError}
+}
+
+type errorPin struct {
+	pin     ErrorPin
+	onError func(err error)
+}
+
+func (p *errorPin) Get() bool {
+	high, err := p.pin.Get()
+	p.report(err)
+	return high
+}
+
+func (p *errorPin) Set(high bool) {
+	p.report(p.pin.Set(high))
+}
+
+func (p *errorPin) High() {
+	p.report(p.pin.High())
+}
+
+func (p *errorPin) Low() {
+	p.report(p.pin.Low())
+}
+
+func (p *errorPin) Configure(config PinConfig) {
+	if cp, ok := p.pin.(ConfigurableErrorPin); ok {
+		p.report(cp.Configure(config))
+	}
+}
+
+func (p *errorPin) report(err error) {
+	if err != nil && p.onError != nil {
+		p.onError(err)
+	}
+}
@@ -0,0 +1,12 @@
+//go:build tinygo && avr
+// +build tinygo,avr
+
+package drivers
+
+import "machine"
+
+// AVR chips only have pull-up resistors.
+const (
+	pinInputPullup   = machine.PinInputPullup
+	pinInputPulldown = machine.PinInput
+)
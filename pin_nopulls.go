@@ -0,0 +1,13 @@
+//go:build tinygo && (fe310 || esp8266)
+// +build tinygo
+// +build fe310 esp8266
+
+package drivers
+
+import "machine"
+
+// These chips do not support pull resistors through the machine package.
+const (
+	pinInputPullup   = machine.PinInput
+	pinInputPulldown = machine.PinInput
+)
@@ -0,0 +1,12 @@
+//go:build !tinygo
+// +build !tinygo
+
+package drivers
+
+// There is no machine package outside of TinyGo, so only ConfigurablePin
+// types can be configured.
+func configureMachinePin(p Pin, mode PinMode) {}
+
+func isMachineNoPin(p Pin) bool {
+	return false
+}
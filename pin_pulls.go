@@ -0,0 +1,11 @@
+//go:build tinygo && !avr && !fe310 && !esp8266
+// +build tinygo,!avr,!fe310,!esp8266
+
+package drivers
+
+import "machine"
+
+const (
+	pinInputPullup   = machine.PinInputPullup
+	pinInputPulldown = machine.PinInputPulldown
+)
@@ -0,0 +1,71 @@
+package drivers_test
+
+import (
+	"errors"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"tinygo.org/x/drivers"
+)
+
+// fakeErrorPin is an ErrorPin that fails once err is set.
+type fakeErrorPin struct {
+	level bool
+	mode  drivers.PinMode
+	err   error
+}
+
+func (p *fakeErrorPin) Get() (bool, error) { return p.level, p.err }
+func (p *fakeErrorPin) High() error        { return p.Set(true) }
+func (p *fakeErrorPin) Low() error         { return p.Set(false) }
+
+func (p *fakeErrorPin) Set(high bool) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.level = high
+	return nil
+}
+
+func (p *fakeErrorPin) Configure(config drivers.PinConfig) error {
+	p.mode = config.Mode
+	return p.err
+}
+
+func TestAsPin(t *testing.T) {
+	c := qt.New(t)
+	fake := &fakeErrorPin{}
+	var errs []error
+	pin := drivers.AsPin(fake, func(err error) {
+		errs = append(errs, err)
+	})
+
+	drivers.ConfigurePin(pin, drivers.PinInputPullup)
+	c.Assert(fake.mode, qt.Equals, drivers.PinInputPullup)
+	pin.High()
+	c.Assert(pin.Get(), qt.IsTrue)
+	pin.Set(false)
+	c.Assert(fake.level, qt.IsFalse)
+	c.Assert(errs, qt.HasLen, 0)
+
+	fake.err = errors.New("bus error")
+	pin.High()
+	c.Assert(pin.Get(), qt.IsFalse)
+	c.Assert(errs, qt.HasLen, 2)
+	c.Assert(errs[0], qt.ErrorMatches, "bus error")
+}
+
+func TestAsPinNoHandler(t *testing.T) {
+	c := qt.New(t)
+	fake := &fakeErrorPin{err: errors.New("bus error")}
+	pin := drivers.AsPin(fake, nil)
+	pin.High()
+	c.Assert(pin.Get(), qt.IsFalse)
+
+	// Configure is ignored by pins that cannot be configured.
+	fake.mode = drivers.PinInput
+	pin = drivers.AsPin(struct{ drivers.ErrorPin }{fake}, nil)
+	drivers.ConfigurePin(pin, drivers.PinOutput)
+	c.Assert(fake.mode, qt.Equals, drivers.PinInput)
+}
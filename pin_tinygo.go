@@ -0,0 +1,28 @@
+//go:build tinygo
+// +build tinygo
+
+package drivers
+
+import "machine"
+
+func configureMachinePin(p Pin, mode PinMode) {
+	pin, ok := p.(machine.Pin)
+	if !ok {
+		return
+	}
+	switch mode {
+	case PinOutput:
+		pin.Configure(machine.PinConfig{Mode: machine.PinOutput})
+	case PinInput:
+		pin.Configure(machine.PinConfig{Mode: machine.PinInput})
+	case PinInputPullup:
+		pin.Configure(machine.PinConfig{Mode: pinInputPullup})
+	case PinInputPulldown:
+		pin.Configure(machine.PinConfig{Mode: pinInputPulldown})
+	}
+}
+
+func isMachineNoPin(p Pin) bool {
+	pin, ok := p.(machine.Pin)
+	return ok && pin == machine.NoPin
+}
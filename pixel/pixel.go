@@ -0,0 +1,95 @@
+// Package pixel converts colors to the pixel formats of displays and LEDs.
+//
+// The conversions work on color.RGBA, as used by drivers.Displayer, one
+// color at a time or on whole spans of colors, for example to fill the
+// transmit buffer of a display:
+//
+//	buf := make([]byte, 64*2)
+//	for len(colors) > 0 {
+//		n := pixel.ToRGB565Bytes(buf, colors)
+//		bus.Tx(buf[:n*2], nil)
+//		colors = colors[n:]
+//	}
+//
+// The alpha component is ignored, except by the LED drivers that use it as
+// brightness.
+package pixel // import "tinygo.org/x/drivers/pixel"
+
+import "image/color"
+
+// RGB565 converts a color to 16 bits, with 5 bits of red in the most
+// significant bits, 6 bits of green and 5 bits of blue.
+func RGB565(c color.RGBA) uint16 {
+	return uint16(c.R&0xF8)<<8 | uint16(c.G&0xFC)<<3 | uint16(c.B>>3)
+}
+
+// BGR565 converts a color to 16 bits, with 5 bits of blue in the most
+// significant bits, 6 bits of green and 5 bits of red.
+func BGR565(c color.RGBA) uint16 {
+	return uint16(c.B&0xF8)<<8 | uint16(c.G&0xFC)<<3 | uint16(c.R>>3)
+}
+
+// RGB565ToRGBA converts a RGB565 pixel to an opaque color. The high bits of
+// each component are repeated in the low bits, so that white stays white.
+func RGB565ToRGBA(p uint16) color.RGBA {
+	r, g, b := uint8(p>>11), uint8(p>>5)&0x3F, uint8(p)&0x1F
+	return color.RGBA{r<<3 | r>>2, g<<2 | g>>4, b<<3 | b>>2, 255}
+}
+
+// RGB666 converts a color to 18 bits, with 6 bits of red in the most
+// significant bits, 6 bits of green and 6 bits of blue.
+func RGB666(c color.RGBA) uint32 {
+	return uint32(c.R>>2)<<12 | uint32(c.G>>2)<<6 | uint32(c.B>>2)
+}
+
+// RGB444 converts a color to 12 bits, with 4 bits of red in the most
+// significant bits, 4 bits of green and 4 bits of blue.
+func RGB444(c color.RGBA) uint16 {
+	return uint16(c.R>>4)<<8 | uint16(c.G>>4)<<4 | uint16(c.B>>4)
+}
+
+// Gray returns the luminance of a color, with the same weights as
+// color.GrayModel.
+func Gray(c color.RGBA) uint8 {
+	y := (19595*uint32(c.R) + 38470*uint32(c.G) + 7471*uint32(c.B) + 1<<15) >> 16
+	return uint8(y)
+}
+
+// Monochrome returns whether a pixel of a monochrome display is on for a
+// color: its luminance must be at least threshold, such as 128.
+func Monochrome(c color.RGBA, threshold uint8) bool {
+	return Gray(c) >= threshold
+}
+
+// Order is the order in which the components of a color are sent to a
+// device, such as GRB for the ws2812 LEDs.
+type Order uint8
+
+const (
+	RGB Order = iota
+	RBG
+	GRB
+	GBR
+	BRG
+	BGR
+)
+
+// Put stores the red, green and blue components of c in the first three
+// bytes of dst, in the order o.
+func (o Order) Put(dst []byte, c color.RGBA) {
+	_ = dst[2]
+	switch o {
+	case RGB:
+		dst[0], dst[1], dst[2] = c.R, c.G, c.B
+	case RBG:
+		dst[0], dst[1], dst[2] = c.R, c.B, c.G
+	case GRB:
+		dst[0], dst[1], dst[2] = c.G, c.R, c.B
+	case GBR:
+		dst[0], dst[1], dst[2] = c.G, c.B, c.R
+	case BRG:
+		dst[0], dst[1], dst[2] = c.B, c.R, c.G
+	case BGR:
+		dst[0], dst[1], dst[2] = c.B, c.G, c.R
+	}
+}
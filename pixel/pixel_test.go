@@ -0,0 +1,114 @@
+package pixel
+
+import (
+	"image/color"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+var (
+	black = color.RGBA{0, 0, 0, 255}
+	white = color.RGBA{255, 255, 255, 255}
+	red   = color.RGBA{255, 0, 0, 255}
+	teal  = color.RGBA{0x12, 0x80, 0xC4, 255}
+)
+
+// rgbaTo565 is the conversion of the display drivers, based on RGBA.
+func rgbaTo565(c color.RGBA) uint16 {
+	r, g, b, _ := c.RGBA()
+	return uint16((r & 0xF800) +
+		((g & 0xFC00) >> 5) +
+		((b & 0xF800) >> 11))
+}
+
+func TestRGB565(t *testing.T) {
+	c := qt.New(t)
+	c.Assert(RGB565(white), qt.Equals, uint16(0xFFFF))
+	c.Assert(RGB565(red), qt.Equals, uint16(0xF800))
+	c.Assert(BGR565(red), qt.Equals, uint16(0x001F))
+	c.Assert(RGB565(teal), qt.Equals, uint16(0x1418))
+	c.Assert(RGB565ToRGBA(0xFFFF), qt.Equals, white)
+	c.Assert(RGB565ToRGBA(0x0000), qt.Equals, black)
+
+	for v := 0; v < 256; v++ {
+		for _, col := range []color.RGBA{{uint8(v), 0, 0, 255}, {0, uint8(v), 0, 255}, {0, 0, uint8(v), 255}} {
+			c.Assert(RGB565(col), qt.Equals, rgbaTo565(col))
+			c.Assert(RGB565(RGB565ToRGBA(RGB565(col))), qt.Equals, RGB565(col))
+		}
+	}
+}
+
+func TestFormats(t *testing.T) {
+	c := qt.New(t)
+	c.Assert(RGB666(white), qt.Equals, uint32(0x3FFFF))
+	c.Assert(RGB666(teal), qt.Equals, uint32(0x04<<12|0x20<<6|0x31))
+	c.Assert(RGB444(white), qt.Equals, uint16(0xFFF))
+	c.Assert(RGB444(teal), qt.Equals, uint16(0x18C))
+
+	c.Assert(Gray(white), qt.Equals, uint8(255))
+	c.Assert(Gray(black), qt.Equals, uint8(0))
+	c.Assert(Gray(teal), qt.Equals, color.GrayModel.Convert(teal).(color.Gray).Y)
+	c.Assert(Monochrome(teal, 128), qt.IsFalse)
+	c.Assert(Monochrome(teal, 90), qt.IsTrue)
+	c.Assert(Monochrome(white, 255), qt.IsTrue)
+
+	buf := make([]byte, 3)
+	for order, want := range map[Order][]byte{
+		RGB: {1, 2, 3},
+		RBG: {1, 3, 2},
+		GRB: {2, 1, 3},
+		GBR: {2, 3, 1},
+		BRG: {3, 1, 2},
+		BGR: {3, 2, 1},
+	} {
+		order.Put(buf, color.RGBA{1, 2, 3, 255})
+		c.Assert(buf, qt.DeepEquals, want, qt.Commentf("order %d", order))
+	}
+}
+
+func TestSpans(t *testing.T) {
+	c := qt.New(t)
+	src := []color.RGBA{red, white, teal}
+
+	u16 := make([]uint16, 2)
+	c.Assert(ToRGB565(u16, src), qt.Equals, 2)
+	c.Assert(u16, qt.DeepEquals, []uint16{0xF800, 0xFFFF})
+
+	b := make([]byte, 7)
+	c.Assert(ToRGB565Bytes(b, src), qt.Equals, 3)
+	c.Assert(b[:6], qt.DeepEquals, []byte{0xF8, 0x00, 0xFF, 0xFF, 0x14, 0x18})
+	c.Assert(ToRGB565Bytes(b[:5], src), qt.Equals, 2)
+
+	b = make([]byte, 9)
+	c.Assert(ToRGB666Bytes(b, src), qt.Equals, 3)
+	c.Assert(b, qt.DeepEquals, []byte{0xFC, 0, 0, 0xFC, 0xFC, 0xFC, 0x10, 0x80, 0xC4})
+
+	// Two colors in 3 bytes, then the last one in 2 bytes.
+	b = make([]byte, 5)
+	c.Assert(ToRGB444Bytes(b, src), qt.Equals, 3)
+	c.Assert(b, qt.DeepEquals, []byte{0xF0, 0x0F, 0xFF, 0x18, 0xC0})
+	c.Assert(ToRGB444Bytes(b[:4], src), qt.Equals, 2)
+	c.Assert(ToRGB444Bytes(b[:1], src), qt.Equals, 0)
+
+	gray := make([]uint8, 4)
+	c.Assert(ToGray(gray, src), qt.Equals, 3)
+	c.Assert(gray[:3], qt.DeepEquals, []uint8{Gray(red), 255, Gray(teal)})
+
+	b = make([]byte, 9)
+	c.Assert(GRB.PutColors(b, src), qt.Equals, 3)
+	c.Assert(b, qt.DeepEquals, []byte{0, 255, 0, 255, 255, 255, 0x80, 0x12, 0xC4})
+}
+
+func TestGamma(t *testing.T) {
+	c := qt.New(t)
+	c.Assert(&Gamma22, qt.DeepEquals, NewGammaTable(2.2))
+	c.Assert(&Gamma28, qt.DeepEquals, NewGammaTable(2.8))
+	c.Assert(NewGammaTable(1)[100], qt.Equals, uint8(100))
+
+	c.Assert(Gamma28.Correct(color.RGBA{255, 128, 0, 100}), qt.DeepEquals, color.RGBA{255, 37, 0, 100})
+
+	colors := []color.RGBA{white, {128, 128, 128, 255}}
+	c.Assert(Gamma22.CorrectColors(colors, colors), qt.Equals, 2)
+	c.Assert(colors, qt.DeepEquals, []color.RGBA{white, {56, 56, 56, 255}})
+}
@@ -0,0 +1,100 @@
+package pixel
+
+import "image/color"
+
+// The functions below convert a span of colors at once. They convert as
+// many colors as fit in dst and return the number of colors converted, so
+// that a long span can be sent through a small buffer.
+
+// ToRGB565 converts colors to RGB565.
+func ToRGB565(dst []uint16, src []color.RGBA) int {
+	n := len(src)
+	if len(dst) < n {
+		n = len(dst)
+	}
+	for i, c := range src[:n] {
+		dst[i] = RGB565(c)
+	}
+	return n
+}
+
+// ToRGB565Bytes converts colors to RGB565, in 2 bytes per color with the
+// most significant byte first, as sent to the displays.
+func ToRGB565Bytes(dst []byte, src []color.RGBA) int {
+	n := len(src)
+	if len(dst)/2 < n {
+		n = len(dst) / 2
+	}
+	for i, c := range src[:n] {
+		p := RGB565(c)
+		dst[i*2] = uint8(p >> 8)
+		dst[i*2+1] = uint8(p)
+	}
+	return n
+}
+
+// ToRGB666Bytes converts colors to RGB666, in 3 bytes per color with the 6
+// bits of each component in the most significant bits, as sent to the
+// displays in their 18 bits per pixel mode.
+func ToRGB666Bytes(dst []byte, src []color.RGBA) int {
+	n := len(src)
+	if len(dst)/3 < n {
+		n = len(dst) / 3
+	}
+	for i, c := range src[:n] {
+		dst[i*3] = c.R & 0xFC
+		dst[i*3+1] = c.G & 0xFC
+		dst[i*3+2] = c.B & 0xFC
+	}
+	return n
+}
+
+// ToRGB444Bytes converts colors to RGB444, packed in 3 bytes per 2 colors as
+// sent to the displays in their 12 bits per pixel mode. The last color of
+// an odd number of colors takes 2 bytes, the 4 low bits of the last byte
+// being zero, so that n colors take (n*3+1)/2 bytes.
+func ToRGB444Bytes(dst []byte, src []color.RGBA) int {
+	n := len(src)
+	if m := len(dst) * 2 / 3; m < n {
+		n = m
+	}
+	for i := 0; i+1 < n; i += 2 {
+		a, b := RGB444(src[i]), RGB444(src[i+1])
+		j := i / 2 * 3
+		dst[j] = uint8(a >> 4)
+		dst[j+1] = uint8(a<<4) | uint8(b>>8)
+		dst[j+2] = uint8(b)
+	}
+	if n%2 == 1 {
+		a := RGB444(src[n-1])
+		j := (n - 1) / 2 * 3
+		dst[j] = uint8(a >> 4)
+		dst[j+1] = uint8(a << 4)
+	}
+	return n
+}
+
+// ToGray converts colors to their luminance.
+func ToGray(dst []uint8, src []color.RGBA) int {
+	n := len(src)
+	if len(dst) < n {
+		n = len(dst)
+	}
+	for i, c := range src[:n] {
+		dst[i] = Gray(c)
+	}
+	return n
+}
+
+// PutColors stores the components of colors in dst, 3 bytes per color in
+// the order o.
+func (o Order) PutColors(dst []byte, src []color.RGBA) int {
+	n := len(src)
+	if len(dst)/3 < n {
+		n = len(dst) / 3
+	}
+	for i, c := range src[:n] {
+		o.Put(dst[i*3:], c)
+	}
+	return n
+}
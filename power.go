@@ -0,0 +1,52 @@
+package drivers
+
+// Sleeper is implemented by devices that can be put in a low-power state and
+// woken up again, such as sensors, displays and radios.
+//
+// Battery powered firmware can put all its peripherals in their lowest power
+// state at once:
+//
+//	peripherals := []drivers.Sleeper{&sensor, &display, &radio}
+//	drivers.SleepAll(peripherals...)
+type Sleeper interface {
+	// Sleep puts the device in its lowest power state from which Wake can
+	// bring it back. Depending on the device, measurements stop and the
+	// content of a display may be blanked until the device is woken up.
+	Sleep() error
+
+	// Wake brings the device back from Sleep, with the configuration it had
+	// before.
+	Wake() error
+}
+
+// SleepReporter is optionally implemented by a Sleeper that keeps track of
+// its power state.
+type SleepReporter interface {
+	// Sleeping returns whether the device has been put to sleep.
+	Sleeping() bool
+}
+
+// SleepAll puts the devices to sleep, in order. It tries every device even
+// if some of them fail, and returns the first error.
+func SleepAll(devices ...Sleeper) error {
+	var first error
+	for _, d := range devices {
+		if err := d.Sleep(); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// WakeAll wakes the devices up, in reverse order, so that the devices put
+// to sleep by SleepAll are woken up in the opposite order. It tries every
+// device even if some of them fail, and returns the first error.
+func WakeAll(devices ...Sleeper) error {
+	var first error
+	for i := len(devices) - 1; i >= 0; i-- {
+		if err := devices[i].Wake(); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
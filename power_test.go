@@ -0,0 +1,98 @@
+package drivers_test
+
+import (
+	"errors"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"tinygo.org/x/drivers"
+	"tinygo.org/x/drivers/adxl345"
+	"tinygo.org/x/drivers/bh1750"
+	"tinygo.org/x/drivers/bmp388"
+	"tinygo.org/x/drivers/hts221"
+	"tinygo.org/x/drivers/ili9341"
+	"tinygo.org/x/drivers/pca9685"
+	"tinygo.org/x/drivers/shtc3"
+	"tinygo.org/x/drivers/ssd1306"
+	"tinygo.org/x/drivers/ssd1331"
+	"tinygo.org/x/drivers/ssd1351"
+	"tinygo.org/x/drivers/st7735"
+	"tinygo.org/x/drivers/st7789"
+	"tinygo.org/x/drivers/waveshare-epd/epd2in13"
+	"tinygo.org/x/drivers/waveshare-epd/epd2in13x"
+	"tinygo.org/x/drivers/waveshare-epd/epd4in2"
+)
+
+// Compile-time checks that the drivers with a low-power mode implement
+// drivers.Sleeper, and drivers.SleepReporter when they track their state.
+var (
+	_ drivers.Sleeper       = (*adxl345.Device)(nil)
+	_ drivers.SleepReporter = (*adxl345.Device)(nil)
+	_ drivers.Sleeper       = (*bh1750.Device)(nil)
+	_ drivers.SleepReporter = (*bh1750.Device)(nil)
+	_ drivers.Sleeper       = (*bmp388.Device)(nil)
+	_ drivers.SleepReporter = (*bmp388.Device)(nil)
+	_ drivers.Sleeper       = (*hts221.Device)(nil)
+	_ drivers.SleepReporter = (*hts221.Device)(nil)
+	_ drivers.Sleeper       = (*shtc3.Device)(nil)
+	_ drivers.SleepReporter = (*shtc3.Device)(nil)
+
+	_ drivers.Sleeper = pca9685.Dev{}
+
+	_ drivers.Sleeper       = (*ili9341.Device)(nil)
+	_ drivers.SleepReporter = (*ili9341.Device)(nil)
+	_ drivers.Sleeper       = (*ssd1306.Device)(nil)
+	_ drivers.SleepReporter = (*ssd1306.Device)(nil)
+	_ drivers.Sleeper       = (*ssd1331.Device)(nil)
+	_ drivers.SleepReporter = (*ssd1331.Device)(nil)
+	_ drivers.Sleeper       = (*ssd1351.Device)(nil)
+	_ drivers.SleepReporter = (*ssd1351.Device)(nil)
+	_ drivers.Sleeper       = (*st7735.Device)(nil)
+	_ drivers.SleepReporter = (*st7735.Device)(nil)
+	_ drivers.Sleeper       = (*st7789.Device)(nil)
+	_ drivers.SleepReporter = (*st7789.Device)(nil)
+
+	_ drivers.Sleeper       = (*epd2in13.Device)(nil)
+	_ drivers.SleepReporter = (*epd2in13.Device)(nil)
+	_ drivers.Sleeper       = (*epd2in13x.Device)(nil)
+	_ drivers.SleepReporter = (*epd2in13x.Device)(nil)
+	_ drivers.Sleeper       = (*epd4in2.Device)(nil)
+	_ drivers.SleepReporter = (*epd4in2.Device)(nil)
+)
+
+// fakeSleeper records the calls made to it in a shared log.
+type fakeSleeper struct {
+	name string
+	log  *[]string
+	err  error
+}
+
+func (s *fakeSleeper) Sleep() error {
+	*s.log = append(*s.log, "sleep "+s.name)
+	return s.err
+}
+
+func (s *fakeSleeper) Wake() error {
+	*s.log = append(*s.log, "wake "+s.name)
+	return s.err
+}
+
+func TestSleepAll(t *testing.T) {
+	c := qt.New(t)
+	var log []string
+	errB := errors.New("b failed")
+	devices := []drivers.Sleeper{
+		&fakeSleeper{name: "a", log: &log},
+		&fakeSleeper{name: "b", log: &log, err: errB},
+		&fakeSleeper{name: "c", log: &log, err: errors.New("c failed")},
+	}
+
+	// Every device is tried, and the first error is returned.
+	c.Assert(drivers.SleepAll(devices...), qt.Equals, errB)
+	c.Assert(log, qt.DeepEquals, []string{"sleep a", "sleep b", "sleep c"})
+
+	log = nil
+	c.Assert(drivers.WakeAll(devices...), qt.ErrorMatches, "c failed")
+	c.Assert(log, qt.DeepEquals, []string{"wake c", "wake b", "wake a"})
+}
@@ -0,0 +1,23 @@
+package drivers
+
+// PWM is a single PWM output, such as a channel of a PWM peripheral, a
+// channel of a PWM controller chip like the PCA9685, or a software PWM on a
+// pin. Drivers for servos, buzzers and dimmable LEDs take a PWM so that they
+// work with any of them.
+//
+// Channels of the same peripheral or chip usually share their period, so
+// setting the period of one channel changes the others too.
+type PWM interface {
+	// SetPeriod sets the period of the signal in nanoseconds. Use the
+	// following formula to convert a frequency to a period:
+	//
+	//	period = 1e9 / frequency
+	SetPeriod(period uint64) error
+
+	// Top returns the value passed to Set for a 100% duty cycle. It may
+	// change after SetPeriod.
+	Top() uint32
+
+	// Set sets the duty cycle, from 0 (always low) to Top (always high).
+	Set(value uint32) error
+}
@@ -0,0 +1,49 @@
+//go:build tinygo
+// +build tinygo
+
+package drivers
+
+import "machine"
+
+// PWMPeripheral is implemented by the PWM peripherals of the machine
+// package, such as machine.PWM0 or machine.Timer1.
+type PWMPeripheral interface {
+	Configure(config machine.PWMConfig) error
+	Channel(pin machine.Pin) (channel uint8, err error)
+	Top() uint32
+	Set(channel uint8, value uint32)
+	SetPeriod(period uint64) error
+}
+
+// NewPWMChannel configures the PWM peripheral with its default period and
+// returns the channel that outputs on pin as a PWM. Please check the chip
+// documentation for the pins that can be used by a given peripheral.
+func NewPWMChannel(pwm PWMPeripheral, pin machine.Pin) (PWM, error) {
+	err := pwm.Configure(machine.PWMConfig{})
+	if err != nil {
+		return nil, err
+	}
+	ch, err := pwm.Channel(pin)
+	if err != nil {
+		return nil, err
+	}
+	return &pwmChannel{pwm, ch}, nil
+}
+
+type pwmChannel struct {
+	pwm PWMPeripheral
+	ch  uint8
+}
+
+func (c *pwmChannel) SetPeriod(period uint64) error {
+	return c.pwm.SetPeriod(period)
+}
+
+func (c *pwmChannel) Top() uint32 {
+	return c.pwm.Top()
+}
+
+func (c *pwmChannel) Set(value uint32) error {
+	c.pwm.Set(c.ch, value)
+	return nil
+}
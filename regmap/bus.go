@@ -0,0 +1,61 @@
+package regmap
+
+import "tinygo.org/x/drivers"
+
+// I2C is the Bus of a device at the given address on an I2C bus, which uses
+// the register access methods of the bus.
+type I2C struct {
+	Bus     drivers.I2C
+	Address uint8
+}
+
+// ReadRegisters implements Bus.
+func (b *I2C) ReadRegisters(reg uint8, buf []byte) error {
+	return b.Bus.ReadRegister(b.Address, reg, buf)
+}
+
+// WriteRegisters implements Bus.
+func (b *I2C) WriteRegisters(reg uint8, buf []byte) error {
+	return b.Bus.WriteRegister(b.Address, reg, buf)
+}
+
+// SPI is the Bus of a device on a SPI bus that is accessed by sending the
+// register address, possibly with a read or write flag, followed by the
+// data. This is the format used by most sensors.
+type SPI struct {
+	Bus drivers.SPI
+
+	// CS is the chip select pin of the device, active low. It must be
+	// configured as an output already. It can be nil if the chip select
+	// is handled elsewhere.
+	CS drivers.Pin
+
+	// ReadFlag and WriteFlag are set in the register address for reads and
+	// writes, for example 0x80 to read from ST and Bosch sensors.
+	ReadFlag  uint8
+	WriteFlag uint8
+
+	header [1]byte
+}
+
+// ReadRegisters implements Bus.
+func (b *SPI) ReadRegisters(reg uint8, buf []byte) error {
+	return b.transfer(reg|b.ReadFlag, nil, buf)
+}
+
+// WriteRegisters implements Bus.
+func (b *SPI) WriteRegisters(reg uint8, buf []byte) error {
+	return b.transfer(reg|b.WriteFlag, buf, nil)
+}
+
+func (b *SPI) transfer(header uint8, w, r []byte) error {
+	if !drivers.IsNoPin(b.CS) {
+		b.CS.Low()
+		defer b.CS.High()
+	}
+	b.header[0] = header
+	if err := b.Bus.Tx(b.header[:], nil); err != nil {
+		return err
+	}
+	return b.Bus.Tx(w, r)
+}
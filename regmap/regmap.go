@@ -0,0 +1,303 @@
+// Package regmap provides access to the registers of I2C and SPI devices
+// through typed bit field definitions.
+//
+// A Map wraps the bus of a device and implements the read-modify-write
+// cycles that drivers otherwise write by hand. Registers that only change
+// when they are written, such as configuration registers, can be cached to
+// save bus transactions, and several fields can be staged and then written
+// at once with Flush:
+//
+//	regs := regmap.New(&regmap.I2C{Bus: bus, Address: 0x34}, regmap.Uint8)
+//	regs.Cache(0x12, 0x28)
+//	regs.Stage(ldo2Voltage, 0xc)
+//	regs.Stage(ldo3Voltage, 0xc)
+//	err := regs.Flush() // a single write to register 0x28
+package regmap // import "tinygo.org/x/drivers/regmap"
+
+// Bus reads and writes consecutive registers of a device, starting at reg.
+// It is implemented by I2C and SPI, and can be implemented by drivers for
+// devices that use another framing.
+type Bus interface {
+	ReadRegisters(reg uint8, buf []byte) error
+	WriteRegisters(reg uint8, buf []byte) error
+}
+
+// Format is the size and byte order of the registers of a device.
+type Format uint8
+
+const (
+	// Uint8 registers hold a single byte.
+	Uint8 Format = iota
+	// Uint16BE registers hold two bytes, most significant first.
+	Uint16BE
+	// Uint16LE registers hold two bytes, least significant first.
+	Uint16LE
+)
+
+// size returns the number of bytes in a register.
+func (f Format) size() int {
+	if f == Uint8 {
+		return 1
+	}
+	return 2
+}
+
+func (f Format) decode(b []byte) uint16 {
+	switch f {
+	case Uint16BE:
+		return uint16(b[0])<<8 | uint16(b[1])
+	case Uint16LE:
+		return uint16(b[1])<<8 | uint16(b[0])
+	}
+	return uint16(b[0])
+}
+
+func (f Format) encode(b []byte, v uint16) {
+	switch f {
+	case Uint16BE:
+		b[0], b[1] = byte(v>>8), byte(v)
+	case Uint16LE:
+		b[0], b[1] = byte(v), byte(v>>8)
+	default:
+		b[0] = byte(v)
+	}
+}
+
+// Field is a group of adjacent bits in a register.
+type Field struct {
+	Reg   uint8
+	Shift uint8
+	Width uint8
+}
+
+// Bit returns the field for bit n of the given register.
+func Bit(reg, n uint8) Field {
+	return Field{Reg: reg, Shift: n, Width: 1}
+}
+
+// Mask returns the mask of the field bits in the register.
+func (f Field) Mask() uint16 {
+	return (1<<f.Width - 1) << f.Shift
+}
+
+// Extract returns the value of the field in the register value v.
+func (f Field) Extract(v uint16) uint16 {
+	return (v & f.Mask()) >> f.Shift
+}
+
+// Insert returns the register value v with the field set to x. Bits of x
+// that do not fit in the field are ignored.
+func (f Field) Insert(v, x uint16) uint16 {
+	return v&^f.Mask() | x<<f.Shift&f.Mask()
+}
+
+// registerSet is a set of register addresses.
+type registerSet [8]uint32
+
+func (s *registerSet) has(reg uint8) bool {
+	return s[reg/32]&(1<<(reg%32)) != 0
+}
+
+func (s *registerSet) add(reg uint8) {
+	s[reg/32] |= 1 << (reg % 32)
+}
+
+func (s *registerSet) remove(reg uint8) {
+	s[reg/32] &^= 1 << (reg % 32)
+}
+
+// Map gives access to the registers of a device.
+type Map struct {
+	bus    Bus
+	format Format
+	buf    [2 * 4]byte
+
+	// values holds the known values of the registers in valid: cached
+	// registers, and registers with staged changes. It is only allocated
+	// once it is needed.
+	values *[256]uint16
+	cached registerSet
+	valid  registerSet
+	dirty  registerSet
+}
+
+// New returns a map of the registers of the device on bus, in the given
+// format.
+func New(bus Bus, format Format) *Map {
+	return &Map{
+		bus:    bus,
+		format: format,
+	}
+}
+
+// Cache marks registers whose content can only be changed by writing them.
+// Once such a register has been read or written, its value is kept in
+// memory and reads are served without accessing the bus.
+func (m *Map) Cache(regs ...uint8) {
+	for _, reg := range regs {
+		m.cached.add(reg)
+	}
+}
+
+// Invalidate forgets the cached values, for example after a reset of the
+// device. Staged changes are dropped as well.
+func (m *Map) Invalidate() {
+	m.valid = registerSet{}
+	m.dirty = registerSet{}
+}
+
+// Read returns the value of a register.
+func (m *Map) Read(reg uint8) (uint16, error) {
+	if m.valid.has(reg) {
+		return m.values[reg], nil
+	}
+	buf := m.buf[:m.format.size()]
+	if err := m.bus.ReadRegisters(reg, buf); err != nil {
+		return 0, err
+	}
+	v := m.format.decode(buf)
+	m.remember(reg, v)
+	return v, nil
+}
+
+// ReadBlock reads len(values) consecutive registers starting at reg in a
+// single bus transaction, bypassing the cache. This is the usual way to read
+// the output registers of a sensor.
+func (m *Map) ReadBlock(reg uint8, values []uint16) error {
+	size := m.format.size()
+	var buf []byte
+	if len(values)*size <= len(m.buf) {
+		buf = m.buf[:len(values)*size]
+	} else {
+		buf = make([]byte, len(values)*size)
+	}
+	if err := m.bus.ReadRegisters(reg, buf); err != nil {
+		return err
+	}
+	for i := range values {
+		values[i] = m.format.decode(buf[i*size:])
+		if m.dirty.has(reg + uint8(i)) {
+			// Keep the staged value, it will be written by Flush.
+			continue
+		}
+		m.remember(reg+uint8(i), values[i])
+	}
+	return nil
+}
+
+// Write writes a register. A staged change of the register is replaced.
+func (m *Map) Write(reg uint8, v uint16) error {
+	buf := m.buf[:m.format.size()]
+	m.format.encode(buf, v)
+	if err := m.bus.WriteRegisters(reg, buf); err != nil {
+		// The register may or may not have been written, so its cached
+		// value cannot be trusted anymore.
+		if !m.dirty.has(reg) {
+			m.valid.remove(reg)
+		}
+		return err
+	}
+	m.dirty.remove(reg)
+	m.valid.remove(reg)
+	m.remember(reg, v)
+	return nil
+}
+
+// Update changes the bits of a register that are set in mask to those of
+// v, leaving the others untouched. The register is read first, unless it
+// is cached.
+func (m *Map) Update(reg uint8, mask, v uint16) error {
+	old, err := m.Read(reg)
+	if err != nil {
+		return err
+	}
+	return m.Write(reg, old&^mask|v&mask)
+}
+
+// Get returns the value of a field.
+func (m *Map) Get(f Field) (uint16, error) {
+	v, err := m.Read(f.Reg)
+	return f.Extract(v), err
+}
+
+// GetBool returns whether a field is non-zero. It is usually used with
+// single bit fields.
+func (m *Map) GetBool(f Field) (bool, error) {
+	v, err := m.Get(f)
+	return v != 0, err
+}
+
+// Set changes the value of a field right away.
+func (m *Map) Set(f Field, x uint16) error {
+	return m.Update(f.Reg, f.Mask(), x<<f.Shift)
+}
+
+// SetBool sets a field to 1 if on is true, or to 0 otherwise.
+func (m *Map) SetBool(f Field, on bool) error {
+	var x uint16
+	if on {
+		x = 1
+	}
+	return m.Set(f, x)
+}
+
+// Stage changes the value of a field in memory, to be written by Flush.
+// Several fields of the same register are then written at once.
+func (m *Map) Stage(f Field, x uint16) error {
+	v, err := m.Read(f.Reg)
+	if err != nil {
+		return err
+	}
+	m.allocate()
+	m.values[f.Reg] = f.Insert(v, x)
+	m.valid.add(f.Reg)
+	m.dirty.add(f.Reg)
+	return nil
+}
+
+// Flush writes the registers changed by Stage. Consecutive registers are
+// written in a single bus transaction.
+func (m *Map) Flush() error {
+	size := m.format.size()
+	for reg := 0; reg < 256; {
+		if !m.dirty.has(uint8(reg)) {
+			reg++
+			continue
+		}
+		end := reg
+		for end < 256 && m.dirty.has(uint8(end)) {
+			end++
+		}
+		buf := make([]byte, (end-reg)*size)
+		for r := reg; r < end; r++ {
+			m.format.encode(buf[(r-reg)*size:], m.values[r])
+		}
+		if err := m.bus.WriteRegisters(uint8(reg), buf); err != nil {
+			return err
+		}
+		for r := reg; r < end; r++ {
+			m.dirty.remove(uint8(r))
+			if !m.cached.has(uint8(r)) {
+				m.valid.remove(uint8(r))
+			}
+		}
+		reg = end
+	}
+	return nil
+}
+
+// remember keeps the value of a cached register.
+func (m *Map) remember(reg uint8, v uint16) {
+	if !m.cached.has(reg) {
+		return
+	}
+	m.allocate()
+	m.values[reg] = v
+	m.valid.add(reg)
+}
+
+func (m *Map) allocate() {
+	if m.values == nil {
+		m.values = new([256]uint16)
+	}
+}
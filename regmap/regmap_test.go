@@ -0,0 +1,140 @@
+package regmap
+
+import (
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"tinygo.org/x/drivers/tester"
+)
+
+func TestFields(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewI2CBus(c)
+	dev := bus.NewDevice(0x34)
+	dev.Registers[0x28] = 0xa5
+	regs := New(&I2C{Bus: bus, Address: 0x34}, Uint8)
+
+	high := Field{Reg: 0x28, Shift: 4, Width: 4}
+	v, err := regs.Get(high)
+	c.Assert(err, qt.IsNil)
+	c.Assert(v, qt.Equals, uint16(0xa))
+
+	c.Assert(regs.Set(high, 0x3), qt.IsNil)
+	c.Assert(dev.Registers[0x28], qt.Equals, uint8(0x35))
+
+	on, err := regs.GetBool(Bit(0x28, 0))
+	c.Assert(err, qt.IsNil)
+	c.Assert(on, qt.IsTrue)
+	c.Assert(regs.SetBool(Bit(0x28, 0), false), qt.IsNil)
+	c.Assert(dev.Registers[0x28], qt.Equals, uint8(0x34))
+}
+
+func TestCache(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewI2CBus(c)
+	dev := bus.NewDevice(0x34)
+	var trace strings.Builder
+	regs := New(&I2C{Bus: tester.NewI2CRecorder(bus, &trace), Address: 0x34}, Uint8)
+	regs.Cache(0x12)
+
+	c.Assert(regs.Set(Bit(0x12, 2), 1), qt.IsNil)
+	c.Assert(regs.Set(Bit(0x12, 3), 1), qt.IsNil)
+	v, err := regs.Read(0x12)
+	c.Assert(err, qt.IsNil)
+	c.Assert(v, qt.Equals, uint16(0x0c))
+	// Register 0x13 is not cached.
+	regs.Read(0x13)
+	regs.Read(0x13)
+	c.Assert(trace.String(), qt.Equals, `read 0x34 reg=0x12 r=00
+write 0x34 reg=0x12 w=04
+write 0x34 reg=0x12 w=0c
+read 0x34 reg=0x13 r=00
+read 0x34 reg=0x13 r=00
+`)
+
+	dev.Registers[0x12] = 0xff
+	regs.Invalidate()
+	v, err = regs.Read(0x12)
+	c.Assert(err, qt.IsNil)
+	c.Assert(v, qt.Equals, uint16(0xff))
+}
+
+func TestStageFlush(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewI2CBus(c)
+	dev := bus.NewDevice(0x40)
+	dev.Registers[0x10] = 0x80
+	var trace strings.Builder
+	regs := New(&I2C{Bus: tester.NewI2CRecorder(bus, &trace), Address: 0x40}, Uint8)
+
+	c.Assert(regs.Stage(Field{Reg: 0x10, Width: 4}, 0x3), qt.IsNil)
+	c.Assert(regs.Stage(Field{Reg: 0x10, Shift: 4, Width: 3}, 0x5), qt.IsNil)
+	c.Assert(regs.Stage(Bit(0x11, 7), 1), qt.IsNil)
+	c.Assert(dev.Registers[0x10], qt.Equals, uint8(0x80))
+	c.Assert(regs.Flush(), qt.IsNil)
+	c.Assert(dev.Registers[0x10:0x12], qt.DeepEquals, []uint8{0xd3, 0x80})
+	c.Assert(trace.String(), qt.Equals, `read 0x40 reg=0x10 r=80
+read 0x40 reg=0x11 r=00
+write 0x40 reg=0x10 w=d380
+`)
+
+	// Nothing is left to write.
+	c.Assert(regs.Flush(), qt.IsNil)
+	c.Assert(strings.Count(trace.String(), "write"), qt.Equals, 1)
+}
+
+func TestUint16(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewI2CBus(c)
+	dev := tester.NewI2CDevice16(c, 0x40)
+	dev.Registers = map[uint8]uint16{0x00: 0x6127, 0xfe: 0x5449}
+	bus.AddDevice(dev)
+	regs := New(&I2C{Bus: bus, Address: 0x40}, Uint16BE)
+
+	v, err := regs.Read(0xfe)
+	c.Assert(err, qt.IsNil)
+	c.Assert(v, qt.Equals, uint16(0x5449))
+	c.Assert(regs.Set(Field{Reg: 0x00, Shift: 9, Width: 3}, 0x7), qt.IsNil)
+	c.Assert(dev.Registers[0x00], qt.Equals, uint16(0x6f27))
+}
+
+func TestReadBlock(t *testing.T) {
+	c := qt.New(t)
+	spi := tester.NewSPIBus(c)
+	cs := tester.NewPin()
+	regs := New(&SPI{Bus: spi, CS: cs, ReadFlag: 0x80}, Uint16LE)
+
+	spi.Expect([]byte{0x92}, nil)
+	spi.Expect(nil, []byte{0x10, 0x00, 0xf0, 0xff, 0x00, 0x40})
+	values := make([]uint16, 3)
+	c.Assert(regs.ReadBlock(0x12, values), qt.IsNil)
+	c.Assert(values, qt.DeepEquals, []uint16{0x0010, 0xfff0, 0x4000})
+	c.Assert(cs.Levels(), qt.DeepEquals, []bool{false, true})
+
+	spi.Expect([]byte{0x7e}, nil)
+	spi.Expect([]byte{0xb6, 0x00}, nil)
+	c.Assert(regs.Write(0x7e, 0xb6), qt.IsNil)
+	spi.AssertDone()
+}
+
+func TestErrors(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewI2CBus(c)
+	bus.NewDevice(0x34)
+	bus.NACK(0x34)
+	regs := New(&I2C{Bus: bus, Address: 0x34}, Uint8)
+	regs.Cache(0x12)
+
+	_, err := regs.Get(Bit(0x12, 0))
+	c.Assert(err, qt.Equals, tester.ErrNACK)
+	c.Assert(regs.Set(Bit(0x12, 0), 1), qt.Equals, tester.ErrNACK)
+	c.Assert(regs.Stage(Bit(0x12, 0), 1), qt.Equals, tester.ErrNACK)
+
+	bus.ACK(0x34)
+	c.Assert(regs.Stage(Bit(0x12, 0), 1), qt.IsNil)
+	bus.NACK(0x34)
+	c.Assert(regs.Flush(), qt.Equals, tester.ErrNACK)
+	bus.ACK(0x34)
+	c.Assert(regs.Flush(), qt.IsNil)
+}
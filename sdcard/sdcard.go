@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"machine"
 	"time"
+
+	"tinygo.org/x/drivers"
 )
 
 const (
@@ -39,6 +41,7 @@ type Device struct {
 	sdCardType byte
 	CID        *CID
 	CSD        *CSD
+	Clock      drivers.Clock
 }
 
 func New(b machine.SPI, sck, sdo, sdi, cs machine.Pin) Device {
@@ -52,7 +55,17 @@ func New(b machine.SPI, sck, sdo, sdi, cs machine.Pin) Device {
 		dummybuf:   make([]byte, 512),
 		tokenbuf:   make([]byte, 1),
 		sdCardType: 0,
+		Clock:      drivers.SystemClock,
+	}
+}
+
+// clock returns the Clock of the device, or drivers.SystemClock for a
+// Device that was not created by New.
+func (d *Device) clock() drivers.Clock {
+	if d.Clock == nil {
+		return drivers.SystemClock
 	}
+	return d.Clock
 }
 
 func (d *Device) Configure() error {
@@ -85,7 +98,7 @@ func (d *Device) initCard() error {
 
 	// CMD0: init card; sould return _R1_IDLE_STATE (allow 5 attempts)
 	ok := false
-	tm := setTimeout(0, 2*time.Second)
+	tm := d.setTimeout(0, 2*time.Second)
 	for !tm.expired() {
 		// Wait up to 2 seconds to be the same as the Arduino
 		if d.cmd(CMD0_GO_IDLE_STATE, 0, 0x95) == _R1_IDLE_STATE {
@@ -137,7 +150,7 @@ func (d *Device) initCard() error {
 
 	// check for timeout
 	ok = false
-	tm = setTimeout(0, 2*time.Second)
+	tm = d.setTimeout(0, 2*time.Second)
 	for !tm.expired() {
 		if d.acmd(ACMD41_SD_APP_OP_COND, arg) == 0 {
 			ok = true
@@ -246,7 +259,7 @@ func (d Device) cmd(cmd byte, arg uint32, crc byte) byte {
 }
 
 func (d Device) waitNotBusy(timeout time.Duration) error {
-	tm := setTimeout(1, timeout)
+	tm := d.setTimeout(1, timeout)
 	for !tm.expired() {
 		r, err := d.bus.Transfer(byte(0xFF))
 		if err != nil {
@@ -262,7 +275,7 @@ func (d Device) waitNotBusy(timeout time.Duration) error {
 func (d Device) waitStartBlock() error {
 	status := byte(0xFF)
 
-	tm := setTimeout(0, 300*time.Millisecond)
+	tm := d.setTimeout(0, 300*time.Millisecond)
 	for !tm.expired() {
 		var err error
 		status, err = d.bus.Transfer(byte(0xFF))
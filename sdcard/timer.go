@@ -2,21 +2,25 @@ package sdcard
 
 import (
 	"time"
+
+	"tinygo.org/x/drivers"
 )
 
 var timeoutTimer [2]timer
 
 type timer struct {
-	start   int64
-	timeout int64
+	clock   drivers.Clock
+	start   time.Time
+	timeout time.Duration
 }
 
-func setTimeout(timerID int, timeout time.Duration) *timer {
-	timeoutTimer[timerID].start = time.Now().UnixNano()
-	timeoutTimer[timerID].timeout = timeout.Nanoseconds()
+func (d Device) setTimeout(timerID int, timeout time.Duration) *timer {
+	timeoutTimer[timerID].clock = d.clock()
+	timeoutTimer[timerID].start = d.clock().Now()
+	timeoutTimer[timerID].timeout = timeout
 	return &timeoutTimer[timerID]
 }
 
 func (t timer) expired() bool {
-	return time.Now().UnixNano() > (t.start + t.timeout)
+	return t.clock.Now().Sub(t.start) > t.timeout
 }
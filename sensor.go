@@ -0,0 +1,48 @@
+package drivers
+
+// The interfaces below are implemented by the sensor drivers in this
+// repository. They all use fixed-point integers in a common SI-derived unit so
+// that application code can swap one part for another without changing any
+// arithmetic.
+
+// Thermometer measures the ambient temperature.
+type Thermometer interface {
+	// ReadTemperature returns the temperature in celsius milli degrees
+	// (°C/1000).
+	ReadTemperature() (int32, error)
+}
+
+// Barometer measures the atmospheric pressure.
+type Barometer interface {
+	// ReadPressure returns the pressure in milli pascals (mPa).
+	ReadPressure() (int32, error)
+}
+
+// Hygrometer measures the relative humidity.
+type Hygrometer interface {
+	// ReadHumidity returns the relative humidity in hundredths of a percent.
+	ReadHumidity() (int32, error)
+}
+
+// Accelerometer measures proper acceleration on three axes.
+type Accelerometer interface {
+	// ReadAcceleration returns the acceleration in µg (micro-gravity). When
+	// one of the axes is pointing straight to Earth and the sensor is not
+	// moving the returned value will be around 1000000 or -1000000.
+	ReadAcceleration() (x, y, z int32, err error)
+}
+
+// Gyroscope measures angular velocity on three axes.
+type Gyroscope interface {
+	// ReadRotation returns the rotation in µ°/s (micro-degrees/sec). Doing a
+	// complete rotation along one axis and integrating all values over time
+	// gives a value close to 360000000.
+	ReadRotation() (x, y, z int32, err error)
+}
+
+// Magnetometer measures the magnetic field on three axes.
+type Magnetometer interface {
+	// ReadMagneticField returns the magnetic field in nT (nanotesla).
+	// 1 G (gauss) = 100_000 nT.
+	ReadMagneticField() (x, y, z int32, err error)
+}
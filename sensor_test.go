@@ -0,0 +1,63 @@
+package drivers_test
+
+import (
+	"tinygo.org/x/drivers"
+	"tinygo.org/x/drivers/adxl345"
+	"tinygo.org/x/drivers/aht20"
+	"tinygo.org/x/drivers/bme280"
+	"tinygo.org/x/drivers/bmp280"
+	"tinygo.org/x/drivers/bmp388"
+	"tinygo.org/x/drivers/lis2mdl"
+	"tinygo.org/x/drivers/lis3dh"
+	"tinygo.org/x/drivers/lsm303agr"
+	"tinygo.org/x/drivers/lsm6dsox"
+	"tinygo.org/x/drivers/lsm9ds1"
+	"tinygo.org/x/drivers/mpu6050"
+	"tinygo.org/x/drivers/sht3x"
+	"tinygo.org/x/drivers/shtc3"
+)
+
+// Compile-time checks that the sensor drivers implement the common sensor
+// interfaces. Drivers that depend on the machine package (such as hts221,
+// lps22hb and bmi160) can only be checked when building with TinyGo.
+var (
+	_ drivers.Thermometer = (*aht20.Device)(nil)
+	_ drivers.Hygrometer  = (*aht20.Device)(nil)
+
+	_ drivers.Thermometer = (*bme280.Device)(nil)
+	_ drivers.Barometer   = (*bme280.Device)(nil)
+	_ drivers.Hygrometer  = (*bme280.Device)(nil)
+
+	_ drivers.Thermometer = (*bmp280.Device)(nil)
+	_ drivers.Barometer   = (*bmp280.Device)(nil)
+
+	_ drivers.Thermometer = (*bmp388.Device)(nil)
+	_ drivers.Barometer   = (*bmp388.Device)(nil)
+
+	_ drivers.Thermometer = (*sht3x.Device)(nil)
+	_ drivers.Hygrometer  = (*sht3x.Device)(nil)
+
+	_ drivers.Thermometer = (*shtc3.Device)(nil)
+	_ drivers.Hygrometer  = (*shtc3.Device)(nil)
+
+	_ drivers.Accelerometer = (*adxl345.Device)(nil)
+	_ drivers.Accelerometer = (*lis3dh.Device)(nil)
+
+	_ drivers.Accelerometer = (*mpu6050.Device)(nil)
+	_ drivers.Gyroscope     = (*mpu6050.Device)(nil)
+
+	_ drivers.Accelerometer = (*lsm6dsox.Device)(nil)
+	_ drivers.Gyroscope     = (*lsm6dsox.Device)(nil)
+	_ drivers.Thermometer   = (*lsm6dsox.Device)(nil)
+
+	_ drivers.Accelerometer = (*lsm9ds1.Device)(nil)
+	_ drivers.Gyroscope     = (*lsm9ds1.Device)(nil)
+	_ drivers.Magnetometer  = (*lsm9ds1.Device)(nil)
+	_ drivers.Thermometer   = (*lsm9ds1.Device)(nil)
+
+	_ drivers.Magnetometer = (*lis2mdl.Device)(nil)
+
+	_ drivers.Accelerometer = (*lsm303agr.Device)(nil)
+	_ drivers.Magnetometer  = (*lsm303agr.Device)(nil)
+	_ drivers.Thermometer   = (*lsm303agr.Device)(nil)
+)
@@ -0,0 +1,71 @@
+//go:build tinygo
+// +build tinygo
+
+package servo
+
+import "machine"
+
+// PWM is the interface necessary for controlling typical servo motors.
+type PWM interface {
+	Configure(config machine.PWMConfig) error
+	Channel(pin machine.Pin) (channel uint8, err error)
+	Top() uint32
+	Set(channel uint8, value uint32)
+}
+
+// Array is an array of servos controlled by a single PWM peripheral. On most
+// chips, one PWM peripheral can control multiple servos (usually two or four).
+type Array struct {
+	pwm PWM
+}
+
+// NewArray returns a new servo array based on the given PWM, for if you want to
+// control multiple servos from a single PWM peripheral. Using a single PWM for
+// multiple servos saves PWM peripherals for other uses and might use less power
+// depending on the chip.
+//
+// If you only want to control a single servo, you could use New with
+// drivers.NewPWMChannel instead.
+func NewArray(pwm PWM) (Array, error) {
+	err := pwm.Configure(machine.PWMConfig{
+		Period: pwmPeriod,
+	})
+	if err != nil {
+		return Array{}, err
+	}
+	return Array{pwm}, nil
+}
+
+// Add adds a new servo to the servo array. Please check the chip documentation
+// which pins can be controlled by the given PWM: depending on the chip this
+// might be rigid (only a single pin) or very flexible (you can pick any pin).
+func (array Array) Add(pin machine.Pin) (Servo, error) {
+	channel, err := array.pwm.Channel(pin)
+	if err != nil {
+		return Servo{}, err
+	}
+	return Servo{
+		pwm: &arrayChannel{array.pwm, channel},
+	}, nil
+}
+
+// arrayChannel is a channel of the PWM of an Array. It implements drivers.PWM.
+type arrayChannel struct {
+	pwm     PWM
+	channel uint8
+}
+
+func (c *arrayChannel) SetPeriod(period uint64) error {
+	return c.pwm.Configure(machine.PWMConfig{
+		Period: period,
+	})
+}
+
+func (c *arrayChannel) Top() uint32 {
+	return c.pwm.Top()
+}
+
+func (c *arrayChannel) Set(value uint32) error {
+	c.pwm.Set(c.channel, value)
+	return nil
+}
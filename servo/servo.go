@@ -1,69 +1,24 @@
 package servo
 
-import "machine"
+import "tinygo.org/x/drivers"
 
-// PWM is the interface necessary for controlling typical servo motors.
-type PWM interface {
-	Configure(config machine.PWMConfig) error
-	Channel(pin machine.Pin) (channel uint8, err error)
-	Top() uint32
-	Set(channel uint8, value uint32)
-}
-
-// Array is an array of servos controlled by a single PWM peripheral. On most
-// chips, one PWM peripheral can control multiple servos (usually two or four).
-type Array struct {
-	pwm PWM
-}
-
-// Servo is a single servo (connected to one PWM output) that's part of a servo
-// array.
+// Servo is a single servo connected to a PWM output, such as a channel of a
+// PWM peripheral, a channel of a PCA9685 or a software PWM.
 type Servo struct {
-	pwm     PWM
-	channel uint8
+	pwm drivers.PWM
 }
 
 const pwmPeriod = 20e6 // 20ms
 
-// NewArray returns a new servo array based on the given PWM, for if you want to
-// control multiple servos from a single PWM peripheral. Using a single PWM for
-// multiple servos saves PWM peripherals for other uses and might use less power
-// depending on the chip.
-//
-// If you only want to control a single servo, you could use the New shorthand
-// instead.
-func NewArray(pwm PWM) (Array, error) {
-	err := pwm.Configure(machine.PWMConfig{
-		Period: pwmPeriod,
-	})
-	if err != nil {
-		return Array{}, err
-	}
-	return Array{pwm}, nil
-}
-
-// Add adds a new servo to the servo array. Please check the chip documentation
-// which pins can be controlled by the given PWM: depending on the chip this
-// might be rigid (only a single pin) or very flexible (you can pick any pin).
-func (array Array) Add(pin machine.Pin) (Servo, error) {
-	channel, err := array.pwm.Channel(pin)
-	if err != nil {
-		return Servo{}, err
-	}
-	return Servo{
-		pwm:     array.pwm,
-		channel: channel,
-	}, nil
-}
-
-// New is a shorthand for NewArray and array.Add. This is useful if you only
-// want to control just a single servo.
-func New(pwm PWM, pin machine.Pin) (Servo, error) {
-	array, err := NewArray(pwm)
+// New returns a servo controlled by the given PWM output, and sets its period
+// to the 20ms expected by servos. Channels sharing the period of this output
+// can control other servos, see NewArray for the PWM peripherals of the chip.
+func New(pwm drivers.PWM) (Servo, error) {
+	err := pwm.SetPeriod(pwmPeriod)
 	if err != nil {
 		return Servo{}, err
 	}
-	return array.Add(pin)
+	return Servo{pwm}, nil
 }
 
 // SetMicroseconds sets the output signal to be high for the given number of
@@ -76,7 +31,7 @@ func New(pwm PWM, pin machine.Pin) (Servo, error) {
 // break the servo as it might destroy the gears if it doesn't support this
 // range. Therefore, to be sure check the datasheet before you try values
 // outside of the 1000µs-2000µs range.
-func (s Servo) SetMicroseconds(microseconds int16) {
+func (s Servo) SetMicroseconds(microseconds int16) error {
 	value := uint64(s.pwm.Top()) * uint64(microseconds) / (pwmPeriod / 1000)
-	s.pwm.Set(s.channel, uint32(value))
+	return s.pwm.Set(uint32(value))
 }
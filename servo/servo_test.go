@@ -0,0 +1,29 @@
+package servo
+
+import (
+	"errors"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"tinygo.org/x/drivers/tester"
+)
+
+func TestSetMicroseconds(t *testing.T) {
+	c := qt.New(t)
+	pwm := tester.NewPWM(4096)
+	s, err := New(pwm)
+	c.Assert(err, qt.IsNil)
+	c.Assert(pwm.Period, qt.Equals, uint64(20e6))
+
+	c.Assert(s.SetMicroseconds(1500), qt.IsNil)
+	c.Assert(pwm.Value(), qt.Equals, uint32(4096*1500/20000))
+}
+
+func TestNewError(t *testing.T) {
+	c := qt.New(t)
+	pwm := tester.NewPWM(4096)
+	pwm.Err = errors.New("bad period")
+	_, err := New(pwm)
+	c.Assert(err, qt.ErrorMatches, "bad period")
+}
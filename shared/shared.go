@@ -0,0 +1,58 @@
+// Package shared provides I2C and SPI bus wrappers that can be used by
+// several drivers running in different goroutines.
+//
+// The drivers in this repository use their bus without any locking, so two
+// goroutines using devices on the same bus can interleave their
+// transactions. Wrapping the bus once and passing the wrapper to every
+// driver constructor serializes the transactions:
+//
+//	bus := shared.NewI2C(machine.I2C0)
+//	sensor := bme280.New(bus)
+//	display := ssd1306.NewI2C(bus)
+//
+// On SPI, each device also has its own chip select, which is only asserted
+// while the device owns the bus.
+package shared // import "tinygo.org/x/drivers/shared"
+
+import (
+	"sync"
+
+	"tinygo.org/x/drivers"
+)
+
+// I2C is an I2C bus that can be shared by several goroutines. Every call is
+// a complete transaction, which runs while holding the bus lock.
+type I2C struct {
+	mu  sync.Mutex
+	bus drivers.I2C
+}
+
+// NewI2C returns a wrapper around bus that serializes its transactions.
+// The bus must already be configured, and must not be used directly
+// anymore.
+func NewI2C(bus drivers.I2C) *I2C {
+	return &I2C{
+		bus: bus,
+	}
+}
+
+// ReadRegister implements drivers.I2C.
+func (b *I2C) ReadRegister(addr uint8, r uint8, buf []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.bus.ReadRegister(addr, r, buf)
+}
+
+// WriteRegister implements drivers.I2C.
+func (b *I2C) WriteRegister(addr uint8, r uint8, buf []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.bus.WriteRegister(addr, r, buf)
+}
+
+// Tx implements drivers.I2C.
+func (b *I2C) Tx(addr uint16, w, r []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.bus.Tx(addr, w, r)
+}
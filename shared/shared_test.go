@@ -0,0 +1,116 @@
+package shared
+
+import (
+	"sync"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"tinygo.org/x/drivers/tester"
+)
+
+// checkedSPI is a SPI bus that checks that exactly one device is selected
+// during every transaction.
+type checkedSPI struct {
+	c   *qt.C
+	cs  []*tester.Pin
+	log []int
+}
+
+func (s *checkedSPI) Tx(w, r []byte) error {
+	selected := -1
+	for i, cs := range s.cs {
+		if !cs.Get() {
+			s.c.Check(selected, qt.Equals, -1, qt.Commentf("several devices selected"))
+			selected = i
+		}
+	}
+	s.c.Check(selected, qt.Not(qt.Equals), -1, qt.Commentf("no device selected"))
+	s.log = append(s.log, selected)
+	return nil
+}
+
+func (s *checkedSPI) Transfer(b byte) (byte, error) {
+	return 0, s.Tx([]byte{b}, nil)
+}
+
+func TestSPI(t *testing.T) {
+	c := qt.New(t)
+	bus := &checkedSPI{c: c, cs: []*tester.Pin{tester.NewPin(), tester.NewPin()}}
+	shared := NewSPI(bus)
+	dev0 := shared.Device(bus.cs[0])
+	dev1 := shared.Device(bus.cs[1])
+	c.Assert(bus.cs[0].Configured(), qt.IsTrue)
+	c.Assert(bus.cs[0].Get(), qt.IsTrue)
+
+	const n = 200
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < n; i++ {
+			dev0.Tx([]byte{1, 2}, nil)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		// A driver that keeps its device selected over several
+		// transactions.
+		cs := dev1.ChipSelect()
+		for i := 0; i < n; i++ {
+			cs.Low()
+			dev1.Transfer(1)
+			dev1.Transfer(2)
+			cs.High()
+		}
+	}()
+	wg.Wait()
+
+	c.Assert(bus.log, qt.HasLen, 3*n)
+	for i := 0; i < len(bus.log); i++ {
+		if bus.log[i] == 1 {
+			c.Assert(bus.log[i+1], qt.Equals, 1, qt.Commentf("transactions of device 1 interleaved"))
+			i++
+		}
+	}
+	c.Assert(bus.cs[0].Get(), qt.IsTrue)
+	c.Assert(bus.cs[1].Get(), qt.IsTrue)
+}
+
+func TestChipSelectHigh(t *testing.T) {
+	c := qt.New(t)
+	cs := tester.NewPin()
+	dev := NewSPI(&checkedSPI{c: c, cs: []*tester.Pin{cs}}).Device(cs)
+
+	// Drivers usually deselect their device when they are configured,
+	// before ever selecting it.
+	dev.ChipSelect().High()
+	c.Assert(dev.ChipSelect().Get(), qt.IsTrue)
+	dev.ChipSelect().Low()
+	c.Assert(dev.ChipSelect().Get(), qt.IsFalse)
+	c.Assert(cs.Get(), qt.IsFalse)
+	dev.ChipSelect().High()
+	c.Assert(cs.Get(), qt.IsTrue)
+}
+
+func TestI2C(t *testing.T) {
+	c := qt.New(t)
+	mock := tester.NewI2CBus(c)
+	dev := mock.NewDevice(0x20)
+	bus := NewI2C(mock)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(r uint8) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				bus.WriteRegister(0x20, r, []byte{byte(j)})
+				buf := make([]byte, 1)
+				bus.ReadRegister(0x20, r, buf)
+			}
+		}(uint8(i))
+	}
+	wg.Wait()
+
+	c.Assert(dev.Registers[:4], qt.DeepEquals, []uint8{99, 99, 99, 99})
+}
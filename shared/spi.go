@@ -0,0 +1,130 @@
+package shared
+
+import (
+	"sync"
+
+	"tinygo.org/x/drivers"
+)
+
+// SPI is a SPI bus that can be shared by several goroutines. The bus itself
+// is not used directly: each device on it is accessed through the SPIDevice
+// returned by Device.
+type SPI struct {
+	mu  sync.Mutex
+	bus drivers.SPI
+}
+
+// NewSPI returns a wrapper around bus that serializes the transactions of
+// its devices. The bus must already be configured, and must not be used
+// directly anymore.
+func NewSPI(bus drivers.SPI) *SPI {
+	return &SPI{
+		bus: bus,
+	}
+}
+
+// Device returns a handle for the device selected by the given chip select
+// pin, active low. The pin is configured as an output and deselected.
+// Drivers that drive their chip select themselves must be given the pin
+// returned by ChipSelect instead of cs.
+func (s *SPI) Device(cs drivers.Pin) *SPIDevice {
+	if !drivers.IsNoPin(cs) {
+		drivers.ConfigurePin(cs, drivers.PinOutput)
+		cs.High()
+	}
+	return &SPIDevice{
+		bus: s,
+		cs:  cs,
+	}
+}
+
+// SPIDevice is a device on a shared SPI bus. It implements drivers.SPI, so
+// it can be passed to any driver constructor. A device must only be used
+// from one goroutine at a time, which is the case when it is owned by a
+// single driver.
+type SPIDevice struct {
+	bus *SPI
+	cs  drivers.Pin
+
+	// held is set while a driver keeps the device selected through the pin
+	// returned by ChipSelect. The bus is locked for the whole time.
+	held bool
+}
+
+// Tx implements drivers.SPI. The chip select is asserted for the duration
+// of the transaction.
+func (d *SPIDevice) Tx(w, r []byte) error {
+	if d.held {
+		return d.bus.bus.Tx(w, r)
+	}
+	d.bus.mu.Lock()
+	defer d.bus.mu.Unlock()
+	d.selectDevice(true)
+	defer d.selectDevice(false)
+	return d.bus.bus.Tx(w, r)
+}
+
+// Transfer implements drivers.SPI.
+func (d *SPIDevice) Transfer(b byte) (byte, error) {
+	if d.held {
+		return d.bus.bus.Transfer(b)
+	}
+	d.bus.mu.Lock()
+	defer d.bus.mu.Unlock()
+	d.selectDevice(true)
+	defer d.selectDevice(false)
+	return d.bus.bus.Transfer(b)
+}
+
+func (d *SPIDevice) selectDevice(selected bool) {
+	if !drivers.IsNoPin(d.cs) {
+		d.cs.Set(!selected)
+	}
+}
+
+// ChipSelect returns a pin for drivers that drive the chip select
+// themselves, such as st7789 or ssd1306. Driving the pin low takes the bus
+// lock and selects the device, and driving it high deselects the device and
+// releases the lock, so the transactions that the driver makes in between
+// are not interleaved with those of other devices.
+func (d *SPIDevice) ChipSelect() drivers.Pin {
+	return chipSelect{d}
+}
+
+// chipSelect is the pin returned by SPIDevice.ChipSelect.
+type chipSelect struct {
+	d *SPIDevice
+}
+
+// Configure implements drivers.ConfigurablePin. The chip select is always
+// an output, so it is configured by SPI.Device already.
+func (p chipSelect) Configure(config drivers.PinConfig) {}
+
+// Get implements drivers.Pin.
+func (p chipSelect) Get() bool {
+	return !p.d.held
+}
+
+// Set implements drivers.Pin.
+func (p chipSelect) Set(high bool) {
+	d := p.d
+	if !high && !d.held {
+		d.bus.mu.Lock()
+		d.held = true
+		d.selectDevice(true)
+	} else if high && d.held {
+		d.selectDevice(false)
+		d.held = false
+		d.bus.mu.Unlock()
+	}
+}
+
+// High implements drivers.Pin.
+func (p chipSelect) High() {
+	p.Set(true)
+}
+
+// Low implements drivers.Pin.
+func (p chipSelect) Low() {
+	p.Set(false)
+}
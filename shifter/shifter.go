@@ -3,7 +3,8 @@ package shifter // import "tinygo.org/x/drivers/shifter"
 
 import (
 	"errors"
-	"machine"
+
+	"tinygo.org/x/drivers"
 )
 
 const (
@@ -16,22 +17,24 @@ type NumberBit int8
 
 // Device holds the Pins.
 type Device struct {
-	latch machine.Pin
-	clk   machine.Pin
-	out   machine.Pin
+	latch drivers.Pin
+	clk   drivers.Pin
+	out   drivers.Pin
 	Pins  []ShiftPin
 	bits  NumberBit
 }
 
-// ShiftPin is the implementation of the ShiftPin interface.
+// ShiftPin is a single input of the shift register. It implements
+// drivers.Pin, so it can be used by other drivers that read pins, such as a
+// keypad.
 type ShiftPin struct {
-	pin     machine.Pin
+	pin     int
 	d       *Device
 	pressed bool
 }
 
 // New returns a new shifter driver given the correct pins.
-func New(numBits NumberBit, latch, clk, out machine.Pin) Device {
+func New(numBits NumberBit, latch, clk, out drivers.Pin) Device {
 	return Device{
 		latch: latch,
 		clk:   clk,
@@ -43,9 +46,9 @@ func New(numBits NumberBit, latch, clk, out machine.Pin) Device {
 
 // Configure here just for interface compatibility.
 func (d *Device) Configure() {
-	d.latch.Configure(machine.PinConfig{Mode: machine.PinOutput})
-	d.clk.Configure(machine.PinConfig{Mode: machine.PinOutput})
-	d.out.Configure(machine.PinConfig{Mode: machine.PinInput})
+	drivers.ConfigurePin(d.latch, drivers.PinOutput)
+	drivers.ConfigurePin(d.clk, drivers.PinOutput)
+	drivers.ConfigurePin(d.out, drivers.PinInput)
 	for i := 0; i < int(d.bits); i++ {
 		d.Pins[i] = d.GetShiftPin(i)
 	}
@@ -53,7 +56,7 @@ func (d *Device) Configure() {
 
 // GetShiftPin returns an ShiftPin for a specific input.
 func (d *Device) GetShiftPin(input int) ShiftPin {
-	return ShiftPin{pin: machine.Pin(input), d: d}
+	return ShiftPin{pin: input, d: d}
 }
 
 // Read8Input updates the internal pins' states and returns it as an uint8.
@@ -84,7 +87,23 @@ func (d *Device) Read32Input() (uint32, error) {
 // Read{8|16|32}Input should be called before to update the state. Read{8|16|32}Input updates
 // all the pins, no need to call it for each pin individually.
 func (p ShiftPin) Get() bool {
-	return p.pressed
+	if p.d == nil {
+		return p.pressed
+	}
+	return p.d.Pins[p.pin].pressed
+}
+
+// Set has no effect, as the pins of the shift register are inputs. It is
+// only here to implement drivers.Pin.
+func (p ShiftPin) Set(value bool) {
+}
+
+// High has no effect, see Set.
+func (p ShiftPin) High() {
+}
+
+// Low has no effect, see Set.
+func (p ShiftPin) Low() {
 }
 
 // Configure here just for interface compatibility.
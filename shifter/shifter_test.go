@@ -0,0 +1,37 @@
+package shifter
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"tinygo.org/x/drivers"
+	"tinygo.org/x/drivers/tester"
+)
+
+func TestRead8Input(t *testing.T) {
+	c := qt.New(t)
+	latch, clk, out := tester.NewPin(), tester.NewPin(), tester.NewPin()
+	d := New(EIGHT_BITS, latch, clk, out)
+	d.Configure()
+	c.Assert(out.Mode(), qt.Equals, drivers.PinInput)
+
+	// The input is read from the highest bit down; drive 0b10000001.
+	var n int
+	out.Input = func() bool {
+		n++
+		return n == 1 || n == 8
+	}
+	v, err := d.Read8Input()
+	c.Assert(err, qt.IsNil)
+	c.Assert(v, qt.Equals, uint8(0b10000001))
+
+	// Pins obtained before or after the read see the new state.
+	var pin drivers.Pin = d.GetShiftPin(7)
+	c.Assert(pin.Get(), qt.IsTrue)
+	c.Assert(d.Pins[0].Get(), qt.IsTrue)
+	c.Assert(d.Pins[1].Get(), qt.IsFalse)
+
+	_, err = d.Read16Input()
+	c.Assert(err, qt.ErrorMatches, "wrong amount of registers")
+}
@@ -1,9 +1,7 @@
 // Package shiftregister is for 8bit shift output register using 3 GPIO pins like SN74ALS164A, SN74AHC594, SN74AHC595, ...
 package shiftregister
 
-import (
-	"machine"
-)
+import "tinygo.org/x/drivers"
 
 type NumberBit int8
 
@@ -16,20 +14,19 @@ const (
 
 // Device holds pin number
 type Device struct {
-	latch, clock, out machine.Pin // IC wiring
+	latch, clock, out drivers.Pin // IC wiring
 	bits              NumberBit   // Pin number
 	mask              uint32      // keep all pins state
 }
 
-// ShiftPin is the implementation of the ShiftPin interface.
-// ShiftPin provide an interface like regular machine.Pin
+// ShiftPin is a single output of the register. It implements drivers.Pin.
 type ShiftPin struct {
 	mask uint32  // Bit representing the pin
 	d    *Device // Reference to the register
 }
 
 // New returns a new shift output register device
-func New(Bits NumberBit, Latch, Clock, Out machine.Pin) *Device {
+func New(Bits NumberBit, Latch, Clock, Out drivers.Pin) *Device {
 	return &Device{
 		latch: Latch,
 		clock: Clock,
@@ -40,9 +37,9 @@ func New(Bits NumberBit, Latch, Clock, Out machine.Pin) *Device {
 
 // Configure set hardware configuration
 func (d *Device) Configure() {
-	d.latch.Configure(machine.PinConfig{Mode: machine.PinOutput})
-	d.clock.Configure(machine.PinConfig{Mode: machine.PinOutput})
-	d.out.Configure(machine.PinConfig{Mode: machine.PinOutput})
+	drivers.ConfigurePin(d.latch, drivers.PinOutput)
+	drivers.ConfigurePin(d.clock, drivers.PinOutput)
+	drivers.ConfigurePin(d.out, drivers.PinOutput)
 	d.latch.High()
 }
 
@@ -72,6 +69,12 @@ func (d *Device) GetShiftPin(pin int) *ShiftPin {
 
 }
 
+// Get returns the value last set on this register pin. It makes ShiftPin
+// implement drivers.Pin, so it can be used by other drivers.
+func (p ShiftPin) Get() bool {
+	return p.d.mask&p.mask != 0
+}
+
 // Set changes the value of this register pin.
 func (p ShiftPin) Set(value bool) {
 	d := p.d
@@ -0,0 +1,51 @@
+package shiftregister
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"tinygo.org/x/drivers"
+	"tinygo.org/x/drivers/tester"
+)
+
+func TestWriteMask(t *testing.T) {
+	c := qt.New(t)
+	latch, clock, out := tester.NewPin(), tester.NewPin(), tester.NewPin()
+	d := New(EIGHT_BITS, latch, clock, out)
+	d.Configure()
+	c.Assert(out.Mode(), qt.Equals, drivers.PinOutput)
+	latch.Reset()
+
+	d.WriteMask(0b10110001)
+
+	// LSB is shifted out first.
+	c.Assert(out.Levels(), qt.DeepEquals, []bool{true, false, false, false, true, true, false, true})
+	c.Assert(clock.Levels(), qt.HasLen, 16)
+	c.Assert(latch.Levels(), qt.DeepEquals, []bool{false, true})
+}
+
+func TestShiftPin(t *testing.T) {
+	c := qt.New(t)
+	latch, clock, out := tester.NewPin(), tester.NewPin(), tester.NewPin()
+	d := New(EIGHT_BITS, latch, clock, out)
+	d.Configure()
+
+	d.GetShiftPin(3).High()
+	c.Assert(d.mask, qt.Equals, uint32(0b1000))
+	d.GetShiftPin(0).High()
+	d.GetShiftPin(3).Low()
+	c.Assert(d.mask, qt.Equals, uint32(0b0001))
+}
+
+func TestShiftPinGet(t *testing.T) {
+	c := qt.New(t)
+	latch, clock, out := tester.NewPin(), tester.NewPin(), tester.NewPin()
+	d := New(EIGHT_BITS, latch, clock, out)
+	d.Configure()
+
+	var pin drivers.Pin = d.GetShiftPin(5)
+	c.Assert(pin.Get(), qt.IsFalse)
+	pin.High()
+	c.Assert(pin.Get(), qt.IsTrue)
+	c.Assert(d.GetShiftPin(4).Get(), qt.IsFalse)
+}
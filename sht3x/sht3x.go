@@ -37,20 +37,20 @@ func (d *Device) ReadTemperature() (tempMilliCelsius int32, err error) {
 }
 
 // Read returns the relative humidity in hundredths of a percent.
-func (d *Device) ReadHumidity() (relativeHumidity int16, err error) {
+func (d *Device) ReadHumidity() (relativeHumidity int32, err error) {
 	_, relativeHumidity, err = d.ReadTemperatureHumidity()
 	return relativeHumidity, err
 }
 
 // Read returns both the temperature and relative humidity.
-func (d *Device) ReadTemperatureHumidity() (tempMilliCelsius int32, relativeHumidity int16, err error) {
+func (d *Device) ReadTemperatureHumidity() (tempMilliCelsius int32, relativeHumidity int32, err error) {
 	var rawTemp, rawHum, errx = d.rawReadings()
 	if errx != nil {
 		err = errx
 		return
 	}
 	tempMilliCelsius = (35000 * int32(rawTemp) / 13107) - 45000
-	relativeHumidity = int16(2000 * int32(rawHum) / 13107)
+	relativeHumidity = 2000 * int32(rawHum) / 13107
 	return tempMilliCelsius, relativeHumidity, err
 }
 
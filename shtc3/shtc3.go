@@ -14,7 +14,8 @@ import (
 
 // Device wraps an I2C connection to a SHT31 device.
 type Device struct {
-	bus drivers.I2C
+	bus      drivers.I2C
+	sleeping bool
 }
 
 // New creates a new SHTC3 connection. The I2C bus must already be
@@ -35,20 +36,20 @@ func (d *Device) ReadTemperature() (tempMilliCelsius int32, err error) {
 }
 
 // Read returns the relative humidity in hundredths of a percent.
-func (d *Device) ReadHumidity() (relativeHumidity int16, err error) {
+func (d *Device) ReadHumidity() (relativeHumidity int32, err error) {
 	_, relativeHumidity, err = d.ReadTemperatureHumidity()
 	return relativeHumidity, err
 }
 
 // Read returns both the temperature and relative humidity.
-func (d *Device) ReadTemperatureHumidity() (tempMilliCelsius int32, relativeHumidity int16, err error) {
+func (d *Device) ReadTemperatureHumidity() (tempMilliCelsius int32, relativeHumidity int32, err error) {
 	var rawTemp, rawHum, errx = d.rawReadings()
 	if errx != nil {
 		err = errx
 		return
 	}
 	tempMilliCelsius = ((21875 * int32(rawTemp)) >> 13) - 45000
-	relativeHumidity = int16((1250 * int32(rawHum)) >> 13)
+	relativeHumidity = (1250 * int32(rawHum)) >> 13
 	return tempMilliCelsius, relativeHumidity, err
 }
 
@@ -62,17 +63,36 @@ func (d *Device) rawReadings() (uint16, uint16, error) {
 
 // WakeUp makes device leave sleep mode
 func (d *Device) WakeUp() error {
-	d.bus.Tx(SHTC3_ADDRESS, []byte(SHTC3_CMD_WAKEUP), nil)
+	err := d.bus.Tx(SHTC3_ADDRESS, []byte(SHTC3_CMD_WAKEUP), nil)
+	if err != nil {
+		return drivers.WrapError("shtc3: wake up", err)
+	}
+	d.sleeping = false
 	time.Sleep(1 * time.Millisecond)
 	return nil
 }
 
+// Wake makes device leave sleep mode. It is the same as WakeUp, and
+// implements drivers.Sleeper.
+func (d *Device) Wake() error {
+	return d.WakeUp()
+}
+
 // Sleep makes device go to sleep
 func (d *Device) Sleep() error {
-	d.bus.Tx(SHTC3_ADDRESS, []byte(SHTC3_CMD_SLEEP), nil)
+	err := d.bus.Tx(SHTC3_ADDRESS, []byte(SHTC3_CMD_SLEEP), nil)
+	if err != nil {
+		return drivers.WrapError("shtc3: sleep", err)
+	}
+	d.sleeping = true
 	return nil
 }
 
+// Sleeping returns whether the device has been put to sleep.
+func (d *Device) Sleeping() bool {
+	return d.sleeping
+}
+
 // readUint converts two bytes to uint16
 func readUint(msb byte, lsb byte) uint16 {
 	return (uint16(msb) << 8) | uint16(lsb)
@@ -0,0 +1,113 @@
+// Package softpwm generates a PWM signal on any digital pin in software, for
+// pins that are not connected to a PWM peripheral or when all of them are in
+// use.
+//
+// The signal is generated by a goroutine that sleeps between the edges, so
+// its precision depends on the resolution of the sleep of the chip and on the
+// other goroutines. It is good enough for servos, buzzers and LEDs, but
+// should not be used where timing is critical.
+//
+//	pwm := softpwm.New(machine.D2)
+//	go pwm.Run()
+//	s, err := servo.New(pwm)
+package softpwm // import "tinygo.org/x/drivers/softpwm"
+
+import (
+	"sync/atomic"
+	"time"
+
+	"tinygo.org/x/drivers"
+)
+
+// top is the value for a 100% duty cycle: the duty cycle is set in steps of
+// 0.1%.
+const top = 1000
+
+// defaultPeriod is a period that works well for LEDs.
+const defaultPeriod = 10 * time.Millisecond
+
+// PWM is a software PWM on a pin. It implements drivers.PWM.
+//
+// The period, duty cycle and stop request are accessed atomically, as they
+// are usually changed from another goroutine than the one running Run.
+type PWM struct {
+	period  int64 // first for the alignment of 64 bit atomics
+	value   uint32
+	stopped uint32
+	pin     drivers.Pin
+
+	// Clock is used to wait between the edges of the signal. It is
+	// drivers.SystemClock by default.
+	Clock drivers.Clock
+}
+
+// New returns a software PWM on the given pin, with a period of 10ms and a
+// duty cycle of 0. It configures the pin as an output. The signal is only
+// generated while Run is running.
+func New(pin drivers.Pin) *PWM {
+	drivers.ConfigurePin(pin, drivers.PinOutput)
+	pin.Low()
+	return &PWM{
+		pin:    pin,
+		period: int64(defaultPeriod),
+		Clock:  drivers.SystemClock,
+	}
+}
+
+// SetPeriod sets the period of the signal in nanoseconds. It implements
+// drivers.PWM.
+func (p *PWM) SetPeriod(period uint64) error {
+	if period == 0 {
+		period = uint64(defaultPeriod)
+	}
+	atomic.StoreInt64(&p.period, int64(period))
+	return nil
+}
+
+// Top returns the value for a 100% duty cycle, which is 1000. It implements
+// drivers.PWM.
+func (p *PWM) Top() uint32 {
+	return top
+}
+
+// Set sets the duty cycle to value/1000. It implements drivers.PWM.
+func (p *PWM) Set(value uint32) error {
+	if value > top {
+		value = top
+	}
+	atomic.StoreUint32(&p.value, value)
+	return nil
+}
+
+// Run generates the signal until Stop is called, and leaves the pin low. It
+// is usually started in its own goroutine. If Stop was called before Run,
+// Run returns at once. Run can be called again after it returned.
+func (p *PWM) Run() {
+	// Returning consumes the stop request, so that the next Run is not
+	// stopped by it.
+	for !atomic.CompareAndSwapUint32(&p.stopped, 1, 0) {
+		p.Cycle()
+	}
+	p.pin.Low()
+}
+
+// Stop makes Run return at the end of the current period.
+func (p *PWM) Stop() {
+	atomic.StoreUint32(&p.stopped, 1)
+}
+
+// Cycle generates a single period of the signal. Run calls it in a loop; it
+// can also be called directly from a loop that has other work to do.
+func (p *PWM) Cycle() {
+	period := time.Duration(atomic.LoadInt64(&p.period))
+	value := atomic.LoadUint32(&p.value)
+	high := period * time.Duration(value) / top
+	if high > 0 {
+		p.pin.High()
+		p.Clock.Sleep(high)
+	}
+	if high < period {
+		p.pin.Low()
+		p.Clock.Sleep(period - high)
+	}
+}
@@ -0,0 +1,83 @@
+package softpwm
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"tinygo.org/x/drivers"
+	"tinygo.org/x/drivers/tester"
+)
+
+var _ drivers.PWM = (*PWM)(nil)
+
+func TestCycle(t *testing.T) {
+	c := qt.New(t)
+	pin := tester.NewPin()
+	clock := tester.NewClock()
+	p := New(pin)
+	p.Clock = clock
+	c.Assert(pin.Mode(), qt.Equals, drivers.PinOutput)
+
+	c.Assert(p.SetPeriod(uint64(20*time.Millisecond)), qt.IsNil)
+	c.Assert(p.Set(p.Top()/4), qt.IsNil)
+	pin.Reset()
+	p.Cycle()
+	c.Assert(pin.Levels(), qt.DeepEquals, []bool{true, false})
+	c.Assert(clock.Sleeps, qt.DeepEquals, []time.Duration{5 * time.Millisecond, 15 * time.Millisecond})
+}
+
+func TestCycleFull(t *testing.T) {
+	c := qt.New(t)
+	pin := tester.NewPin()
+	clock := tester.NewClock()
+	p := New(pin)
+	p.Clock = clock
+
+	// Values above Top are clamped to an always high output.
+	c.Assert(p.Set(p.Top()+1), qt.IsNil)
+	pin.Reset()
+	p.Cycle()
+	c.Assert(pin.Levels(), qt.DeepEquals, []bool{true})
+	c.Assert(clock.Slept(), qt.Equals, defaultPeriod)
+
+	c.Assert(p.Set(0), qt.IsNil)
+	pin.Reset()
+	p.Cycle()
+	c.Assert(pin.Levels(), qt.DeepEquals, []bool{false})
+}
+
+func TestRunStop(t *testing.T) {
+	c := qt.New(t)
+	pin := tester.NewPin()
+	clock := tester.NewClock()
+	p := New(pin)
+	p.Clock = clock
+	p.Set(p.Top() / 2)
+
+	// Stop from within the clock, as another goroutine would.
+	clock.At(25*time.Millisecond, p.Stop)
+	p.Run()
+	c.Assert(clock.Slept(), qt.Equals, 30*time.Millisecond)
+	c.Assert(pin.Get(), qt.IsFalse)
+}
+
+func TestStopBeforeRun(t *testing.T) {
+	c := qt.New(t)
+	pin := tester.NewPin()
+	clock := tester.NewClock()
+	p := New(pin)
+	p.Clock = clock
+	p.Set(p.Top() / 2)
+
+	// A Stop that happens before Run starts is not lost.
+	p.Stop()
+	p.Run()
+	c.Assert(clock.Slept(), qt.Equals, time.Duration(0))
+
+	// It only stops that Run.
+	clock.At(5*time.Millisecond, p.Stop)
+	p.Run()
+	c.Assert(clock.Slept(), qt.Equals, 10*time.Millisecond)
+}
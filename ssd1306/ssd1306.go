@@ -7,7 +7,6 @@ package ssd1306 // import "tinygo.org/x/drivers/ssd1306"
 import (
 	"errors"
 	"image/color"
-	"machine"
 	"time"
 
 	"tinygo.org/x/drivers"
@@ -22,6 +21,7 @@ type Device struct {
 	bufferSize int16
 	vccState   VccMode
 	canReset   bool
+	sleeping   bool
 }
 
 // Config is the configuration for the display
@@ -39,9 +39,9 @@ type I2CBus struct {
 
 type SPIBus struct {
 	wire     drivers.SPI
-	dcPin    machine.Pin
-	resetPin machine.Pin
-	csPin    machine.Pin
+	dcPin    drivers.Pin
+	resetPin drivers.Pin
+	csPin    drivers.Pin
 }
 
 type Buser interface {
@@ -63,10 +63,10 @@ func NewI2C(bus drivers.I2C) Device {
 }
 
 // NewSPI creates a new SSD1306 connection. The SPI wire must already be configured.
-func NewSPI(bus drivers.SPI, dcPin, resetPin, csPin machine.Pin) Device {
-	dcPin.Configure(machine.PinConfig{Mode: machine.PinOutput})
-	resetPin.Configure(machine.PinConfig{Mode: machine.PinOutput})
-	csPin.Configure(machine.PinConfig{Mode: machine.PinOutput})
+func NewSPI(bus drivers.SPI, dcPin, resetPin, csPin drivers.Pin) Device {
+	drivers.ConfigurePin(dcPin, drivers.PinOutput)
+	drivers.ConfigurePin(resetPin, drivers.PinOutput)
+	drivers.ConfigurePin(csPin, drivers.PinOutput)
 	return Device{
 		bus: &SPIBus{
 			wire:     bus,
@@ -304,3 +304,23 @@ func (b *SPIBus) tx(data []byte, isCommand bool) {
 func (d *Device) Size() (w, h int16) {
 	return d.width, d.height
 }
+
+// Sleep turns the display off, putting the controller in sleep mode. The
+// content of the display memory is kept. It implements drivers.Sleeper.
+func (d *Device) Sleep() error {
+	d.Command(DISPLAYOFF)
+	d.sleeping = true
+	return nil
+}
+
+// Wake turns the display back on. It implements drivers.Sleeper.
+func (d *Device) Wake() error {
+	d.Command(DISPLAYON)
+	d.sleeping = false
+	return nil
+}
+
+// Sleeping returns whether the display has been put to sleep.
+func (d *Device) Sleeping() bool {
+	return d.sleeping
+}
@@ -6,41 +6,48 @@ package ssd1331 // import "tinygo.org/x/drivers/ssd1331"
 
 import (
 	"image/color"
-	"machine"
-
-	"errors"
 	"time"
 
 	"tinygo.org/x/drivers"
+	"tinygo.org/x/drivers/pixel"
 )
 
 type Model uint8
-type Rotation uint8
+type Rotation = drivers.Rotation
+
+// remaps holds the value of the remap register (SETREMAP) for each
+// rotation: 65k colors with COM split (0x60), plus the column address remap
+// (0x02) and COM scan remap (0x10) that orient the panel. The rotations by
+// 90 and 270 degrees increment the RAM address vertically (0x01).
+var remaps = [4]uint8{0x72, 0x63, 0x60, 0x71}
 
 // Device wraps an SPI connection.
 type Device struct {
 	bus         drivers.SPI
-	dcPin       machine.Pin
-	resetPin    machine.Pin
-	csPin       machine.Pin
+	dcPin       drivers.Pin
+	resetPin    drivers.Pin
+	csPin       drivers.Pin
 	width       int16
 	height      int16
 	batchLength int16
 	isBGR       bool
 	batchData   []uint8
+	rotation    Rotation
+	sleeping    bool
 }
 
 // Config is the configuration for the display
 type Config struct {
-	Width  int16
-	Height int16
+	Width    int16
+	Height   int16
+	Rotation Rotation
 }
 
 // New creates a new SSD1331 connection. The SPI wire must already be configured.
-func New(bus drivers.SPI, resetPin, dcPin, csPin machine.Pin) Device {
-	dcPin.Configure(machine.PinConfig{Mode: machine.PinOutput})
-	resetPin.Configure(machine.PinConfig{Mode: machine.PinOutput})
-	csPin.Configure(machine.PinConfig{Mode: machine.PinOutput})
+func New(bus drivers.SPI, resetPin, dcPin, csPin drivers.Pin) Device {
+	drivers.ConfigurePin(dcPin, drivers.PinOutput)
+	drivers.ConfigurePin(resetPin, drivers.PinOutput)
+	drivers.ConfigurePin(csPin, drivers.PinOutput)
 	return Device{
 		bus:      bus,
 		dcPin:    dcPin,
@@ -79,8 +86,9 @@ func (d *Device) Configure(cfg Config) {
 
 	// Initialization
 	d.Command(DISPLAYOFF)
+	d.rotation = cfg.Rotation % 4
 	d.Command(SETREMAP)
-	d.Command(0x72) // RGB
+	d.Command(remaps[d.rotation]) // RGB
 	//d.Command(0x76) // BGR
 	d.Command(STARTLINE)
 	d.Command(0x0)
@@ -125,7 +133,8 @@ func (d *Device) Display() error {
 
 // SetPixel sets a pixel in the screen
 func (d *Device) SetPixel(x int16, y int16, c color.RGBA) {
-	if x < 0 || y < 0 || x >= d.width || y >= d.height {
+	w, h := d.Size()
+	if x < 0 || y < 0 || x >= w || y >= h {
 		return
 	}
 	d.FillRectangle(x, y, 1, 1, c)
@@ -133,6 +142,11 @@ func (d *Device) SetPixel(x int16, y int16, c color.RGBA) {
 
 // setWindow prepares the screen to be modified at a given rectangle
 func (d *Device) setWindow(x, y, w, h int16) {
+	if d.rotation == drivers.Rotation90 || d.rotation == drivers.Rotation270 {
+		// The column and row addresses are not swapped by the remap
+		// register, only the direction in which the RAM is written.
+		x, y, w, h = y, x, h, w
+	}
 	/*d.Tx([]uint8{SETCOLUMN}, true)
 	d.Tx([]uint8{uint8(x), uint8(x + w - 1)}, false)
 	d.Tx([]uint8{SETROW}, true)
@@ -147,12 +161,11 @@ func (d *Device) setWindow(x, y, w, h int16) {
 
 // FillRectangle fills a rectangle at a given coordinates with a color
 func (d *Device) FillRectangle(x, y, width, height int16, c color.RGBA) error {
-	if x < 0 || y < 0 || width <= 0 || height <= 0 ||
-		x >= d.width || (x+width) > d.width || y >= d.height || (y+height) > d.height {
-		return errors.New("rectangle coordinates outside display area")
+	if !d.inBounds(x, y, width, height) {
+		return drivers.ErrOutOfBounds
 	}
 	d.setWindow(x, y, width, height)
-	c565 := RGBATo565(c)
+	c565 := pixel.RGB565(c)
 	c1 := uint8(c565 >> 8)
 	c2 := uint8(c565)
 
@@ -175,59 +188,136 @@ func (d *Device) FillRectangle(x, y, width, height int16, c color.RGBA) error {
 
 // FillRectangle fills a rectangle at a given coordinates with a buffer
 func (d *Device) FillRectangleWithBuffer(x, y, width, height int16, buffer []color.RGBA) error {
-	if x < 0 || y < 0 || width <= 0 || height <= 0 ||
-		x >= d.width || (x+width) > d.width || y >= d.height || (y+height) > d.height {
-		return errors.New("rectangle coordinates outside display area")
+	if !d.inBounds(x, y, width, height) {
+		return drivers.ErrOutOfBounds
 	}
 	k := width * height
 	l := int16(len(buffer))
 	if k != l {
-		return errors.New("buffer length does not match with rectangle size")
+		return drivers.ErrBufferSize
 	}
 
 	d.setWindow(x, y, width, height)
 
-	offset := int16(0)
-	for k > 0 {
-		for i := int16(0); i < d.batchLength; i++ {
-			if offset+i < l {
-				c565 := RGBATo565(buffer[offset+i])
-				c1 := uint8(c565 >> 8)
-				c2 := uint8(c565)
-				d.batchData[i*2] = c1
-				d.batchData[i*2+1] = c2
-			}
+	for len(buffer) > 0 {
+		n := pixel.ToRGB565Bytes(d.batchData, buffer)
+		d.Tx(d.batchData[:n*2], false)
+		buffer = buffer[n:]
+	}
+	return nil
+}
+
+// DrawRGBBitmap copies an RGB565 bitmap of w*h pixels to the display at
+// given coordinates.
+func (d *Device) DrawRGBBitmap(x, y int16, data []uint16, w, h int16) error {
+	if !d.inBounds(x, y, w, h) {
+		return drivers.ErrOutOfBounds
+	}
+	if int32(w)*int32(h) != int32(len(data)) {
+		return drivers.ErrBufferSize
+	}
+	d.setWindow(x, y, w, h)
+
+	for len(data) > 0 {
+		n := int32(len(data))
+		if n > int32(d.batchLength) {
+			n = int32(d.batchLength)
 		}
-		if k >= d.batchLength {
-			d.Tx(d.batchData, false)
-		} else {
-			d.Tx(d.batchData[:k*2], false)
+		for i, c := range data[:n] {
+			d.batchData[i*2] = uint8(c >> 8)
+			d.batchData[i*2+1] = uint8(c)
 		}
-		k -= d.batchLength
-		offset += d.batchLength
+		d.Tx(d.batchData[:n*2], false)
+		data = data[n:]
 	}
 	return nil
 }
 
+// DrawRGBBitmap8 copies an RGB565 bitmap of w*h pixels, stored as 2 bytes per
+// pixel with the most significant byte first, to the display at given
+// coordinates.
+func (d *Device) DrawRGBBitmap8(x, y int16, data []uint8, w, h int16) error {
+	if !d.inBounds(x, y, w, h) {
+		return drivers.ErrOutOfBounds
+	}
+	if int32(w)*int32(h)*2 != int32(len(data)) {
+		return drivers.ErrBufferSize
+	}
+	d.setWindow(x, y, w, h)
+	d.Tx(data, false)
+	return nil
+}
+
 // DrawFastVLine draws a vertical line faster than using SetPixel
-func (d *Device) DrawFastVLine(x, y0, y1 int16, c color.RGBA) {
+func (d *Device) DrawFastVLine(x, y0, y1 int16, c color.RGBA) error {
 	if y0 > y1 {
 		y0, y1 = y1, y0
 	}
-	d.FillRectangle(x, y0, 1, y1-y0+1, c)
+	return d.FillRectangle(x, y0, 1, y1-y0+1, c)
 }
 
 // DrawFastHLine draws a horizontal line faster than using SetPixel
-func (d *Device) DrawFastHLine(x0, x1, y int16, c color.RGBA) {
+func (d *Device) DrawFastHLine(x0, x1, y int16, c color.RGBA) error {
 	if x0 > x1 {
 		x0, x1 = x1, x0
 	}
-	d.FillRectangle(x0, y, x1-x0+1, 1, c)
+	return d.FillRectangle(x0, y, x1-x0+1, 1, c)
 }
 
 // FillScreen fills the screen with a given color
-func (d *Device) FillScreen(c color.RGBA) {
-	d.FillRectangle(0, 0, d.width, d.height, c)
+func (d *Device) FillScreen(c color.RGBA) error {
+	w, h := d.Size()
+	return d.FillRectangle(0, 0, w, h, c)
+}
+
+// inBounds returns whether a rectangle is entirely on the display.
+func (d *Device) inBounds(x, y, w, h int16) bool {
+	k, l := d.Size()
+	return x >= 0 && y >= 0 && w > 0 && h > 0 && x+w <= k && y+h <= l
+}
+
+// Rotation returns the current rotation of the device.
+func (d *Device) Rotation() Rotation {
+	return d.rotation
+}
+
+// SetRotation changes the rotation of the device (clock-wise).
+func (d *Device) SetRotation(rotation Rotation) error {
+	if rotation > drivers.Rotation270 {
+		return drivers.ErrInvalidConfig
+	}
+	d.Command(SETREMAP)
+	d.Command(remaps[rotation])
+	d.rotation = rotation
+	return nil
+}
+
+// SetScrollArea sets an area to scroll with fixed top and bottom parts of
+// the display. The SSD1331 can only scroll the whole display this way, so
+// both fixed areas must be 0.
+func (d *Device) SetScrollArea(topFixedArea, bottomFixedArea int16) error {
+	if topFixedArea != 0 || bottomFixedArea != 0 {
+		return drivers.ErrInvalidConfig
+	}
+	return nil
+}
+
+// SetScroll sets the display start line, which scrolls the display
+// vertically. The line wraps around the height of the display, so that
+// negative lines scroll down. It fails before Configure.
+func (d *Device) SetScroll(line int16) error {
+	h := d.height
+	if h == 0 {
+		return drivers.ErrInvalidConfig
+	}
+	d.Command(STARTLINE)
+	d.Command(uint8(((line % h) + h) % h))
+	return nil
+}
+
+// StopScroll returns the display to its normal state.
+func (d *Device) StopScroll() error {
+	return d.SetScroll(0)
 }
 
 // SetContrast sets the three contrast values (A, B & C)
@@ -258,6 +348,9 @@ func (d *Device) Tx(data []byte, isCommand bool) {
 
 // Size returns the current size of the display.
 func (d *Device) Size() (w, h int16) {
+	if d.rotation == drivers.Rotation90 || d.rotation == drivers.Rotation270 {
+		return d.height, d.width
+	}
 	return d.width, d.height
 }
 
@@ -267,9 +360,28 @@ func (d *Device) IsBGR(bgr bool) {
 }
 
 // RGBATo565 converts a color.RGBA to uint16 used in the display
+//
+// Deprecated: use pixel.RGB565.
 func RGBATo565(c color.RGBA) uint16 {
-	r, g, b, _ := c.RGBA()
-	return uint16((r & 0xF800) +
-		((g & 0xFC00) >> 5) +
-		((b & 0xF800) >> 11))
+	return pixel.RGB565(c)
+}
+
+// Sleep turns the display off, putting the controller in sleep mode. The
+// content of the display memory is kept. It implements drivers.Sleeper.
+func (d *Device) Sleep() error {
+	d.Command(DISPLAYOFF)
+	d.sleeping = true
+	return nil
+}
+
+// Wake turns the display back on. It implements drivers.Sleeper.
+func (d *Device) Wake() error {
+	d.Command(DISPLAYON)
+	d.sleeping = false
+	return nil
+}
+
+// Sleeping returns whether the display has been put to sleep.
+func (d *Device) Sleeping() bool {
+	return d.sleeping
 }
@@ -0,0 +1,53 @@
+package ssd1331
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"tinygo.org/x/drivers"
+	"tinygo.org/x/drivers/tester"
+)
+
+func TestSetRotation(t *testing.T) {
+	c := qt.New(t)
+	for rotation, remap := range []byte{0x72, 0x63, 0x60, 0x71} {
+		bus := tester.NewSPIBus(c)
+		dc := tester.NewPin()
+		d := New(bus, tester.NewPin(), dc, tester.NewPin())
+		d.width, d.height = 96, 64
+
+		// The remap value is sent as a command, like every parameter of
+		// the SSD1331.
+		bus.Expect([]byte{SETREMAP}, nil)
+		bus.Expect([]byte{remap}, nil)
+		c.Assert(d.SetRotation(drivers.Rotation(rotation)), qt.IsNil)
+		bus.AssertDone()
+		c.Assert(dc.Levels(), qt.DeepEquals, []bool{false, false})
+		c.Assert(d.Rotation(), qt.Equals, drivers.Rotation(rotation))
+
+		w, h := d.Size()
+		if rotation%2 == 1 {
+			c.Assert([]int16{w, h}, qt.DeepEquals, []int16{64, 96})
+		} else {
+			c.Assert([]int16{w, h}, qt.DeepEquals, []int16{96, 64})
+		}
+	}
+
+	d := New(tester.NewSPIBus(c), tester.NewPin(), tester.NewPin(), tester.NewPin())
+	c.Assert(d.SetRotation(4), qt.Equals, drivers.ErrInvalidConfig)
+}
+
+func TestSetScroll(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewSPIBus(c)
+	d := New(bus, tester.NewPin(), tester.NewPin(), tester.NewPin())
+	c.Assert(d.SetScroll(10), qt.Equals, drivers.ErrInvalidConfig)
+
+	d.width, d.height = 96, 64
+	for line, start := range map[int16]byte{10: 10, 70: 6, -10: 54} {
+		bus.Expect([]byte{STARTLINE}, nil)
+		bus.Expect([]byte{start}, nil)
+		c.Assert(d.SetScroll(line), qt.IsNil)
+		bus.AssertDone()
+	}
+}
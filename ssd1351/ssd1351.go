@@ -5,32 +5,44 @@
 package ssd1351 // import "tinygo.org/x/drivers/ssd1351"
 
 import (
-	"errors"
 	"image/color"
-	"machine"
 	"time"
 
 	"tinygo.org/x/drivers"
+	"tinygo.org/x/drivers/pixel"
 )
 
-var (
-	errDrawingOutOfBounds = errors.New("rectangle coordinates outside display area")
-	errBufferSizeMismatch = errors.New("buffer length does not match with rectangle size")
-)
+// Rotation controls the rotation used by the display.
+type Rotation = drivers.Rotation
+
+// remaps holds the value of the remap and color depth register
+// (SET_REMAP_COLORDEPTH) for each rotation. The default orientation 0x72
+// selects 65k colors with COM split, the COM scan remap (0x10) and the
+// column address remap (0x02). The other rotations toggle these remaps, and
+// the rotations by 90 and 270 degrees increment the RAM address vertically
+// (0x01).
+var remaps = [4]uint8{
+	0x72,
+	0x72 ^ 0x03,
+	0x72 ^ 0x12,
+	0x72 ^ 0x11,
+}
 
 // Device wraps an SPI connection.
 type Device struct {
 	bus          drivers.SPI
-	dcPin        machine.Pin
-	resetPin     machine.Pin
-	csPin        machine.Pin
-	enPin        machine.Pin
-	rwPin        machine.Pin
+	dcPin        drivers.Pin
+	resetPin     drivers.Pin
+	csPin        drivers.Pin
+	enPin        drivers.Pin
+	rwPin        drivers.Pin
 	width        int16
 	height       int16
 	rowOffset    int16
 	columnOffset int16
 	bufferLength int16
+	rotation     Rotation
+	sleeping     bool
 }
 
 // Config is the configuration for the display
@@ -39,10 +51,11 @@ type Config struct {
 	Height       int16
 	RowOffset    int16
 	ColumnOffset int16
+	Rotation     Rotation
 }
 
 // New creates a new SSD1351 connection. The SPI wire must already be configured.
-func New(bus drivers.SPI, resetPin, dcPin, csPin, enPin, rwPin machine.Pin) Device {
+func New(bus drivers.SPI, resetPin, dcPin, csPin, enPin, rwPin drivers.Pin) Device {
 	return Device{
 		bus:      bus,
 		dcPin:    dcPin,
@@ -74,11 +87,11 @@ func (d *Device) Configure(cfg Config) {
 	}
 
 	// configure GPIO pins
-	d.dcPin.Configure(machine.PinConfig{Mode: machine.PinOutput})
-	d.resetPin.Configure(machine.PinConfig{Mode: machine.PinOutput})
-	d.csPin.Configure(machine.PinConfig{Mode: machine.PinOutput})
-	d.enPin.Configure(machine.PinConfig{Mode: machine.PinOutput})
-	d.rwPin.Configure(machine.PinConfig{Mode: machine.PinOutput})
+	drivers.ConfigurePin(d.dcPin, drivers.PinOutput)
+	drivers.ConfigurePin(d.resetPin, drivers.PinOutput)
+	drivers.ConfigurePin(d.csPin, drivers.PinOutput)
+	drivers.ConfigurePin(d.enPin, drivers.PinOutput)
+	drivers.ConfigurePin(d.rwPin, drivers.PinOutput)
 
 	// reset the device
 	d.resetPin.High()
@@ -103,7 +116,8 @@ func (d *Device) Configure(cfg Config) {
 	d.Command(SET_MUX_RATIO)
 	d.Data(0x7F)
 	d.Command(SET_REMAP_COLORDEPTH)
-	d.Data(0x72)
+	d.Data(remaps[cfg.Rotation%4])
+	d.rotation = cfg.Rotation % 4
 	d.Command(SET_COLUMN_ADDRESS)
 	d.Data(0x00)
 	d.Data(0x7F)
@@ -148,7 +162,8 @@ func (d *Device) Display() error {
 
 // SetPixel sets a pixel in the buffer
 func (d *Device) SetPixel(x int16, y int16, c color.RGBA) {
-	if x < 0 || y < 0 || x >= d.width || y >= d.height {
+	w, h := d.Size()
+	if x < 0 || y < 0 || x >= w || y >= h {
 		return
 	}
 	d.FillRectangle(x, y, 1, 1, c)
@@ -156,6 +171,11 @@ func (d *Device) SetPixel(x int16, y int16, c color.RGBA) {
 
 // setWindow prepares the screen memory to be modified at given coordinates
 func (d *Device) setWindow(x, y, w, h int16) {
+	if d.rotation == drivers.Rotation90 || d.rotation == drivers.Rotation270 {
+		// The column and row addresses are not swapped by the remap
+		// register, only the direction in which the RAM is written.
+		x, y, w, h = y, x, h, w
+	}
 	x += d.columnOffset
 	y += d.rowOffset
 	d.Command(SET_COLUMN_ADDRESS)
@@ -167,12 +187,11 @@ func (d *Device) setWindow(x, y, w, h int16) {
 
 // FillRectangle fills a rectangle at given coordinates with a color
 func (d *Device) FillRectangle(x, y, width, height int16, c color.RGBA) error {
-	if x < 0 || y < 0 || width <= 0 || height <= 0 ||
-		x >= d.width || (x+width) > d.width || y >= d.height || (y+height) > d.height {
-		return errDrawingOutOfBounds
+	if !d.inBounds(x, y, width, height) {
+		return drivers.ErrOutOfBounds
 	}
 	d.setWindow(x, y, width, height)
-	c565 := RGBATo565(c)
+	c565 := pixel.RGB565(c)
 	c1 := uint8(c565 >> 8)
 	c2 := uint8(c565)
 
@@ -200,14 +219,13 @@ func (d *Device) FillRectangle(x, y, width, height int16, c color.RGBA) error {
 
 // FillRectangleWithBuffer fills a rectangle at given coordinates with a buffer
 func (d *Device) FillRectangleWithBuffer(x, y, width, height int16, buffer []color.RGBA) error {
-	if x < 0 || y < 0 || width <= 0 || height <= 0 ||
-		x >= d.width || (x+width) > d.width || y >= d.height || (y+height) > d.height {
-		return errDrawingOutOfBounds
+	if !d.inBounds(x, y, width, height) {
+		return drivers.ErrOutOfBounds
 	}
 	dim := int16(width * height)
 	l := int16(len(buffer))
 	if dim != l {
-		return errBufferSizeMismatch
+		return drivers.ErrBufferSize
 	}
 
 	d.setWindow(x, y, width, height)
@@ -218,47 +236,126 @@ func (d *Device) FillRectangleWithBuffer(x, y, width, height int16, buffer []col
 	}
 	data := make([]uint8, bl*2)
 
-	offset := int16(0)
-	for dim > 0 {
-		for i := int16(0); i < bl; i++ {
-			if offset+i < l {
-				c565 := RGBATo565(buffer[offset+i])
-				c1 := uint8(c565 >> 8)
-				c2 := uint8(c565)
-				data[i*2] = c1
-				data[i*2+1] = c2
-			}
+	for len(buffer) > 0 {
+		n := pixel.ToRGB565Bytes(data, buffer)
+		d.Tx(data[:n*2], false)
+		buffer = buffer[n:]
+	}
+	return nil
+}
+
+// DrawRGBBitmap copies an RGB565 bitmap of w*h pixels to the display at
+// given coordinates.
+func (d *Device) DrawRGBBitmap(x, y int16, data []uint16, w, h int16) error {
+	if !d.inBounds(x, y, w, h) {
+		return drivers.ErrOutOfBounds
+	}
+	if int32(w)*int32(h) != int32(len(data)) {
+		return drivers.ErrBufferSize
+	}
+	d.setWindow(x, y, w, h)
+
+	buf := make([]uint8, d.bufferLength*2)
+	for len(data) > 0 {
+		n := int32(len(data))
+		if n > int32(d.bufferLength) {
+			n = int32(d.bufferLength)
 		}
-		if dim >= d.bufferLength {
-			d.Tx(data, false)
-		} else {
-			d.Tx(data[:dim*2], false)
+		for i, c := range data[:n] {
+			buf[i*2] = uint8(c >> 8)
+			buf[i*2+1] = uint8(c)
 		}
-		dim -= d.bufferLength
-		offset += d.bufferLength
+		d.Tx(buf[:n*2], false)
+		data = data[n:]
+	}
+	return nil
+}
+
+// DrawRGBBitmap8 copies an RGB565 bitmap of w*h pixels, stored as 2 bytes per
+// pixel with the most significant byte first, to the display at given
+// coordinates.
+func (d *Device) DrawRGBBitmap8(x, y int16, data []uint8, w, h int16) error {
+	if !d.inBounds(x, y, w, h) {
+		return drivers.ErrOutOfBounds
+	}
+	if int32(w)*int32(h)*2 != int32(len(data)) {
+		return drivers.ErrBufferSize
 	}
+	d.setWindow(x, y, w, h)
+	d.Tx(data, false)
 	return nil
 }
 
 // DrawFastVLine draws a vertical line faster than using SetPixel
-func (d *Device) DrawFastVLine(x, y0, y1 int16, c color.RGBA) {
+func (d *Device) DrawFastVLine(x, y0, y1 int16, c color.RGBA) error {
 	if y0 > y1 {
 		y0, y1 = y1, y0
 	}
-	d.FillRectangle(x, y0, 1, y1-y0+1, c)
+	return d.FillRectangle(x, y0, 1, y1-y0+1, c)
 }
 
 // DrawFastHLine draws a horizontal line faster than using SetPixel
-func (d *Device) DrawFastHLine(x0, x1, y int16, c color.RGBA) {
+func (d *Device) DrawFastHLine(x0, x1, y int16, c color.RGBA) error {
 	if x0 > x1 {
 		x0, x1 = x1, x0
 	}
-	d.FillRectangle(x0, y, x1-x0+1, 1, c)
+	return d.FillRectangle(x0, y, x1-x0+1, 1, c)
 }
 
 // FillScreen fills the screen with a given color
-func (d *Device) FillScreen(c color.RGBA) {
-	d.FillRectangle(0, 0, d.width, d.height, c)
+func (d *Device) FillScreen(c color.RGBA) error {
+	w, h := d.Size()
+	return d.FillRectangle(0, 0, w, h, c)
+}
+
+// inBounds returns whether a rectangle is entirely on the display.
+func (d *Device) inBounds(x, y, w, h int16) bool {
+	k, l := d.Size()
+	return x >= 0 && y >= 0 && w > 0 && h > 0 && x+w <= k && y+h <= l
+}
+
+// Rotation returns the current rotation of the device.
+func (d *Device) Rotation() Rotation {
+	return d.rotation
+}
+
+// SetRotation changes the rotation of the device (clock-wise).
+func (d *Device) SetRotation(rotation Rotation) error {
+	if rotation > drivers.Rotation270 {
+		return drivers.ErrInvalidConfig
+	}
+	d.Command(SET_REMAP_COLORDEPTH)
+	d.Data(remaps[rotation])
+	d.rotation = rotation
+	return nil
+}
+
+// SetScrollArea sets an area to scroll with fixed top and bottom parts of
+// the display. The SSD1351 can only scroll the whole display, so both fixed
+// areas must be 0.
+func (d *Device) SetScrollArea(topFixedArea, bottomFixedArea int16) error {
+	if topFixedArea != 0 || bottomFixedArea != 0 {
+		return drivers.ErrInvalidConfig
+	}
+	return nil
+}
+
+// SetScroll sets the display start line, which scrolls the display
+// vertically. The line wraps around the height of the display, so that
+// negative lines scroll down. It fails before Configure.
+func (d *Device) SetScroll(line int16) error {
+	h := d.height
+	if h == 0 {
+		return drivers.ErrInvalidConfig
+	}
+	d.Command(SET_DISPLAY_START_LINE)
+	d.Data(uint8(((line % h) + h) % h))
+	return nil
+}
+
+// StopScroll returns the display to its normal state.
+func (d *Device) StopScroll() error {
+	return d.SetScroll(0)
 }
 
 // SetContrast sets the three contrast values (A, B & C)
@@ -287,13 +384,35 @@ func (d *Device) Tx(data []byte, isCommand bool) {
 
 // Size returns the current size of the display
 func (d *Device) Size() (w, h int16) {
+	if d.rotation == drivers.Rotation90 || d.rotation == drivers.Rotation270 {
+		return d.height, d.width
+	}
 	return d.width, d.height
 }
 
 // RGBATo565 converts a color.RGBA to uint16 used in the display
+//
+// Deprecated: use pixel.RGB565.
 func RGBATo565(c color.RGBA) uint16 {
-	r, g, b, _ := c.RGBA()
-	return uint16((r & 0xF800) +
-		((g & 0xFC00) >> 5) +
-		((b & 0xF800) >> 11))
+	return pixel.RGB565(c)
+}
+
+// Sleep turns the display off, putting the controller in sleep mode. The
+// content of the display memory is kept. It implements drivers.Sleeper.
+func (d *Device) Sleep() error {
+	d.Command(SLEEP_MODE_DISPLAY_OFF)
+	d.sleeping = true
+	return nil
+}
+
+// Wake turns the display back on. It implements drivers.Sleeper.
+func (d *Device) Wake() error {
+	d.Command(SLEEP_MODE_DISPLAY_ON)
+	d.sleeping = false
+	return nil
+}
+
+// Sleeping returns whether the display has been put to sleep.
+func (d *Device) Sleeping() bool {
+	return d.sleeping
 }
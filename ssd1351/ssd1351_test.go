@@ -0,0 +1,70 @@
+package ssd1351
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"tinygo.org/x/drivers"
+	"tinygo.org/x/drivers/tester"
+)
+
+func TestSetRotation(t *testing.T) {
+	c := qt.New(t)
+	for rotation, remap := range []byte{0x72, 0x71, 0x60, 0x63} {
+		bus := tester.NewSPIBus(c)
+		dc := tester.NewPin()
+		d := New(bus, tester.NewPin(), dc, tester.NewPin(), tester.NewPin(), tester.NewPin())
+		d.width, d.height = 128, 96
+
+		bus.Expect([]byte{SET_REMAP_COLORDEPTH}, nil)
+		bus.Expect([]byte{remap}, nil)
+		c.Assert(d.SetRotation(drivers.Rotation(rotation)), qt.IsNil)
+		bus.AssertDone()
+		c.Assert(dc.Levels(), qt.DeepEquals, []bool{false, true})
+		c.Assert(d.Rotation(), qt.Equals, drivers.Rotation(rotation))
+
+		w, h := d.Size()
+		if rotation%2 == 1 {
+			c.Assert([]int16{w, h}, qt.DeepEquals, []int16{96, 128})
+		} else {
+			c.Assert([]int16{w, h}, qt.DeepEquals, []int16{128, 96})
+		}
+	}
+
+	d := New(tester.NewSPIBus(c), tester.NewPin(), tester.NewPin(), tester.NewPin(), tester.NewPin(), tester.NewPin())
+	c.Assert(d.SetRotation(4), qt.Equals, drivers.ErrInvalidConfig)
+}
+
+func TestConfigureRemap(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewSPIBus(c)
+	bus.AllowUnscripted = true
+	d := New(bus, tester.NewPin(), tester.NewPin(), tester.NewPin(), tester.NewPin(), tester.NewPin())
+	d.Configure(Config{})
+
+	// The default rotation keeps the remap of the earlier releases.
+	var remap []byte
+	for i, tx := range bus.Log {
+		if len(tx.W) == 1 && tx.W[0] == SET_REMAP_COLORDEPTH && i+1 < len(bus.Log) {
+			remap = bus.Log[i+1].W
+			break
+		}
+	}
+	c.Assert(remap, qt.DeepEquals, []byte{0x72})
+	c.Assert(d.Rotation(), qt.Equals, drivers.Rotation0)
+}
+
+func TestSetScroll(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewSPIBus(c)
+	d := New(bus, tester.NewPin(), tester.NewPin(), tester.NewPin(), tester.NewPin(), tester.NewPin())
+	c.Assert(d.SetScroll(10), qt.Equals, drivers.ErrInvalidConfig)
+
+	d.width, d.height = 128, 96
+	for line, start := range map[int16]byte{10: 10, 100: 4, -10: 86} {
+		bus.Expect([]byte{SET_DISPLAY_START_LINE}, nil)
+		bus.Expect([]byte{start}, nil)
+		c.Assert(d.SetScroll(line), qt.IsNil)
+		bus.AssertDone()
+	}
+}
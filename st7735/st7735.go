@@ -6,24 +6,22 @@ package st7735 // import "tinygo.org/x/drivers/st7735"
 
 import (
 	"image/color"
-	"machine"
 	"time"
 
-	"errors"
-
 	"tinygo.org/x/drivers"
+	"tinygo.org/x/drivers/pixel"
 )
 
 type Model uint8
-type Rotation uint8
+type Rotation = drivers.Rotation
 
 // Device wraps an SPI connection.
 type Device struct {
 	bus          drivers.SPI
-	dcPin        machine.Pin
-	resetPin     machine.Pin
-	csPin        machine.Pin
-	blPin        machine.Pin
+	dcPin        drivers.Pin
+	resetPin     drivers.Pin
+	csPin        drivers.Pin
+	blPin        drivers.Pin
 	width        int16
 	height       int16
 	columnOffset int16
@@ -33,6 +31,7 @@ type Device struct {
 	model        Model
 	isBGR        bool
 	batchData    []uint8
+	sleeping     bool
 }
 
 // Config is the configuration for the display
@@ -46,11 +45,11 @@ type Config struct {
 }
 
 // New creates a new ST7735 connection. The SPI wire must already be configured.
-func New(bus drivers.SPI, resetPin, dcPin, csPin, blPin machine.Pin) Device {
-	dcPin.Configure(machine.PinConfig{Mode: machine.PinOutput})
-	resetPin.Configure(machine.PinConfig{Mode: machine.PinOutput})
-	csPin.Configure(machine.PinConfig{Mode: machine.PinOutput})
-	blPin.Configure(machine.PinConfig{Mode: machine.PinOutput})
+func New(bus drivers.SPI, resetPin, dcPin, csPin, blPin drivers.Pin) Device {
+	drivers.ConfigurePin(dcPin, drivers.PinOutput)
+	drivers.ConfigurePin(resetPin, drivers.PinOutput)
+	drivers.ConfigurePin(csPin, drivers.PinOutput)
+	drivers.ConfigurePin(blPin, drivers.PinOutput)
 	return Device{
 		bus:      bus,
 		dcPin:    dcPin,
@@ -226,25 +225,28 @@ func (d *Device) setWindow(x, y, w, h int16) {
 	d.Command(RAMWR)
 }
 
-// SetScrollWindow sets an area to scroll with fixed top and bottom parts of the display
-func (d *Device) SetScrollArea(topFixedArea, bottomFixedArea int16) {
+// SetScrollArea sets an area to scroll with fixed top and bottom parts of the display
+func (d *Device) SetScrollArea(topFixedArea, bottomFixedArea int16) error {
 	d.Command(VSCRDEF)
 	d.Tx([]uint8{
 		uint8(topFixedArea >> 8), uint8(topFixedArea),
-		uint8(d.height - topFixedArea - bottomFixedArea>>8), uint8(d.height - topFixedArea - bottomFixedArea),
+		uint8((d.height - topFixedArea - bottomFixedArea) >> 8), uint8(d.height - topFixedArea - bottomFixedArea),
 		uint8(bottomFixedArea >> 8), uint8(bottomFixedArea)},
 		false)
+	return nil
 }
 
 // SetScroll sets the vertical scroll address of the display.
-func (d *Device) SetScroll(line int16) {
+func (d *Device) SetScroll(line int16) error {
 	d.Command(VSCRSADD)
 	d.Tx([]uint8{uint8(line >> 8), uint8(line)}, false)
+	return nil
 }
 
-// SpotScroll returns the display to its normal state
-func (d *Device) StopScroll() {
+// StopScroll returns the display to its normal state
+func (d *Device) StopScroll() error {
 	d.Command(NORON)
+	return nil
 }
 
 // FillRectangle fills a rectangle at a given coordinates with a color
@@ -252,10 +254,10 @@ func (d *Device) FillRectangle(x, y, width, height int16, c color.RGBA) error {
 	k, i := d.Size()
 	if x < 0 || y < 0 || width <= 0 || height <= 0 ||
 		x >= k || (x+width) > k || y >= i || (y+height) > i {
-		return errors.New("rectangle coordinates outside display area")
+		return drivers.ErrOutOfBounds
 	}
 	d.setWindow(x, y, width, height)
-	c565 := RGBATo565(c)
+	c565 := pixel.RGB565(c)
 	c1 := uint8(c565 >> 8)
 	c2 := uint8(c565)
 
@@ -280,67 +282,103 @@ func (d *Device) FillRectangleWithBuffer(x, y, width, height int16, buffer []col
 	k, l := d.Size()
 	if x < 0 || y < 0 || width <= 0 || height <= 0 ||
 		x >= k || (x+width) > k || y >= l || (y+height) > l {
-		return errors.New("rectangle coordinates outside display area")
+		return drivers.ErrOutOfBounds
 	}
 	k = width * height
 	l = int16(len(buffer))
 	if k != l {
-		return errors.New("buffer length does not match with rectangle size")
+		return drivers.ErrBufferSize
 	}
 
 	d.setWindow(x, y, width, height)
 
-	offset := int16(0)
-	for k > 0 {
-		for i := int16(0); i < d.batchLength; i++ {
-			if offset+i < l {
-				c565 := RGBATo565(buffer[offset+i])
-				c1 := uint8(c565 >> 8)
-				c2 := uint8(c565)
-				d.batchData[i*2] = c1
-				d.batchData[i*2+1] = c2
-			}
+	for len(buffer) > 0 {
+		n := pixel.ToRGB565Bytes(d.batchData, buffer)
+		d.Tx(d.batchData[:n*2], false)
+		buffer = buffer[n:]
+	}
+	return nil
+}
+
+// DrawRGBBitmap copies an RGB565 bitmap of w*h pixels to the display at
+// given coordinates.
+func (d *Device) DrawRGBBitmap(x, y int16, data []uint16, w, h int16) error {
+	k, l := d.Size()
+	if x < 0 || y < 0 || w <= 0 || h <= 0 ||
+		x >= k || (x+w) > k || y >= l || (y+h) > l {
+		return drivers.ErrOutOfBounds
+	}
+	if int32(w)*int32(h) != int32(len(data)) {
+		return drivers.ErrBufferSize
+	}
+	d.setWindow(x, y, w, h)
+
+	for len(data) > 0 {
+		n := int32(len(data))
+		if n > int32(d.batchLength) {
+			n = int32(d.batchLength)
 		}
-		if k >= d.batchLength {
-			d.Tx(d.batchData, false)
-		} else {
-			d.Tx(d.batchData[:k*2], false)
+		for i, c := range data[:n] {
+			d.batchData[i*2] = uint8(c >> 8)
+			d.batchData[i*2+1] = uint8(c)
 		}
-		k -= d.batchLength
-		offset += d.batchLength
+		d.Tx(d.batchData[:n*2], false)
+		data = data[n:]
+	}
+	return nil
+}
+
+// DrawRGBBitmap8 copies an RGB565 bitmap of w*h pixels, stored as 2 bytes per
+// pixel with the most significant byte first, to the display at given
+// coordinates.
+func (d *Device) DrawRGBBitmap8(x, y int16, data []uint8, w, h int16) error {
+	k, l := d.Size()
+	if x < 0 || y < 0 || w <= 0 || h <= 0 ||
+		x >= k || (x+w) > k || y >= l || (y+h) > l {
+		return drivers.ErrOutOfBounds
 	}
+	if int32(w)*int32(h)*2 != int32(len(data)) {
+		return drivers.ErrBufferSize
+	}
+	d.setWindow(x, y, w, h)
+	d.Tx(data, false)
 	return nil
 }
 
 // DrawFastVLine draws a vertical line faster than using SetPixel
-func (d *Device) DrawFastVLine(x, y0, y1 int16, c color.RGBA) {
+func (d *Device) DrawFastVLine(x, y0, y1 int16, c color.RGBA) error {
 	if y0 > y1 {
 		y0, y1 = y1, y0
 	}
-	d.FillRectangle(x, y0, 1, y1-y0+1, c)
+	return d.FillRectangle(x, y0, 1, y1-y0+1, c)
 }
 
 // DrawFastHLine draws a horizontal line faster than using SetPixel
-func (d *Device) DrawFastHLine(x0, x1, y int16, c color.RGBA) {
+func (d *Device) DrawFastHLine(x0, x1, y int16, c color.RGBA) error {
 	if x0 > x1 {
 		x0, x1 = x1, x0
 	}
-	d.FillRectangle(x0, y, x1-x0+1, 1, c)
+	return d.FillRectangle(x0, y, x1-x0+1, 1, c)
 }
 
 // FillScreen fills the screen with a given color
-func (d *Device) FillScreen(c color.RGBA) {
-	if d.rotation == NO_ROTATION || d.rotation == ROTATION_180 {
-		d.FillRectangle(0, 0, d.width, d.height, c)
-	} else {
-		d.FillRectangle(0, 0, d.height, d.width, c)
-	}
+func (d *Device) FillScreen(c color.RGBA) error {
+	w, h := d.Size()
+	return d.FillRectangle(0, 0, w, h, c)
+}
+
+// Rotation returns the current rotation of the device.
+func (d *Device) Rotation() Rotation {
+	return d.rotation
 }
 
 // SetRotation changes the rotation of the device (clock-wise)
-func (d *Device) SetRotation(rotation Rotation) {
+func (d *Device) SetRotation(rotation Rotation) error {
+	if rotation > ROTATION_270 {
+		return drivers.ErrInvalidConfig
+	}
 	madctl := uint8(0)
-	switch rotation % 4 {
+	switch rotation {
 	case 0:
 		madctl = MADCTL_MX | MADCTL_MY
 		break
@@ -358,7 +396,8 @@ func (d *Device) SetRotation(rotation Rotation) {
 	}
 	d.Command(MADCTL)
 	d.Data(madctl)
-
+	d.rotation = rotation
+	return nil
 }
 
 // Command sends a command to the display
@@ -394,6 +433,34 @@ func (d *Device) EnableBacklight(enable bool) {
 	}
 }
 
+// Sleep turns the display and its backlight off, and puts the controller in
+// sleep mode. The content of the display memory is kept. It implements
+// drivers.Sleeper.
+func (d *Device) Sleep() error {
+	d.EnableBacklight(false)
+	d.Command(DISPOFF)
+	d.Command(SLPIN)
+	time.Sleep(5 * time.Millisecond)
+	d.sleeping = true
+	return nil
+}
+
+// Wake takes the controller out of sleep mode, and turns the display and its
+// backlight back on. It implements drivers.Sleeper.
+func (d *Device) Wake() error {
+	d.Command(SLPOUT)
+	time.Sleep(5 * time.Millisecond) // required before the next command
+	d.Command(DISPON)
+	d.EnableBacklight(true)
+	d.sleeping = false
+	return nil
+}
+
+// Sleeping returns whether the display has been put to sleep.
+func (d *Device) Sleeping() bool {
+	return d.sleeping
+}
+
 // InverColors inverts the colors of the screen
 func (d *Device) InvertColors(invert bool) {
 	if invert {
@@ -409,9 +476,8 @@ func (d *Device) IsBGR(bgr bool) {
 }
 
 // RGBATo565 converts a color.RGBA to uint16 used in the display
+//
+// Deprecated: use pixel.RGB565.
 func RGBATo565(c color.RGBA) uint16 {
-	r, g, b, _ := c.RGBA()
-	return uint16((r & 0xF800) +
-		((g & 0xFC00) >> 5) +
-		((b & 0xF800) >> 11))
+	return pixel.RGB565(c)
 }
@@ -0,0 +1,187 @@
+package st7789
+
+import (
+	"errors"
+	"image/color"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"tinygo.org/x/drivers"
+	"tinygo.org/x/drivers/tester"
+)
+
+func TestNewConfiguresPins(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewSPIBus(c)
+	rst, dc, cs, bl := tester.NewPin(), tester.NewPin(), tester.NewPin(), tester.NewPin()
+	New(bus, rst, dc, cs, bl)
+
+	for _, p := range []*tester.Pin{rst, dc, cs, bl} {
+		c.Assert(p.Configured(), qt.Equals, true)
+		c.Assert(p.Mode(), qt.Equals, drivers.PinOutput)
+	}
+}
+
+func TestCommandData(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewSPIBus(c)
+	rst, dc, cs, bl := tester.NewPin(), tester.NewPin(), tester.NewPin(), tester.NewPin()
+	d := New(bus, rst, dc, cs, bl)
+
+	bus.Expect([]byte{COLMOD}, nil)
+	bus.Expect([]byte{0x55}, nil)
+	d.Command(COLMOD)
+	d.Data(0x55)
+	bus.AssertDone()
+
+	// DC is low for commands and high for data, CS frames each transfer.
+	c.Assert(dc.Levels(), qt.DeepEquals, []bool{false, true})
+	c.Assert(cs.Levels(), qt.DeepEquals, []bool{false, true, false, true})
+}
+
+func TestEnableBacklight(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewSPIBus(c)
+	rst, dc, cs, bl := tester.NewPin(), tester.NewPin(), tester.NewPin(), tester.NewPin()
+	d := New(bus, rst, dc, cs, bl)
+
+	d.EnableBacklight(true)
+	d.EnableBacklight(false)
+	c.Assert(bl.Levels(), qt.DeepEquals, []bool{true, false})
+}
+
+func TestSleepWake(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.NewSPIBus(c)
+	rst, dc, cs, bl := tester.NewPin(), tester.NewPin(), tester.NewPin(), tester.NewPin()
+	d := New(bus, rst, dc, cs, bl)
+
+	bus.Expect([]byte{DISPOFF}, nil)
+	bus.Expect([]byte{SLPIN}, nil)
+	c.Assert(d.Sleep(), qt.IsNil)
+	c.Assert(d.Sleeping(), qt.IsTrue)
+
+	bus.Expect([]byte{SLPOUT}, nil)
+	bus.Expect([]byte{DISPON}, nil)
+	c.Assert(d.Wake(), qt.IsNil)
+	c.Assert(d.Sleeping(), qt.IsFalse)
+	bus.AssertDone()
+
+	c.Assert(bl.Levels(), qt.DeepEquals, []bool{false, true})
+}
+
+// newTestDevice returns a 240x320 device without running the initialization
+// sequence of Configure.
+func newTestDevice(c *qt.C) (*Device, *tester.SPIBus) {
+	bus := tester.NewSPIBus(c)
+	d := New(bus, tester.NewPin(), tester.NewPin(), tester.NewPin(), tester.NewPin())
+	d.width, d.height = 240, 320
+	d.batchLength = 320
+	return &d, bus
+}
+
+func TestDrawRGBBitmap(t *testing.T) {
+	c := qt.New(t)
+	d, bus := newTestDevice(c)
+
+	bus.Expect([]byte{CASET}, nil)
+	bus.Expect([]byte{0, 10, 0, 11}, nil)
+	bus.Expect([]byte{RASET}, nil)
+	bus.Expect([]byte{0, 20, 0, 20}, nil)
+	bus.Expect([]byte{RAMWR}, nil)
+	bus.Expect([]byte{0xf8, 0x00, 0x07, 0xe0}, nil)
+	c.Assert(d.DrawRGBBitmap(10, 20, []uint16{0xf800, 0x07e0}, 2, 1), qt.IsNil)
+	bus.AssertDone()
+
+	err := d.DrawRGBBitmap(10, 20, []uint16{0xf800}, 2, 1)
+	c.Assert(errors.Is(err, drivers.ErrBufferSize), qt.IsTrue)
+	err = d.DrawRGBBitmap(239, 0, []uint16{0xf800, 0x07e0}, 2, 1)
+	c.Assert(errors.Is(err, drivers.ErrOutOfBounds), qt.IsTrue)
+	c.Assert(bus.Log, qt.HasLen, 6)
+}
+
+func TestSetRotation(t *testing.T) {
+	c := qt.New(t)
+	d, bus := newTestDevice(c)
+	c.Assert(d.Rotation(), qt.Equals, NO_ROTATION)
+
+	bus.Expect([]byte{MADCTL}, nil)
+	bus.Expect([]byte{MADCTL_MY | MADCTL_MV}, nil)
+	c.Assert(d.SetRotation(ROTATION_90), qt.IsNil)
+	bus.AssertDone()
+	c.Assert(d.Rotation(), qt.Equals, ROTATION_90)
+	w, h := d.Size()
+	c.Assert([]int16{w, h}, qt.DeepEquals, []int16{320, 240})
+
+	// The rectangle fits the rotated display only.
+	bus.AllowUnscripted = true
+	c.Assert(d.FillRectangle(300, 0, 20, 10, color.RGBA{}), qt.IsNil)
+	c.Assert(d.SetRotation(NO_ROTATION), qt.IsNil)
+	err := d.FillRectangle(300, 0, 20, 10, color.RGBA{})
+	c.Assert(errors.Is(err, drivers.ErrOutOfBounds), qt.IsTrue)
+
+	err = d.SetRotation(4)
+	c.Assert(errors.Is(err, drivers.ErrInvalidConfig), qt.IsTrue)
+	c.Assert(d.Rotation(), qt.Equals, NO_ROTATION)
+}
+
+// newEmulatedDevice returns a device of the given size connected to an
+// emulated controller with the same memory size, in 16 bits per pixel.
+func newEmulatedDevice(c *qt.C, width, height int16) (*Device, *tester.DisplayController) {
+	ctrl := tester.NewDisplayController(c, width, height)
+	d := New(ctrl, tester.NewPin(), ctrl.DC, tester.NewPin(), tester.NewPin())
+	d.width, d.height = width, height
+	d.batchLength = int32(height)
+	d.Command(COLMOD)
+	d.Data(0x55)
+	return &d, ctrl
+}
+
+type bufferDisplayer interface {
+	drivers.AcceleratedDisplayer
+	FillRectangleWithBuffer(x, y, width, height int16, buffer []color.RGBA) error
+}
+
+// drawScene draws with all the drawing methods, close to the corners of the
+// display.
+func drawScene(c *qt.C, d bufferDisplayer) {
+	w, h := d.Size()
+	buffer := make([]color.RGBA, 8*5)
+	for i := range buffer {
+		buffer[i] = color.RGBA{uint8(i * 6), 255 - uint8(i*6), 0x80, 255}
+	}
+	c.Assert(d.FillRectangle(1, 2, 5, 3, color.RGBA{255, 0, 0, 255}), qt.IsNil)
+	c.Assert(d.FillRectangleWithBuffer(w-8, h-5, 8, 5, buffer), qt.IsNil)
+	c.Assert(d.DrawRGBBitmap(0, h-2, []uint16{0xf800, 0x07e0, 0x001f, 0xffff, 0x0000, 0x1234}, 3, 2), qt.IsNil)
+	d.SetPixel(w-1, 0, color.RGBA{255, 255, 255, 255})
+}
+
+func TestRotationsOnController(t *testing.T) {
+	c := qt.New(t)
+	for rotation := NO_ROTATION; rotation <= ROTATION_270; rotation++ {
+		d, ctrl := newEmulatedDevice(c, 24, 32)
+		c.Assert(d.SetRotation(rotation), qt.IsNil)
+		drawScene(c, d)
+
+		// The panel shows the memory rotated by 180 degrees.
+		want := tester.NewDisplay(24, 32, tester.RGB565Model)
+		c.Assert(want.SetRotation((rotation+2)%4), qt.IsNil)
+		drawScene(c, want)
+		c.Assert(ctrl.Display.Memory().Pix, qt.DeepEquals, want.Memory().Pix, qt.Commentf("rotation %d", rotation))
+	}
+}
+
+func TestScrollOnController(t *testing.T) {
+	c := qt.New(t)
+	d, ctrl := newEmulatedDevice(c, 24, 32)
+	c.Assert(d.SetRotation(ROTATION_180), qt.IsNil)
+	c.Assert(d.FillRectangle(0, 10, 24, 1, color.RGBA{255, 255, 255, 255}), qt.IsNil)
+
+	c.Assert(d.SetScrollArea(4, 4), qt.IsNil)
+	c.Assert(d.SetScroll(10), qt.IsNil)
+	c.Assert(ctrl.Display.Frame().RGBAAt(0, 4), qt.Equals, color.RGBA{255, 255, 255, 255})
+	c.Assert(ctrl.Display.Frame().RGBAAt(0, 10), qt.Equals, color.RGBA{0, 0, 0, 255})
+
+	c.Assert(d.StopScroll(), qt.IsNil)
+	c.Assert(ctrl.Display.Frame().RGBAAt(0, 10), qt.Equals, color.RGBA{255, 255, 255, 255})
+}
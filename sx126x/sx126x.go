@@ -147,6 +147,23 @@ func (d *Device) SetStandby() {
 	d.ExecSetCommand(SX126X_CMD_SET_STANDBY, []uint8{SX126X_STANDBY_RC})
 }
 
+// Sleep sets the device in SLEEP mode, keeping its configuration. It implements drivers.Sleeper.
+func (d *Device) Sleep() error {
+	d.SetSleep()
+	return nil
+}
+
+// Wake wakes the device up from SLEEP mode and sets it in STANDBY mode. It implements drivers.Sleeper.
+func (d *Device) Wake() error {
+	d.SetStandby()
+	return nil
+}
+
+// Sleeping returns whether the device is in SLEEP mode.
+func (d *Device) Sleeping() bool {
+	return d.deepSleep
+}
+
 // SetFs sets the device in frequency synthesis mode where the PLL is locked to the carrier frequency.
 func (d *Device) SetFs() {
 	d.ExecSetCommand(SX126X_CMD_SET_FS, []uint8{})
@@ -0,0 +1,93 @@
+package tester
+
+import (
+	"time"
+)
+
+// Clock implements the drivers.Clock interface with a fake time that only
+// advances when told to. Sleep returns immediately after advancing the
+// time, so timeouts of drivers can be tested without waiting.
+type Clock struct {
+	start time.Time
+	now   time.Time
+
+	// Step, if non-zero, advances the time on every call to Now. It lets
+	// polling loops that do not sleep reach their timeout.
+	Step time.Duration
+
+	// Sleeps holds the duration of every call to Sleep, in order.
+	Sleeps []time.Duration
+
+	timers []clockTimer
+}
+
+type clockTimer struct {
+	at time.Duration
+	f  func()
+}
+
+// NewClock returns a new fake clock.
+func NewClock() *Clock {
+	start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return &Clock{
+		start: start,
+		now:   start,
+	}
+}
+
+// Now implements drivers.Clock. It returns the current fake time, then
+// advances it by Step.
+func (c *Clock) Now() time.Time {
+	now := c.now
+	if c.Step != 0 {
+		c.Advance(c.Step)
+	}
+	return now
+}
+
+// Sleep implements drivers.Clock. It advances the time by d and returns
+// immediately.
+func (c *Clock) Sleep(d time.Duration) {
+	c.Sleeps = append(c.Sleeps, d)
+	c.Advance(d)
+}
+
+// Advance advances the time by d and runs the functions scheduled with At
+// that are due.
+func (c *Clock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+	elapsed := c.Elapsed()
+	for len(c.timers) > 0 && c.timers[0].at <= elapsed {
+		f := c.timers[0].f
+		c.timers = c.timers[1:]
+		f()
+	}
+}
+
+// Elapsed returns the time elapsed since the creation of the clock.
+func (c *Clock) Elapsed() time.Duration {
+	return c.now.Sub(c.start)
+}
+
+// Slept returns the total duration of the calls to Sleep.
+func (c *Clock) Slept() time.Duration {
+	var total time.Duration
+	for _, d := range c.Sleeps {
+		total += d
+	}
+	return total
+}
+
+// At schedules f to run once the time elapsed since the creation of the
+// clock reaches at. It can be used to simulate a device that changes state
+// after a delay, for example a pin that goes low once the device is ready.
+// If at has already passed, f runs on the next advance of the time.
+func (c *Clock) At(at time.Duration, f func()) {
+	i := len(c.timers)
+	for i > 0 && c.timers[i-1].at > at {
+		i--
+	}
+	c.timers = append(c.timers, clockTimer{})
+	copy(c.timers[i+1:], c.timers[i:])
+	c.timers[i] = clockTimer{at: at, f: f}
+}
@@ -0,0 +1,57 @@
+package tester
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"tinygo.org/x/drivers"
+)
+
+var _ drivers.Clock = (*Clock)(nil)
+
+func TestClockSleep(t *testing.T) {
+	c := qt.New(t)
+	clock := NewClock()
+	start := clock.Now()
+
+	clock.Sleep(10 * time.Millisecond)
+	clock.Sleep(5 * time.Millisecond)
+	c.Assert(clock.Now().Sub(start), qt.Equals, 15*time.Millisecond)
+	c.Assert(clock.Sleeps, qt.DeepEquals, []time.Duration{10 * time.Millisecond, 5 * time.Millisecond})
+	c.Assert(clock.Slept(), qt.Equals, 15*time.Millisecond)
+
+	clock.Advance(time.Second)
+	c.Assert(clock.Elapsed(), qt.Equals, time.Second+15*time.Millisecond)
+	c.Assert(clock.Slept(), qt.Equals, 15*time.Millisecond)
+}
+
+func TestClockStep(t *testing.T) {
+	c := qt.New(t)
+	clock := NewClock()
+	clock.Step = time.Millisecond
+
+	start := clock.Now()
+	n := 0
+	for clock.Now().Sub(start) < 10*time.Millisecond {
+		n++
+	}
+	c.Assert(n, qt.Equals, 9)
+}
+
+func TestClockAt(t *testing.T) {
+	c := qt.New(t)
+	clock := NewClock()
+	var fired []string
+	clock.At(20*time.Millisecond, func() { fired = append(fired, "b") })
+	clock.At(10*time.Millisecond, func() { fired = append(fired, "a") })
+	clock.At(20*time.Millisecond, func() { fired = append(fired, "c") })
+
+	clock.Sleep(5 * time.Millisecond)
+	c.Assert(fired, qt.HasLen, 0)
+	clock.Sleep(5 * time.Millisecond)
+	c.Assert(fired, qt.DeepEquals, []string{"a"})
+	clock.Sleep(time.Second)
+	c.Assert(fired, qt.DeepEquals, []string{"a", "b", "c"})
+}
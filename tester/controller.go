@@ -0,0 +1,319 @@
+package tester
+
+import (
+	"image/color"
+
+	"tinygo.org/x/drivers/pixel"
+)
+
+// Commands of the MIPI DCS display controllers, as decoded by
+// DisplayController.
+const (
+	dcsSWRESET  = 0x01
+	dcsSLPIN    = 0x10
+	dcsSLPOUT   = 0x11
+	dcsPTLON    = 0x12
+	dcsNORON    = 0x13
+	dcsINVOFF   = 0x20
+	dcsINVON    = 0x21
+	dcsDISPOFF  = 0x28
+	dcsDISPON   = 0x29
+	dcsCASET    = 0x2A
+	dcsRASET    = 0x2B
+	dcsRAMWR    = 0x2C
+	dcsVSCRDEF  = 0x33
+	dcsMADCTL   = 0x36
+	dcsVSCRSADD = 0x37
+	dcsCOLMOD   = 0x3A
+	dcsRAMWRC   = 0x3C
+
+	madctlMY  = 0x80
+	madctlMX  = 0x40
+	madctlMV  = 0x20
+	madctlBGR = 0x08
+)
+
+// dcsParams is the number of parameters of the commands that are decoded.
+// The parameters of other commands are ignored.
+var dcsParams = map[byte]int{
+	dcsCASET:    4,
+	dcsRASET:    4,
+	dcsVSCRDEF:  6,
+	dcsMADCTL:   1,
+	dcsVSCRSADD: 2,
+	dcsCOLMOD:   1,
+}
+
+// DisplayController emulates the controller of the TFT displays driven by
+// the st7789, st7735 and ili9341 packages. It implements drivers.SPI and
+// decodes the MIPI DCS commands sent on the bus, with the DC pin low for
+// commands and high for their data, to rebuild the memory of the display:
+//
+//	ctrl := tester.NewST7789(c)
+//	d := st7789.New(ctrl, tester.NewPin(), ctrl.DC, tester.NewPin(), tester.NewPin())
+//	d.FillRectangle(10, 10, 20, 20, red)
+//	ctrl.Display.AssertPNG(c, "testdata/fill.png")
+//
+// The address window (CASET, RASET), memory writes (RAMWR, RAMWRC), memory
+// access control (MADCTL), pixel format (COLMOD) and vertical scrolling
+// (VSCRDEF, VSCRSADD, NORON) are emulated. Data written outside the memory
+// and invalid parameters are flagged with the Failer.
+type DisplayController struct {
+	c Failer
+
+	// DC is the data/command pin to give to the driver.
+	DC *Pin
+
+	// Display holds the memory of the controller, in the orientation of
+	// the panel, and the frames shown with the scrolling applied.
+	Display *Display
+
+	// BGR is set for the panels with blue, green and red subpixels, which
+	// show the right colors when the BGR bit of MADCTL is set.
+	BGR bool
+
+	// Sleeping, On and Inverted are the states set by SLPIN/SLPOUT,
+	// DISPON/DISPOFF and INVON/INVOFF.
+	Sleeping bool
+	On       bool
+	Inverted bool
+
+	cmd     byte
+	params  []byte
+	pending []byte
+
+	madctl       uint8
+	colmod       uint8
+	xs, xe       int
+	ys, ye       int
+	column, page int
+}
+
+// NewDisplayController returns a controller with a memory of the given
+// size, in its state after a reset.
+func NewDisplayController(c Failer, width, height int16) *DisplayController {
+	ctrl := &DisplayController{
+		c:       c,
+		DC:      NewPin(),
+		Display: NewDisplay(width, height, nil),
+	}
+	ctrl.reset()
+	return ctrl
+}
+
+// NewST7789 returns the controller of a ST7789 display, with a memory of
+// 240x320 pixels.
+func NewST7789(c Failer) *DisplayController {
+	return NewDisplayController(c, 240, 320)
+}
+
+// NewST7735 returns the controller of a ST7735 display, with a memory of
+// 132x162 pixels.
+func NewST7735(c Failer) *DisplayController {
+	return NewDisplayController(c, 132, 162)
+}
+
+// NewILI9341 returns the controller of an ILI9341 display, with a memory of
+// 240x320 pixels and a BGR panel.
+func NewILI9341(c Failer) *DisplayController {
+	ctrl := NewDisplayController(c, 240, 320)
+	ctrl.BGR = true
+	return ctrl
+}
+
+// Tx implements drivers.SPI. Nothing is read from the controller: r is
+// filled with zeros.
+func (ctrl *DisplayController) Tx(w, r []byte) error {
+	for i := range r {
+		r[i] = 0
+	}
+	command := !ctrl.DC.Get()
+	for _, b := range w {
+		if command {
+			ctrl.command(b)
+		} else {
+			ctrl.data(b)
+		}
+	}
+	return nil
+}
+
+// Transfer implements drivers.SPI.
+func (ctrl *DisplayController) Transfer(b byte) (byte, error) {
+	return 0, ctrl.Tx([]byte{b}, nil)
+}
+
+// MADCTL returns the memory access control set by the driver.
+func (ctrl *DisplayController) MADCTL() uint8 {
+	return ctrl.madctl
+}
+
+// PixelFormat returns the pixel format set with COLMOD, which defaults to
+// 18 bits per pixel after a reset.
+func (ctrl *DisplayController) PixelFormat() uint8 {
+	return ctrl.colmod
+}
+
+// reset sets the registers to their values after a reset. The memory is
+// kept.
+func (ctrl *DisplayController) reset() {
+	w, h := ctrl.Display.mem.Rect.Dx(), ctrl.Display.mem.Rect.Dy()
+	ctrl.madctl = 0
+	ctrl.colmod = 0x66
+	ctrl.xs, ctrl.xe = 0, w-1
+	ctrl.ys, ctrl.ye = 0, h-1
+	ctrl.Display.scrolling = false
+	ctrl.Display.top, ctrl.Display.bottom, ctrl.Display.scroll = 0, 0, 0
+	ctrl.Sleeping = true
+	ctrl.On = false
+	ctrl.Inverted = false
+}
+
+func (ctrl *DisplayController) command(cmd byte) {
+	ctrl.cmd = cmd
+	ctrl.params = ctrl.params[:0]
+	ctrl.pending = ctrl.pending[:0]
+	switch cmd {
+	case dcsSWRESET:
+		ctrl.reset()
+	case dcsSLPIN:
+		ctrl.Sleeping = true
+	case dcsSLPOUT:
+		ctrl.Sleeping = false
+	case dcsNORON, dcsPTLON:
+		ctrl.Display.scrolling = false
+	case dcsINVON:
+		ctrl.Inverted = true
+	case dcsINVOFF:
+		ctrl.Inverted = false
+	case dcsDISPON:
+		ctrl.On = true
+	case dcsDISPOFF:
+		ctrl.On = false
+	case dcsRAMWR:
+		ctrl.column, ctrl.page = ctrl.xs, ctrl.ys
+	}
+}
+
+func (ctrl *DisplayController) data(b byte) {
+	if ctrl.cmd == dcsRAMWR || ctrl.cmd == dcsRAMWRC {
+		ctrl.pixelData(b)
+		return
+	}
+	n, ok := dcsParams[ctrl.cmd]
+	if !ok || len(ctrl.params) >= n {
+		return
+	}
+	ctrl.params = append(ctrl.params, b)
+	if len(ctrl.params) == n {
+		ctrl.apply()
+	}
+}
+
+// apply applies a command once all its parameters have been received.
+func (ctrl *DisplayController) apply() {
+	p := ctrl.params
+	u16 := func(i int) int {
+		return int(p[i])<<8 | int(p[i+1])
+	}
+	switch ctrl.cmd {
+	case dcsCASET:
+		ctrl.xs, ctrl.xe = u16(0), u16(2)
+		if ctrl.xs > ctrl.xe {
+			ctrl.c.Fatalf("CASET start column %d after end column %d", ctrl.xs, ctrl.xe)
+		}
+	case dcsRASET:
+		ctrl.ys, ctrl.ye = u16(0), u16(2)
+		if ctrl.ys > ctrl.ye {
+			ctrl.c.Fatalf("RASET start row %d after end row %d", ctrl.ys, ctrl.ye)
+		}
+	case dcsMADCTL:
+		ctrl.madctl = p[0]
+	case dcsCOLMOD:
+		switch p[0] & 0x07 {
+		case 3, 5, 6:
+			ctrl.colmod = p[0]
+		default:
+			ctrl.c.Fatalf("COLMOD unsupported pixel format %#x", p[0])
+		}
+	case dcsVSCRDEF:
+		top, height, bottom := u16(0), u16(2), u16(4)
+		if lines := ctrl.Display.mem.Rect.Dy(); top+height+bottom != lines {
+			ctrl.c.Fatalf("VSCRDEF areas %d+%d+%d do not match the %d lines of memory", top, height, bottom, lines)
+			return
+		}
+		ctrl.Display.top, ctrl.Display.bottom = int16(top), int16(bottom)
+	case dcsVSCRSADD:
+		ctrl.Display.scroll = int16(u16(0))
+		ctrl.Display.scrolling = true
+	}
+}
+
+// pixelData decodes the pixels written to memory in the current pixel
+// format: 12 bits per pixel (2 pixels in 3 bytes), 16 bits per pixel in
+// RGB565, or 18 bits per pixel (a byte per component, 6 bits used).
+func (ctrl *DisplayController) pixelData(b byte) {
+	ctrl.pending = append(ctrl.pending, b)
+	p := ctrl.pending
+	switch ctrl.colmod & 0x07 {
+	case 3:
+		if len(p) == 3 {
+			ctrl.write(rgb444(uint16(p[0])<<4 | uint16(p[1])>>4))
+			ctrl.write(rgb444(uint16(p[1]&0x0F)<<8 | uint16(p[2])))
+			ctrl.pending = p[:0]
+		}
+	case 5:
+		if len(p) == 2 {
+			ctrl.write(pixel.RGB565ToRGBA(uint16(p[0])<<8 | uint16(p[1])))
+			ctrl.pending = p[:0]
+		}
+	default:
+		if len(p) == 3 {
+			ctrl.write(color.RGBA{p[0]&0xFC | p[0]>>6, p[1]&0xFC | p[1]>>6, p[2]&0xFC | p[2]>>6, 255})
+			ctrl.pending = p[:0]
+		}
+	}
+}
+
+// rgb444 converts a 12 bit pixel to an opaque color.
+func rgb444(p uint16) color.RGBA {
+	r, g, b := uint8(p>>8)&0x0F, uint8(p>>4)&0x0F, uint8(p)&0x0F
+	return color.RGBA{r<<4 | r, g<<4 | g, b<<4 | b, 255}
+}
+
+// write writes a pixel at the address counter, through the memory access
+// control, and moves the counter to the next pixel of the address window.
+func (ctrl *DisplayController) write(c color.RGBA) {
+	if (ctrl.madctl&madctlBGR != 0) != ctrl.BGR {
+		c.R, c.B = c.B, c.R
+	}
+
+	// The rows and columns are exchanged first, then mirrored in memory.
+	x, y := ctrl.column, ctrl.page
+	if ctrl.madctl&madctlMV != 0 {
+		x, y = y, x
+	}
+	mem := ctrl.Display.mem
+	w, h := mem.Rect.Dx(), mem.Rect.Dy()
+	if ctrl.madctl&madctlMX != 0 {
+		x = w - 1 - x
+	}
+	if ctrl.madctl&madctlMY != 0 {
+		y = h - 1 - y
+	}
+	if x < 0 || y < 0 || x >= w || y >= h {
+		ctrl.c.Fatalf("pixel at column %d, row %d written outside the %dx%d memory (MADCTL %#x)",
+			ctrl.column, ctrl.page, w, h, ctrl.madctl)
+	} else {
+		mem.SetRGBA(x, y, c)
+	}
+
+	ctrl.column++
+	if ctrl.column > ctrl.xe {
+		ctrl.column = ctrl.xs
+		ctrl.page++
+		if ctrl.page > ctrl.ye {
+			ctrl.page = ctrl.ys
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package tester
+
+import (
+	"image/color"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"tinygo.org/x/drivers"
+)
+
+var _ drivers.SPI = (*DisplayController)(nil)
+
+// send sends a command and its data to the controller.
+func send(ctrl *DisplayController, cmd byte, data ...byte) {
+	ctrl.DC.Low()
+	ctrl.Tx([]byte{cmd}, nil)
+	ctrl.DC.High()
+	if len(data) > 0 {
+		ctrl.Tx(data, nil)
+	}
+}
+
+func TestDisplayControllerWindow(t *testing.T) {
+	c := qt.New(t)
+	ctrl := NewDisplayController(c, 4, 3)
+	send(ctrl, dcsCOLMOD, 0x55)
+	send(ctrl, dcsCASET, 0, 1, 0, 2)
+	send(ctrl, dcsRASET, 0, 1, 0, 2)
+	send(ctrl, dcsRAMWR, 0xF8, 0x00, 0x07, 0xE0)
+
+	// The pixels may be split across transfers, and wrap around the window.
+	ctrl.Tx([]byte{0x00}, nil)
+	ctrl.Tx([]byte{0x1F, 0xFF, 0xFF, 0xF8, 0x00}, nil)
+
+	mem := ctrl.Display.Memory()
+	c.Assert(mem.RGBAAt(1, 1), qt.Equals, red)
+	c.Assert(mem.RGBAAt(2, 1), qt.Equals, color.RGBA{0, 255, 0, 255})
+	c.Assert(mem.RGBAAt(1, 2), qt.Equals, color.RGBA{0, 0, 255, 255})
+	c.Assert(mem.RGBAAt(2, 2), qt.Equals, white)
+	c.Assert(mem.RGBAAt(0, 0), qt.Equals, black)
+
+	// RAMWRC continues where the previous write stopped.
+	send(ctrl, dcsRAMWRC, 0x00, 0x00)
+	c.Assert(mem.RGBAAt(2, 1), qt.Equals, black)
+}
+
+func TestDisplayControllerPixelFormats(t *testing.T) {
+	c := qt.New(t)
+	ctrl := NewDisplayController(c, 2, 1)
+	c.Assert(ctrl.PixelFormat(), qt.Equals, uint8(0x66))
+	send(ctrl, dcsRAMWR, 0xFC, 0x00, 0x00, 0x00, 0x80, 0xFC)
+	mem := ctrl.Display.Memory()
+	c.Assert(mem.RGBAAt(0, 0), qt.Equals, red)
+	c.Assert(mem.RGBAAt(1, 0), qt.Equals, color.RGBA{0, 0x82, 0xFF, 255})
+
+	send(ctrl, dcsCOLMOD, 0x53)
+	send(ctrl, dcsRAMWR, 0x0F, 0x0F, 0xFF)
+	c.Assert(mem.RGBAAt(0, 0), qt.Equals, color.RGBA{0, 0xFF, 0, 255})
+	c.Assert(mem.RGBAAt(1, 0), qt.Equals, white)
+
+	f := &recordingFailer{}
+	ctrl = NewDisplayController(f, 2, 1)
+	send(ctrl, dcsCOLMOD, 0x77)
+	c.Assert(f.failures, qt.DeepEquals, []string{"COLMOD unsupported pixel format 0x77"})
+}
+
+func TestDisplayControllerMADCTL(t *testing.T) {
+	c := qt.New(t)
+	tests := []struct {
+		madctl        uint8
+		first, second [2]int
+	}{
+		{0, [2]int{0, 0}, [2]int{1, 0}},
+		{madctlMX, [2]int{3, 0}, [2]int{2, 0}},
+		{madctlMY, [2]int{0, 2}, [2]int{1, 2}},
+		{madctlMV, [2]int{0, 0}, [2]int{0, 1}},
+		{madctlMV | madctlMY, [2]int{0, 2}, [2]int{0, 1}},
+		{madctlMV | madctlMX, [2]int{3, 0}, [2]int{3, 1}},
+	}
+	for _, test := range tests {
+		ctrl := NewDisplayController(c, 4, 3)
+		send(ctrl, dcsCOLMOD, 0x55)
+		send(ctrl, dcsMADCTL, test.madctl)
+		send(ctrl, dcsCASET, 0, 0, 0, 1)
+		send(ctrl, dcsRASET, 0, 0, 0, 0)
+		send(ctrl, dcsRAMWR, 0xFF, 0xFF, 0xF8, 0x00)
+		mem := ctrl.Display.Memory()
+		comment := qt.Commentf("MADCTL %#x", test.madctl)
+		c.Assert(mem.RGBAAt(test.first[0], test.first[1]), qt.Equals, white, comment)
+		c.Assert(mem.RGBAAt(test.second[0], test.second[1]), qt.Equals, red, comment)
+	}
+
+	// The red and blue components are swapped, unless the panel is BGR too.
+	ctrl := NewDisplayController(c, 1, 1)
+	send(ctrl, dcsCOLMOD, 0x55)
+	send(ctrl, dcsMADCTL, madctlBGR)
+	send(ctrl, dcsRAMWR, 0xF8, 0x00)
+	c.Assert(ctrl.Display.GetPixel(0, 0), qt.Equals, color.RGBA{0, 0, 255, 255})
+	ctrl.BGR = true
+	send(ctrl, dcsRAMWR, 0xF8, 0x00)
+	c.Assert(ctrl.Display.GetPixel(0, 0), qt.Equals, red)
+
+	// Writes outside the memory are flagged.
+	f := &recordingFailer{}
+	ctrl = NewDisplayController(f, 4, 3)
+	send(ctrl, dcsMADCTL, madctlMV)
+	send(ctrl, dcsCASET, 0, 0, 0, 3)
+	send(ctrl, dcsRAMWR, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0)
+	c.Assert(f.failures, qt.DeepEquals, []string{
+		"pixel at column 3, row 0 written outside the 4x3 memory (MADCTL 0x20)",
+	})
+}
+
+func TestDisplayControllerScroll(t *testing.T) {
+	c := qt.New(t)
+	ctrl := NewDisplayController(c, 1, 4)
+	send(ctrl, dcsCOLMOD, 0x55)
+	send(ctrl, dcsRAMWR, 0x00, 0x00, 0x00, 0x01, 0x00, 0x02, 0x00, 0x03)
+	rows := func() []uint8 {
+		frame := ctrl.Display.Frame()
+		var r []uint8
+		for y := 0; y < 4; y++ {
+			r = append(r, frame.RGBAAt(0, y).B>>3)
+		}
+		return r
+	}
+
+	send(ctrl, dcsVSCRDEF, 0, 1, 0, 3, 0, 0)
+	c.Assert(rows(), qt.DeepEquals, []uint8{0, 1, 2, 3})
+	send(ctrl, dcsVSCRSADD, 0, 2)
+	c.Assert(rows(), qt.DeepEquals, []uint8{0, 2, 3, 1})
+	send(ctrl, dcsNORON)
+	c.Assert(rows(), qt.DeepEquals, []uint8{0, 1, 2, 3})
+
+	f := &recordingFailer{}
+	ctrl = NewDisplayController(f, 1, 4)
+	send(ctrl, dcsVSCRDEF, 0, 1, 0, 4, 0, 0)
+	c.Assert(f.failures, qt.DeepEquals, []string{"VSCRDEF areas 1+4+0 do not match the 4 lines of memory"})
+}
+
+func TestDisplayControllerState(t *testing.T) {
+	c := qt.New(t)
+	ctrl := NewILI9341(c)
+	c.Assert(ctrl.Sleeping, qt.IsTrue)
+	send(ctrl, dcsSLPOUT)
+	send(ctrl, dcsDISPON)
+	send(ctrl, dcsINVON)
+	send(ctrl, dcsMADCTL, 0x48)
+	c.Assert(ctrl.Sleeping, qt.IsFalse)
+	c.Assert(ctrl.On, qt.IsTrue)
+	c.Assert(ctrl.Inverted, qt.IsTrue)
+	c.Assert(ctrl.MADCTL(), qt.Equals, uint8(0x48))
+
+	send(ctrl, dcsSWRESET)
+	c.Assert(ctrl.Sleeping, qt.IsTrue)
+	c.Assert(ctrl.On, qt.IsFalse)
+	c.Assert(ctrl.MADCTL(), qt.Equals, uint8(0))
+
+	// Reads return zeros.
+	r := []byte{1, 2}
+	c.Assert(ctrl.Tx([]byte{0, 0}, r), qt.IsNil)
+	c.Assert(r, qt.DeepEquals, []byte{0, 0})
+}
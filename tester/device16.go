@@ -11,6 +11,11 @@ type I2CDevice16 struct {
 	// If Err is non-nil, it will be returned as the error from the
 	// I2C methods.
 	Err error
+	// Faults configures the faults injected into transactions.
+	Faults I2CFaults
+	// Stuck holds registers that always read as the given value, and
+	// ignore writes.
+	Stuck map[uint8]uint16
 }
 
 // NewI2CDevice returns a new mock I2C device.
@@ -46,11 +51,14 @@ func (d *I2CDevice16) ReadRegister(r uint8, buf []byte) error {
 	if !ok {
 		d.c.Fatalf("register read [%#x] unknown register", r)
 	}
+	if v, ok := d.Stuck[r]; ok {
+		val = v
+	}
 
-	buf[0] = byte(val >> 8)
-	buf[1] = byte(val & 0xff)
+	n, err := d.Faults.inject(2)
+	copy(buf[:n], []byte{byte(val >> 8), byte(val & 0xff)})
 
-	return nil
+	return err
 }
 
 // WriteRegister implements I2C.WriteRegister.
@@ -68,7 +76,13 @@ func (d *I2CDevice16) WriteRegister(r uint8, buf []byte) error {
 		d.c.Fatalf("register write [%#x] unknown register", r)
 	}
 
-	d.Registers[r] = uint16(buf[0])<<8 | uint16(buf[1])
+	// A partial write leaves the register untouched.
+	if _, err := d.Faults.inject(2); err != nil {
+		return err
+	}
+	if _, ok := d.Stuck[r]; !ok {
+		d.Registers[r] = uint16(buf[0])<<8 | uint16(buf[1])
+	}
 
 	return nil
 }
@@ -11,6 +11,11 @@ type I2CDevice8 struct {
 	// If Err is non-nil, it will be returned as the error from the
 	// I2C methods.
 	Err error
+	// Faults configures the faults injected into transactions.
+	Faults I2CFaults
+	// Stuck holds registers that always read as the given value, and
+	// ignore writes.
+	Stuck map[uint8]uint8
 }
 
 // NewI2CDevice returns a new mock I2C device.
@@ -39,8 +44,14 @@ func (d *I2CDevice8) ReadRegister(r uint8, buf []byte) error {
 		return d.Err
 	}
 	d.assertRegisterRange(r, buf)
-	copy(buf, d.Registers[r:])
-	return nil
+	n, err := d.Faults.inject(len(buf))
+	copy(buf[:n], d.Registers[r:])
+	for i := 0; i < n; i++ {
+		if v, ok := d.Stuck[r+uint8(i)]; ok {
+			buf[i] = v
+		}
+	}
+	return err
 }
 
 // WriteRegister implements I2C.WriteRegister.
@@ -49,8 +60,13 @@ func (d *I2CDevice8) WriteRegister(r uint8, buf []byte) error {
 		return d.Err
 	}
 	d.assertRegisterRange(r, buf)
-	copy(d.Registers[r:], buf)
-	return nil
+	n, err := d.Faults.inject(len(buf))
+	for i := 0; i < n; i++ {
+		if _, ok := d.Stuck[r+uint8(i)]; !ok {
+			d.Registers[int(r)+i] = buf[i]
+		}
+	}
+	return err
 }
 
 // Tx implements I2C.Tx.
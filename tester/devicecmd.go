@@ -34,6 +34,9 @@ type I2CDeviceCmd struct {
 	// If Err is non-nil, it will be returned as the error from the
 	// I2C methods.
 	Err error
+
+	// Faults configures the faults injected into transactions.
+	Faults I2CFaults
 }
 
 // NewI2CDeviceCmd returns a new mock I2C device.
@@ -73,8 +76,21 @@ func (d *I2CDeviceCmd) Tx(w, r []byte) error {
 		return d.Err
 	}
 
+	// A fault while the command is sent means the device never sees it,
+	// a fault while reading cuts the response short.
+	n, faultErr := d.Faults.inject(len(w) + len(r))
+	if faultErr != nil {
+		if n <= len(w) && len(w) != 0 {
+			return faultErr
+		}
+		r = r[:n-len(w)]
+	}
+
 	if len(w) == 0 && len(d.pendingResponse) != 0 {
-		return d.respond(r)
+		if err := d.respond(r); err != nil {
+			return err
+		}
+		return faultErr
 	}
 
 	cmd := d.FindCommand(w)
@@ -85,7 +101,10 @@ func (d *I2CDeviceCmd) Tx(w, r []byte) error {
 
 	cmd.Invocations++
 	d.pendingResponse = cmd.Response
-	return d.respond(r)
+	if err := d.respond(r); err != nil {
+		return err
+	}
+	return faultErr
 }
 
 func (d *I2CDeviceCmd) FindCommand(command []byte) *Cmd {
@@ -0,0 +1,383 @@
+package tester
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"os"
+	"strings"
+
+	"tinygo.org/x/drivers"
+	"tinygo.org/x/drivers/pixel"
+)
+
+// The color models below emulate the colors that can be shown by the
+// displays. They convert colors the same way as the drivers.
+var (
+	// RGB565Model quantizes colors to 16 bits, as sent to the TFT and OLED
+	// color displays.
+	RGB565Model color.Model = color.ModelFunc(rgb565Model)
+
+	// MonochromeModel converts colors to black or white: a pixel is on
+	// unless the color is black, as with the ssd1306 and pcd8544 displays.
+	MonochromeModel color.Model = color.ModelFunc(monochromeModel)
+
+	// TriColorModel converts colors to the white, black and red palette of
+	// the tri-color e-paper displays, such as the epd2in13x: pure reds are
+	// red, black is white (the pixel is off) and other colors are black.
+	TriColorModel color.Model = color.ModelFunc(triColorModel)
+)
+
+var (
+	black = color.RGBA{0, 0, 0, 255}
+	white = color.RGBA{255, 255, 255, 255}
+	red   = color.RGBA{255, 0, 0, 255}
+)
+
+func rgb565Model(c color.Color) color.Color {
+	return pixel.RGB565ToRGBA(pixel.RGB565(color.RGBAModel.Convert(c).(color.RGBA)))
+}
+
+func monochromeModel(c color.Color) color.Color {
+	rgba := color.RGBAModel.Convert(c).(color.RGBA)
+	if rgba.R != 0 || rgba.G != 0 || rgba.B != 0 {
+		return white
+	}
+	return black
+}
+
+func triColorModel(c color.Color) color.Color {
+	rgba := color.RGBAModel.Convert(c).(color.RGBA)
+	switch {
+	case rgba.R != 0 && rgba.G == 0 && rgba.B == 0:
+		return red
+	case rgba.G != 0 || rgba.B != 0:
+		return black
+	}
+	return white
+}
+
+// Display is a virtual display that draws in memory, to test the code that
+// uses a drivers.Displayer without hardware. It also implements
+// drivers.AcceleratedDisplayer.
+//
+// The frames can be saved as PNG and compared with golden images, usually in
+// the testdata directory:
+//
+//	d := tester.NewDisplay(128, 64, tester.MonochromeModel)
+//	drawMenu(d)
+//	d.AssertPNG(t, "testdata/menu.png")
+//
+// The memory of the display is in the orientation of the panel: drawing
+// with a rotation rotates the content of the frames.
+type Display struct {
+	mem      *image.RGBA
+	model    color.Model
+	rotation drivers.Rotation
+
+	scrolling   bool
+	top, bottom int16
+	scroll      int16
+
+	// Displays is the number of calls to Display.
+	Displays int
+
+	// Err, if non-nil, is returned by Display and the drawing methods that
+	// return an error, which then have no effect.
+	Err error
+}
+
+// NewDisplay returns a display of the given size, cleared to black. The
+// colors are converted with model, to emulate the colors of a display; a nil
+// model keeps the colors as they are. Black is converted too, so that a
+// tri-color display starts white.
+func NewDisplay(width, height int16, model color.Model) *Display {
+	d := &Display{
+		mem:   image.NewRGBA(image.Rect(0, 0, int(width), int(height))),
+		model: model,
+	}
+	draw.Draw(d.mem, d.mem.Rect, image.NewUniform(d.convert(black)), image.Point{}, draw.Src)
+	return d
+}
+
+// Size implements drivers.Displayer. The width and height are swapped when
+// the display is rotated by 90 or 270 degrees.
+func (d *Display) Size() (x, y int16) {
+	w, h := int16(d.mem.Rect.Dx()), int16(d.mem.Rect.Dy())
+	if d.rotation&1 != 0 {
+		return h, w
+	}
+	return w, h
+}
+
+// SetPixel implements drivers.Displayer. Pixels outside the display are
+// ignored, as with the drivers.
+func (d *Display) SetPixel(x, y int16, c color.RGBA) {
+	w, h := d.Size()
+	if x < 0 || y < 0 || x >= w || y >= h {
+		return
+	}
+	d.set(x, y, d.convert(c))
+}
+
+// GetPixel returns the color of a pixel, as converted by the model of the
+// display. It returns black for the pixels outside the display.
+func (d *Display) GetPixel(x, y int16) color.RGBA {
+	w, h := d.Size()
+	if x < 0 || y < 0 || x >= w || y >= h {
+		return black
+	}
+	px, py := d.physical(x, y)
+	return d.mem.RGBAAt(px, py)
+}
+
+// Display implements drivers.Displayer.
+func (d *Display) Display() error {
+	if d.Err != nil {
+		return d.Err
+	}
+	d.Displays++
+	return nil
+}
+
+// FillRectangle implements drivers.AcceleratedDisplayer.
+func (d *Display) FillRectangle(x, y, width, height int16, c color.RGBA) error {
+	if err := d.check(x, y, width, height); err != nil {
+		return err
+	}
+	c = d.convert(c)
+	for j := y; j < y+height; j++ {
+		for i := x; i < x+width; i++ {
+			d.set(i, j, c)
+		}
+	}
+	return nil
+}
+
+// FillRectangleWithBuffer copies an image of width*height colors, stored
+// row by row, to the given position.
+func (d *Display) FillRectangleWithBuffer(x, y, width, height int16, buffer []color.RGBA) error {
+	if err := d.check(x, y, width, height); err != nil {
+		return err
+	}
+	if len(buffer) != int(width)*int(height) {
+		return drivers.ErrBufferSize
+	}
+	for j := int16(0); j < height; j++ {
+		for i := int16(0); i < width; i++ {
+			d.set(x+i, y+j, d.convert(buffer[int(j)*int(width)+int(i)]))
+		}
+	}
+	return nil
+}
+
+// DrawRGBBitmap implements drivers.AcceleratedDisplayer.
+func (d *Display) DrawRGBBitmap(x, y int16, data []uint16, w, h int16) error {
+	if err := d.check(x, y, w, h); err != nil {
+		return err
+	}
+	if len(data) != int(w)*int(h) {
+		return drivers.ErrBufferSize
+	}
+	for j := int16(0); j < h; j++ {
+		for i := int16(0); i < w; i++ {
+			d.set(x+i, y+j, d.convert(pixel.RGB565ToRGBA(data[int(j)*int(w)+int(i)])))
+		}
+	}
+	return nil
+}
+
+// SetScrollArea implements drivers.AcceleratedDisplayer. The fixed areas are
+// counted in lines of the panel, whatever the rotation.
+func (d *Display) SetScrollArea(topFixedArea, bottomFixedArea int16) error {
+	if d.Err != nil {
+		return d.Err
+	}
+	if topFixedArea < 0 || bottomFixedArea < 0 || int(topFixedArea)+int(bottomFixedArea) >= d.mem.Rect.Dy() {
+		return drivers.ErrInvalidConfig
+	}
+	d.top, d.bottom = topFixedArea, bottomFixedArea
+	d.scroll = topFixedArea
+	d.scrolling = true
+	return nil
+}
+
+// SetScroll implements drivers.AcceleratedDisplayer. The frames show the
+// line of memory at the top of the scroll area, followed by the next lines
+// of the scroll area, wrapping around.
+func (d *Display) SetScroll(line int16) error {
+	if d.Err != nil {
+		return d.Err
+	}
+	d.scroll = line
+	d.scrolling = true
+	return nil
+}
+
+// StopScroll implements drivers.AcceleratedDisplayer.
+func (d *Display) StopScroll() error {
+	if d.Err != nil {
+		return d.Err
+	}
+	d.scrolling = false
+	d.top, d.bottom, d.scroll = 0, 0, 0
+	return nil
+}
+
+// Rotation implements drivers.AcceleratedDisplayer.
+func (d *Display) Rotation() drivers.Rotation {
+	return d.rotation
+}
+
+// SetRotation implements drivers.AcceleratedDisplayer.
+func (d *Display) SetRotation(rotation drivers.Rotation) error {
+	if d.Err != nil {
+		return d.Err
+	}
+	if rotation > drivers.Rotation270 {
+		return drivers.ErrInvalidConfig
+	}
+	d.rotation = rotation
+	return nil
+}
+
+// Memory returns the memory of the display, in the orientation of the panel.
+// It is not a copy: drawing on the display changes it.
+func (d *Display) Memory() *image.RGBA {
+	return d.mem
+}
+
+// Frame returns a copy of the image shown by the display, which is its
+// memory with the scrolling applied.
+func (d *Display) Frame() *image.RGBA {
+	frame := image.NewRGBA(d.mem.Rect)
+	copy(frame.Pix, d.mem.Pix)
+	if !d.scrolling {
+		return frame
+	}
+	height := int(d.mem.Rect.Dy()) - int(d.top) - int(d.bottom)
+	offset := (int(d.scroll) - int(d.top)) % height
+	if offset < 0 {
+		offset += height
+	}
+	for i := 0; i < height; i++ {
+		src := int(d.top) + (i+offset)%height
+		dst := int(d.top) + i
+		copy(frame.Pix[dst*frame.Stride:(dst+1)*frame.Stride], d.mem.Pix[src*d.mem.Stride:(src+1)*d.mem.Stride])
+	}
+	return frame
+}
+
+// WritePNG encodes the current frame as PNG.
+func (d *Display) WritePNG(w io.Writer) error {
+	return png.Encode(w, d.Frame())
+}
+
+// SavePNG saves the current frame as PNG in the named file, for example to
+// create or update a golden image.
+func (d *Display) SavePNG(name string) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := d.WritePNG(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// ComparePNG returns the number of pixels of the current frame that differ
+// from the image in the named PNG file. Images of a different size differ
+// in all their pixels.
+func (d *Display) ComparePNG(name string) (int, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	img, err := png.Decode(f)
+	if err != nil {
+		return 0, err
+	}
+	frame := d.Frame()
+	if img.Bounds().Size() != frame.Rect.Size() {
+		return frame.Rect.Dx() * frame.Rect.Dy(), nil
+	}
+	diff := 0
+	min := img.Bounds().Min
+	for y := 0; y < frame.Rect.Dy(); y++ {
+		for x := 0; x < frame.Rect.Dx(); x++ {
+			if color.RGBAModel.Convert(img.At(min.X+x, min.Y+y)) != frame.RGBAAt(x, y) {
+				diff++
+			}
+		}
+	}
+	return diff, nil
+}
+
+// AssertPNG flags an error if the current frame is not the same as the
+// image in the named PNG file. The frame is then saved next to it, with
+// the .actual.png extension, to be inspected or to replace the golden image.
+func (d *Display) AssertPNG(c Failer, name string) {
+	diff, err := d.ComparePNG(name)
+	if err != nil {
+		c.Fatalf("cannot compare with golden image: %v", err)
+		return
+	}
+	if diff == 0 {
+		return
+	}
+	actual := strings.TrimSuffix(name, ".png") + ".actual.png"
+	if err := d.SavePNG(actual); err != nil {
+		c.Fatalf("%d pixels differ from %s, cannot save frame: %v", diff, name, err)
+		return
+	}
+	c.Fatalf("%d pixels differ from %s, frame saved as %s", diff, name, actual)
+}
+
+// check returns the error of the display, or ErrOutOfBounds if the
+// rectangle is not entirely on the display.
+func (d *Display) check(x, y, width, height int16) error {
+	if d.Err != nil {
+		return d.Err
+	}
+	w, h := d.Size()
+	if x < 0 || y < 0 || width <= 0 || height <= 0 || x >= w || y >= h ||
+		int(x)+int(width) > int(w) || int(y)+int(height) > int(h) {
+		return drivers.ErrOutOfBounds
+	}
+	return nil
+}
+
+// convert converts a color with the model of the display.
+func (d *Display) convert(c color.RGBA) color.RGBA {
+	if d.model == nil {
+		return c
+	}
+	return color.RGBAModel.Convert(d.model.Convert(c)).(color.RGBA)
+}
+
+// set sets a pixel on the display, in rotated coordinates.
+func (d *Display) set(x, y int16, c color.RGBA) {
+	px, py := d.physical(x, y)
+	d.mem.SetRGBA(px, py, c)
+}
+
+// physical returns the position in memory of a pixel in rotated
+// coordinates. With a rotation of 90 degrees, the top left corner of the
+// content is shown at the top right corner of the panel.
+func (d *Display) physical(x, y int16) (int, int) {
+	w, h := d.mem.Rect.Dx(), d.mem.Rect.Dy()
+	switch d.rotation {
+	case drivers.Rotation90:
+		return w - 1 - int(y), int(x)
+	case drivers.Rotation180:
+		return w - 1 - int(x), h - 1 - int(y)
+	case drivers.Rotation270:
+		return int(y), h - 1 - int(x)
+	}
+	return int(x), int(y)
+}
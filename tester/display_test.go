@@ -0,0 +1,163 @@
+package tester
+
+import (
+	"bytes"
+	"errors"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"tinygo.org/x/drivers"
+)
+
+var _ drivers.AcceleratedDisplayer = (*Display)(nil)
+
+func TestDisplayModels(t *testing.T) {
+	c := qt.New(t)
+	gray := color.RGBA{0x80, 0x81, 0x82, 255}
+	tests := []struct {
+		model       color.Model
+		background  color.RGBA
+		black, gray color.RGBA
+		red         color.RGBA
+	}{
+		{nil, black, black, gray, red},
+		{RGB565Model, black, black, color.RGBA{0x84, 0x82, 0x84, 255}, red},
+		{MonochromeModel, black, black, white, white},
+		{TriColorModel, white, white, black, red},
+	}
+	for _, test := range tests {
+		d := NewDisplay(4, 2, test.model)
+		c.Assert(d.GetPixel(0, 0), qt.Equals, test.background)
+		d.SetPixel(0, 0, black)
+		d.SetPixel(1, 0, gray)
+		d.SetPixel(2, 0, red)
+		c.Assert(d.GetPixel(0, 0), qt.Equals, test.black)
+		c.Assert(d.GetPixel(1, 0), qt.Equals, test.gray)
+		c.Assert(d.GetPixel(2, 0), qt.Equals, test.red)
+	}
+}
+
+func TestDisplayDrawing(t *testing.T) {
+	c := qt.New(t)
+	d := NewDisplay(4, 3, nil)
+	d.SetPixel(-1, 0, white)
+	d.SetPixel(4, 0, white)
+	c.Assert(d.FillRectangle(1, 1, 3, 2, white), qt.IsNil)
+	c.Assert(d.GetPixel(0, 1), qt.Equals, black)
+	c.Assert(d.GetPixel(1, 1), qt.Equals, white)
+	c.Assert(d.GetPixel(3, 2), qt.Equals, white)
+
+	c.Assert(d.FillRectangle(2, 2, 3, 1, white), qt.Equals, drivers.ErrOutOfBounds)
+	c.Assert(d.FillRectangle(0, 0, 0, 1, white), qt.Equals, drivers.ErrOutOfBounds)
+	c.Assert(d.DrawRGBBitmap(0, 0, []uint16{0xF800, 0x07E0}, 2, 1), qt.IsNil)
+	c.Assert(d.GetPixel(0, 0), qt.Equals, red)
+	c.Assert(d.GetPixel(1, 0), qt.Equals, color.RGBA{0, 255, 0, 255})
+	c.Assert(d.DrawRGBBitmap(0, 0, []uint16{0xF800}, 2, 1), qt.Equals, drivers.ErrBufferSize)
+	c.Assert(d.FillRectangleWithBuffer(3, 0, 1, 2, []color.RGBA{red, red}), qt.IsNil)
+	c.Assert(d.GetPixel(3, 1), qt.Equals, red)
+
+	c.Assert(d.Display(), qt.IsNil)
+	c.Assert(d.Displays, qt.Equals, 1)
+	d.Err = errors.New("broken")
+	c.Assert(d.Display(), qt.ErrorMatches, "broken")
+	c.Assert(d.FillRectangle(0, 0, 1, 1, black), qt.ErrorMatches, "broken")
+	c.Assert(d.GetPixel(0, 0), qt.Equals, red)
+}
+
+func TestDisplayRotation(t *testing.T) {
+	c := qt.New(t)
+	for rotation, corner := range [][2]int{{0, 0}, {3, 0}, {3, 1}, {0, 1}} {
+		d := NewDisplay(4, 2, nil)
+		c.Assert(d.SetRotation(drivers.Rotation(rotation)), qt.IsNil)
+		c.Assert(d.Rotation(), qt.Equals, drivers.Rotation(rotation))
+		w, h := d.Size()
+		if rotation%2 == 1 {
+			c.Assert([]int16{w, h}, qt.DeepEquals, []int16{2, 4})
+		} else {
+			c.Assert([]int16{w, h}, qt.DeepEquals, []int16{4, 2})
+		}
+		// The top left corner of the content moves clockwise on the panel.
+		d.SetPixel(0, 0, white)
+		c.Assert(d.Memory().RGBAAt(corner[0], corner[1]), qt.Equals, white, qt.Commentf("rotation %d", rotation))
+		c.Assert(d.GetPixel(0, 0), qt.Equals, white)
+		c.Assert(d.FillRectangle(0, 0, w, h, red), qt.IsNil)
+	}
+	c.Assert(NewDisplay(4, 2, nil).SetRotation(4), qt.Equals, drivers.ErrInvalidConfig)
+}
+
+func TestDisplayScroll(t *testing.T) {
+	c := qt.New(t)
+	d := NewDisplay(1, 6, nil)
+	for y := int16(0); y < 6; y++ {
+		d.SetPixel(0, y, color.RGBA{uint8(y), 0, 0, 255})
+	}
+	rows := func() []uint8 {
+		frame := d.Frame()
+		var r []uint8
+		for y := 0; y < 6; y++ {
+			r = append(r, frame.RGBAAt(0, y).R)
+		}
+		return r
+	}
+	c.Assert(rows(), qt.DeepEquals, []uint8{0, 1, 2, 3, 4, 5})
+
+	// Lines 1 to 4 scroll, the first and last lines are fixed.
+	c.Assert(d.SetScrollArea(1, 1), qt.IsNil)
+	c.Assert(rows(), qt.DeepEquals, []uint8{0, 1, 2, 3, 4, 5})
+	c.Assert(d.SetScroll(3), qt.IsNil)
+	c.Assert(rows(), qt.DeepEquals, []uint8{0, 3, 4, 1, 2, 5})
+
+	// The memory is not changed.
+	c.Assert(d.GetPixel(0, 1), qt.Equals, color.RGBA{1, 0, 0, 255})
+
+	c.Assert(d.StopScroll(), qt.IsNil)
+	c.Assert(rows(), qt.DeepEquals, []uint8{0, 1, 2, 3, 4, 5})
+	c.Assert(d.SetScrollArea(3, 3), qt.Equals, drivers.ErrInvalidConfig)
+}
+
+func TestDisplayPNG(t *testing.T) {
+	c := qt.New(t)
+	d := NewDisplay(8, 4, RGB565Model)
+	c.Assert(d.FillRectangle(2, 1, 4, 2, color.RGBA{0x12, 0x34, 0x56, 255}), qt.IsNil)
+
+	var buf bytes.Buffer
+	c.Assert(d.WritePNG(&buf), qt.IsNil)
+	img, err := png.Decode(&buf)
+	c.Assert(err, qt.IsNil)
+	c.Assert(img.Bounds().Dx(), qt.Equals, 8)
+	c.Assert(color.RGBAModel.Convert(img.At(2, 1)), qt.Equals, d.GetPixel(2, 1))
+
+	golden := filepath.Join(t.TempDir(), "frame.png")
+	c.Assert(d.SavePNG(golden), qt.IsNil)
+	diff, err := d.ComparePNG(golden)
+	c.Assert(err, qt.IsNil)
+	c.Assert(diff, qt.Equals, 0)
+	d.AssertPNG(c, golden)
+
+	// A different frame fails and is saved next to the golden image.
+	d.SetPixel(0, 0, white)
+	d.SetPixel(7, 3, white)
+	diff, err = d.ComparePNG(golden)
+	c.Assert(err, qt.IsNil)
+	c.Assert(diff, qt.Equals, 2)
+
+	f := &recordingFailer{}
+	d.AssertPNG(f, golden)
+	c.Assert(f.failures, qt.DeepEquals, []string{
+		"2 pixels differ from " + golden + ", frame saved as " + golden[:len(golden)-4] + ".actual.png",
+	})
+	_, err = os.Stat(golden[:len(golden)-4] + ".actual.png")
+	c.Assert(err, qt.IsNil)
+
+	// Images of a different size differ everywhere.
+	diff, err = NewDisplay(4, 4, nil).ComparePNG(golden)
+	c.Assert(err, qt.IsNil)
+	c.Assert(diff, qt.Equals, 16)
+
+	_, err = d.ComparePNG(filepath.Join(t.TempDir(), "missing.png"))
+	c.Assert(err, qt.Not(qt.IsNil))
+}
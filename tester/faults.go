@@ -0,0 +1,57 @@
+package tester
+
+import (
+	"errors"
+	"math/rand"
+)
+
+var (
+	// ErrNACK is returned by a mock I2C bus when a device does not
+	// acknowledge its address.
+	ErrNACK = errors.New("i2c: address not acknowledged")
+
+	// ErrBusFault is returned by a mock I2C device when a fault has been
+	// injected into a transaction.
+	ErrBusFault = errors.New("i2c: bus fault")
+)
+
+// I2CFaults configures the faults injected into the transactions of a mock
+// I2C device. The zero value injects no fault.
+type I2CFaults struct {
+	// FailAfter, if non-zero, makes the device fail with ErrBusFault once
+	// it has transferred that many bytes. The transaction that reaches the
+	// limit is cut short: only the bytes up to the limit are transferred.
+	FailAfter int
+
+	// Transferred is the number of bytes transferred by the device so far.
+	Transferred int
+
+	rate float64
+	rand *rand.Rand
+}
+
+// FailRandomly makes transactions fail with ErrBusFault with probability p,
+// between 0 and 1. The failures are drawn from a pseudo-random generator
+// initialized with seed, so a test sees the same failures on every run.
+func (f *I2CFaults) FailRandomly(p float64, seed int64) {
+	f.rate = p
+	f.rand = rand.New(rand.NewSource(seed))
+}
+
+// inject returns how many of the n bytes of a transaction can be
+// transferred, and the error that the transaction fails with, if any.
+func (f *I2CFaults) inject(n int) (int, error) {
+	if f.rand != nil && f.rand.Float64() < f.rate {
+		return 0, ErrBusFault
+	}
+	if f.FailAfter != 0 && f.Transferred+n > f.FailAfter {
+		n = f.FailAfter - f.Transferred
+		if n < 0 {
+			n = 0
+		}
+		f.Transferred += n
+		return n, ErrBusFault
+	}
+	f.Transferred += n
+	return n, nil
+}
@@ -0,0 +1,98 @@
+package tester
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestI2CNACK(t *testing.T) {
+	c := qt.New(t)
+	bus := NewI2CBus(c)
+	bus.NewDevice(0x20)
+	buf := make([]byte, 1)
+
+	bus.NACK(0x20)
+	c.Assert(bus.ReadRegister(0x20, 0, buf), qt.Equals, ErrNACK)
+	c.Assert(bus.WriteRegister(0x20, 0, buf), qt.Equals, ErrNACK)
+	c.Assert(bus.Tx(0x20, buf, nil), qt.Equals, ErrNACK)
+
+	bus.ACK(0x20)
+	c.Assert(bus.ReadRegister(0x20, 0, buf), qt.IsNil)
+}
+
+func TestI2CNACKUnknown(t *testing.T) {
+	c := qt.New(t)
+	bus := NewI2CBus(c)
+	bus.NACKUnknown = true
+	bus.NewDevice(0x20)
+
+	c.Assert(bus.ReadRegister(0x21, 0, make([]byte, 1)), qt.Equals, ErrNACK)
+	c.Assert(bus.ReadRegister(0x20, 0, make([]byte, 1)), qt.IsNil)
+}
+
+func TestI2CFailAfter(t *testing.T) {
+	c := qt.New(t)
+	dev := NewI2CDevice8(c, 0x20)
+	dev.Registers[0] = 0x11
+	dev.Registers[1] = 0x22
+	dev.Registers[2] = 0x33
+	dev.Faults.FailAfter = 3
+
+	buf := make([]byte, 2)
+	c.Assert(dev.ReadRegister(0, buf), qt.IsNil)
+	c.Assert(buf, qt.DeepEquals, []byte{0x11, 0x22})
+
+	// Only the first byte of the second read gets through.
+	buf = make([]byte, 2)
+	c.Assert(dev.ReadRegister(1, buf), qt.Equals, ErrBusFault)
+	c.Assert(buf, qt.DeepEquals, []byte{0x22, 0})
+
+	c.Assert(dev.WriteRegister(0, []byte{0}), qt.Equals, ErrBusFault)
+	c.Assert(dev.Registers[0], qt.Equals, uint8(0x11))
+}
+
+func TestI2CFailRandomly(t *testing.T) {
+	c := qt.New(t)
+	failures := func() []bool {
+		dev := NewI2CDevice16(c, 0x40)
+		dev.Registers = map[uint8]uint16{0: 0x1234}
+		dev.Faults.FailRandomly(0.5, 1)
+		var failed []bool
+		for i := 0; i < 32; i++ {
+			err := dev.ReadRegister(0, make([]byte, 2))
+			failed = append(failed, err == ErrBusFault)
+		}
+		return failed
+	}
+
+	first := failures()
+	c.Assert(first, qt.Contains, true)
+	c.Assert(first, qt.Contains, false)
+	// The same seed gives the same failures.
+	c.Assert(failures(), qt.DeepEquals, first)
+}
+
+func TestI2CStuck(t *testing.T) {
+	c := qt.New(t)
+	dev := NewI2CDevice8(c, 0x20)
+	dev.Stuck = map[uint8]uint8{1: 0xff}
+
+	c.Assert(dev.WriteRegister(0, []byte{1, 2, 3}), qt.IsNil)
+	buf := make([]byte, 3)
+	c.Assert(dev.ReadRegister(0, buf), qt.IsNil)
+	c.Assert(buf, qt.DeepEquals, []byte{1, 0xff, 3})
+}
+
+func TestI2CCmdFault(t *testing.T) {
+	c := qt.New(t)
+	dev := NewI2CDeviceCmd(c, 0x38)
+	dev.Commands = map[uint8]*Cmd{
+		0x71: {Command: []byte{0x71}, Mask: []byte{0xff}, Response: []byte{0x18, 0x1c}},
+	}
+	dev.Faults.FailAfter = 2
+
+	r := make([]byte, 2)
+	c.Assert(dev.Tx([]byte{0x71}, r), qt.Equals, ErrBusFault)
+	c.Assert(r, qt.DeepEquals, []byte{0x18, 0})
+}
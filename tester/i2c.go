@@ -6,6 +6,12 @@ import "fmt"
 type I2CBus struct {
 	c       Failer
 	devices []I2CDevice
+	nacks   map[uint8]bool
+
+	// NACKUnknown makes transactions to an address with no device fail
+	// with ErrNACK, as they would on a real bus, instead of flagging an
+	// error.
+	NACKUnknown bool
 }
 
 // NewI2CBus returns an I2CBus mock I2C instance that uses c to flag errors
@@ -36,19 +42,45 @@ func (bus *I2CBus) NewDevice(addr uint8) *I2CDevice8 {
 	return dev
 }
 
+// NACK makes every transaction to the given address fail with ErrNACK, as
+// if the device had stopped answering, until it is cleared with ACK.
+func (bus *I2CBus) NACK(addr uint8) {
+	if bus.nacks == nil {
+		bus.nacks = make(map[uint8]bool)
+	}
+	bus.nacks[addr] = true
+}
+
+// ACK clears a NACK set on the given address.
+func (bus *I2CBus) ACK(addr uint8) {
+	delete(bus.nacks, addr)
+}
+
 // ReadRegister implements I2C.ReadRegister.
 func (bus *I2CBus) ReadRegister(addr uint8, r uint8, buf []byte) error {
-	return bus.FindDevice(addr).ReadRegister(r, buf)
+	dev, err := bus.device(addr)
+	if err != nil {
+		return err
+	}
+	return dev.ReadRegister(r, buf)
 }
 
 // WriteRegister implements I2C.WriteRegister.
 func (bus *I2CBus) WriteRegister(addr uint8, r uint8, buf []byte) error {
-	return bus.FindDevice(addr).WriteRegister(r, buf)
+	dev, err := bus.device(addr)
+	if err != nil {
+		return err
+	}
+	return dev.WriteRegister(r, buf)
 }
 
 // Tx implements I2C.Tx.
 func (bus *I2CBus) Tx(addr uint16, w, r []byte) error {
-	return bus.FindDevice(uint8(addr)).Tx(w, r)
+	dev, err := bus.device(uint8(addr))
+	if err != nil {
+		return err
+	}
+	return dev.Tx(w, r)
 }
 
 // FindDevice returns the device with the given address.
@@ -61,3 +93,20 @@ func (bus *I2CBus) FindDevice(addr uint8) I2CDevice {
 	bus.c.Fatalf("invalid device addr %#x passed to i2c bus", addr)
 	panic("unreachable")
 }
+
+// device returns the device that acknowledges the given address, or
+// ErrNACK if there is none and the bus is set up to report it.
+func (bus *I2CBus) device(addr uint8) (I2CDevice, error) {
+	if bus.nacks[addr] {
+		return nil, ErrNACK
+	}
+	if bus.NACKUnknown {
+		for _, dev := range bus.devices {
+			if dev.Addr() == addr {
+				return dev, nil
+			}
+		}
+		return nil, ErrNACK
+	}
+	return bus.FindDevice(addr), nil
+}
@@ -0,0 +1,105 @@
+package tester
+
+import (
+	"time"
+
+	"tinygo.org/x/drivers"
+)
+
+// PinEvent is a level set on a mock Pin.
+type PinEvent struct {
+	// At is the time the level was set, relative to the creation or last
+	// reset of the pin, on the clock of the pin.
+	At time.Duration
+	// Level is the level the pin was driven to.
+	Level bool
+}
+
+// Pin implements the drivers.Pin interface in memory for testing. It
+// records the level set by the code under test on a timeline.
+type Pin struct {
+	clock      drivers.Clock
+	start      time.Time
+	mode       drivers.PinMode
+	configured bool
+	level      bool
+
+	// Input, if non-nil, returns the level read by Get. It can be used to
+	// simulate a device that drives the pin, for example a busy line.
+	// Otherwise Get returns the last level set on the pin.
+	Input func() bool
+
+	// Events holds every level set on the pin, in order, including the
+	// calls to Set that do not change the level.
+	Events []PinEvent
+}
+
+// NewPin returns a new mock pin that records its timeline on the system
+// clock.
+func NewPin() *Pin {
+	return NewPinWithClock(drivers.SystemClock)
+}
+
+// NewPinWithClock returns a new mock pin that records its timeline on the
+// given clock, such as a tester.Clock shared with the driver under test.
+func NewPinWithClock(clock drivers.Clock) *Pin {
+	return &Pin{
+		clock: clock,
+		start: clock.Now(),
+	}
+}
+
+// Configure implements drivers.ConfigurablePin.
+func (p *Pin) Configure(config drivers.PinConfig) {
+	p.mode = config.Mode
+	p.configured = true
+}
+
+// Mode returns the mode the pin was configured to.
+func (p *Pin) Mode() drivers.PinMode {
+	return p.mode
+}
+
+// Configured returns whether the pin has been configured.
+func (p *Pin) Configured() bool {
+	return p.configured
+}
+
+// Get implements drivers.Pin.
+func (p *Pin) Get() bool {
+	if p.Input != nil {
+		return p.Input()
+	}
+	return p.level
+}
+
+// Set implements drivers.Pin.
+func (p *Pin) Set(high bool) {
+	p.level = high
+	p.Events = append(p.Events, PinEvent{At: p.clock.Now().Sub(p.start), Level: high})
+}
+
+// High implements drivers.Pin.
+func (p *Pin) High() {
+	p.Set(true)
+}
+
+// Low implements drivers.Pin.
+func (p *Pin) Low() {
+	p.Set(false)
+}
+
+// Levels returns the levels the pin was driven to, in order.
+func (p *Pin) Levels() []bool {
+	levels := make([]bool, len(p.Events))
+	for i, e := range p.Events {
+		levels[i] = e.Level
+	}
+	return levels
+}
+
+// Reset clears the recorded events and restarts the timeline.
+func (p *Pin) Reset() {
+	p.Events = nil
+	p.start = p.clock.Now()
+}
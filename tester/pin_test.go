@@ -0,0 +1,78 @@
+package tester
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"tinygo.org/x/drivers"
+)
+
+func TestPinConfigure(t *testing.T) {
+	c := qt.New(t)
+	p := NewPin()
+	c.Assert(p.Configured(), qt.Equals, false)
+
+	drivers.ConfigurePin(p, drivers.PinInputPullup)
+	c.Assert(p.Configured(), qt.Equals, true)
+	c.Assert(p.Mode(), qt.Equals, drivers.PinInputPullup)
+}
+
+func TestPinLevels(t *testing.T) {
+	c := qt.New(t)
+	p := NewPin()
+
+	p.High()
+	c.Assert(p.Get(), qt.Equals, true)
+	p.Low()
+	c.Assert(p.Get(), qt.Equals, false)
+	p.Set(true)
+
+	c.Assert(p.Levels(), qt.DeepEquals, []bool{true, false, true})
+	c.Assert(p.Events[2].At >= p.Events[0].At, qt.Equals, true)
+
+	p.Reset()
+	c.Assert(p.Events, qt.HasLen, 0)
+}
+
+func TestPinTimeline(t *testing.T) {
+	c := qt.New(t)
+	clock := NewClock()
+	p := NewPinWithClock(clock)
+
+	clock.Advance(time.Millisecond)
+	p.High()
+	clock.Sleep(500 * time.Microsecond)
+	p.High()
+	p.Low()
+	c.Assert(p.Events, qt.DeepEquals, []PinEvent{
+		{At: time.Millisecond, Level: true},
+		{At: 1500 * time.Microsecond, Level: true},
+		{At: 1500 * time.Microsecond, Level: false},
+	})
+
+	p.Reset()
+	clock.Advance(time.Millisecond)
+	p.High()
+	c.Assert(p.Events, qt.DeepEquals, []PinEvent{{At: time.Millisecond, Level: true}})
+}
+
+func TestPinInput(t *testing.T) {
+	c := qt.New(t)
+	p := NewPin()
+	polls := 0
+	p.Input = func() bool {
+		polls++
+		return polls > 2
+	}
+
+	c.Assert(p.Get(), qt.Equals, false)
+	c.Assert(p.Get(), qt.Equals, false)
+	c.Assert(p.Get(), qt.Equals, true)
+}
+
+func TestIsNoPin(t *testing.T) {
+	c := qt.New(t)
+	c.Assert(drivers.IsNoPin(nil), qt.Equals, true)
+	c.Assert(drivers.IsNoPin(NewPin()), qt.Equals, false)
+}
@@ -0,0 +1,55 @@
+package tester
+
+// PWM implements the drivers.PWM interface in memory for testing. It records
+// the period and values set by the code under test.
+type PWM struct {
+	top uint32
+
+	// Period is the last period set with SetPeriod.
+	Period uint64
+
+	// Values holds the values passed to Set, in order.
+	Values []uint32
+
+	// Err, if non-nil, is returned by SetPeriod and Set, which then have no
+	// effect.
+	Err error
+}
+
+// NewPWM returns a new mock PWM output whose Top method returns top.
+func NewPWM(top uint32) *PWM {
+	return &PWM{
+		top: top,
+	}
+}
+
+// SetPeriod implements drivers.PWM.
+func (p *PWM) SetPeriod(period uint64) error {
+	if p.Err != nil {
+		return p.Err
+	}
+	p.Period = period
+	return nil
+}
+
+// Top implements drivers.PWM.
+func (p *PWM) Top() uint32 {
+	return p.top
+}
+
+// Set implements drivers.PWM.
+func (p *PWM) Set(value uint32) error {
+	if p.Err != nil {
+		return p.Err
+	}
+	p.Values = append(p.Values, value)
+	return nil
+}
+
+// Value returns the last value set, or 0 if none was set.
+func (p *PWM) Value() uint32 {
+	if len(p.Values) == 0 {
+		return 0
+	}
+	return p.Values[len(p.Values)-1]
+}
@@ -0,0 +1,29 @@
+package tester
+
+import (
+	"errors"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"tinygo.org/x/drivers"
+)
+
+var _ drivers.PWM = (*PWM)(nil)
+
+func TestPWM(t *testing.T) {
+	c := qt.New(t)
+	p := NewPWM(100)
+	c.Assert(p.Top(), qt.Equals, uint32(100))
+	c.Assert(p.Value(), qt.Equals, uint32(0))
+
+	c.Assert(p.SetPeriod(1e6), qt.IsNil)
+	c.Assert(p.Set(25), qt.IsNil)
+	c.Assert(p.Set(50), qt.IsNil)
+	c.Assert(p.Period, qt.Equals, uint64(1e6))
+	c.Assert(p.Values, qt.DeepEquals, []uint32{25, 50})
+	c.Assert(p.Value(), qt.Equals, uint32(50))
+
+	p.Err = errors.New("broken")
+	c.Assert(p.Set(75), qt.ErrorMatches, "broken")
+	c.Assert(p.Value(), qt.Equals, uint32(50))
+}
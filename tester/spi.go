@@ -0,0 +1,140 @@
+package tester
+
+import "bytes"
+
+// SPITransaction is a single transaction seen by a mock SPI bus. Calls to
+// Transfer are recorded as a transaction of one byte.
+type SPITransaction struct {
+	// W holds the bytes sent by the code under test. When it only received
+	// data, W holds the zero bytes that were clocked out.
+	W []byte
+	// R holds the bytes returned to the code under test.
+	R []byte
+}
+
+// SPIBus implements the SPI interface in memory for testing.
+//
+// Tests script the bus by queuing the transactions they expect the code
+// under test to perform with Expect and ExpectTransfer. Each transaction on
+// the bus is matched against the next expectation in order, and receives the
+// canned response of that expectation. Every transaction is appended to Log.
+type SPIBus struct {
+	c        Failer
+	expected []SPITransaction
+
+	// Log holds every transaction performed on the bus, in order.
+	Log []SPITransaction
+
+	// If AllowUnscripted is true, transactions performed after all
+	// expectations have been consumed are accepted and read as zeros.
+	// Otherwise they are treated as an error.
+	AllowUnscripted bool
+
+	// If Err is non-nil, it will be returned as the error from the
+	// SPI methods.
+	Err error
+}
+
+// NewSPIBus returns an SPIBus mock SPI instance that uses c to flag errors
+// if they happen.
+func NewSPIBus(c Failer) *SPIBus {
+	return &SPIBus{
+		c: c,
+	}
+}
+
+// Expect queues a transaction that the code under test is expected to
+// perform. If w is nil the written bytes are not checked. The transaction
+// will read r, or zeros if r is nil.
+func (bus *SPIBus) Expect(w, r []byte) {
+	bus.expected = append(bus.expected, SPITransaction{W: w, R: r})
+}
+
+// ExpectTransfer queues a single byte transaction that writes w and reads r.
+func (bus *SPIBus) ExpectTransfer(w, r byte) {
+	bus.Expect([]byte{w}, []byte{r})
+}
+
+// Pending returns the number of expectations that have not been consumed.
+func (bus *SPIBus) Pending() int {
+	return len(bus.expected)
+}
+
+// AssertDone flags an error if some expectations have not been consumed.
+func (bus *SPIBus) AssertDone() {
+	if len(bus.expected) != 0 {
+		bus.c.Fatalf("%d expected spi transactions not performed, next: %#x", len(bus.expected), bus.expected[0].W)
+	}
+}
+
+// Written returns all bytes written on the bus so far, concatenated.
+func (bus *SPIBus) Written() []byte {
+	var buf []byte
+	for _, tx := range bus.Log {
+		buf = append(buf, tx.W...)
+	}
+	return buf
+}
+
+// Reset clears the transaction log and any pending expectations.
+func (bus *SPIBus) Reset() {
+	bus.expected = nil
+	bus.Log = nil
+}
+
+// Tx implements SPI.Tx.
+func (bus *SPIBus) Tx(w, r []byte) error {
+	if bus.Err != nil {
+		return bus.Err
+	}
+
+	if w != nil && r != nil && len(w) != len(r) {
+		bus.c.Fatalf("spi tx with mismatched buffers (w: %d bytes, r: %d bytes)", len(w), len(r))
+	}
+
+	n := len(w)
+	if w == nil {
+		n = len(r)
+	}
+	sent := make([]byte, n)
+	copy(sent, w)
+
+	resp := bus.respond(sent)
+	copy(r, resp)
+	bus.Log = append(bus.Log, SPITransaction{W: sent, R: resp})
+	return nil
+}
+
+// Transfer implements SPI.Transfer.
+func (bus *SPIBus) Transfer(b byte) (byte, error) {
+	var r [1]byte
+	err := bus.Tx([]byte{b}, r[:])
+	return r[0], err
+}
+
+// respond matches the sent bytes against the next expectation and returns
+// the bytes read by the transaction.
+func (bus *SPIBus) respond(sent []byte) []byte {
+	resp := make([]byte, len(sent))
+
+	if len(bus.expected) == 0 {
+		if !bus.AllowUnscripted {
+			bus.c.Fatalf("unexpected spi transaction %#x", sent)
+		}
+		return resp
+	}
+
+	exp := bus.expected[0]
+	bus.expected = bus.expected[1:]
+
+	if exp.W != nil && !bytes.Equal(exp.W, sent) {
+		bus.c.Fatalf("spi transaction mismatch (expected: %#x, got: %#x)", exp.W, sent)
+	}
+	if exp.R != nil {
+		if len(exp.R) != len(sent) {
+			bus.c.Fatalf("spi response size mismatch (expected: %d bytes, got: %d bytes)", len(exp.R), len(sent))
+		}
+		copy(resp, exp.R)
+	}
+	return resp
+}
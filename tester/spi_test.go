@@ -0,0 +1,97 @@
+package tester
+
+import (
+	"errors"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestSPIScripted(t *testing.T) {
+	c := qt.New(t)
+	bus := NewSPIBus(c)
+	bus.Expect([]byte{0x9f, 0, 0, 0}, []byte{0, 0xef, 0x40, 0x18})
+	bus.ExpectTransfer(0x05, 0x02)
+
+	r := make([]byte, 4)
+	err := bus.Tx([]byte{0x9f, 0, 0, 0}, r)
+	c.Assert(err, qt.IsNil)
+	c.Assert(r, qt.DeepEquals, []byte{0, 0xef, 0x40, 0x18})
+
+	b, err := bus.Transfer(0x05)
+	c.Assert(err, qt.IsNil)
+	c.Assert(b, qt.Equals, byte(0x02))
+
+	bus.AssertDone()
+	c.Assert(bus.Log, qt.HasLen, 2)
+	c.Assert(bus.Written(), qt.DeepEquals, []byte{0x9f, 0, 0, 0, 0x05})
+}
+
+func TestSPIReadOnly(t *testing.T) {
+	c := qt.New(t)
+	bus := NewSPIBus(c)
+	bus.Expect(nil, []byte{1, 2, 3})
+
+	r := make([]byte, 3)
+	err := bus.Tx(nil, r)
+	c.Assert(err, qt.IsNil)
+	c.Assert(r, qt.DeepEquals, []byte{1, 2, 3})
+	c.Assert(bus.Log[0].W, qt.DeepEquals, []byte{0, 0, 0})
+}
+
+func TestSPIMismatch(t *testing.T) {
+	c := qt.New(t)
+	f := &recordingFailer{}
+	bus := NewSPIBus(f)
+	bus.Expect([]byte{0x01}, nil)
+
+	bus.Transfer(0x02)
+	c.Assert(f.failures, qt.HasLen, 1)
+	c.Assert(f.failures[0], qt.Contains, "mismatch")
+
+	// The response is scripted for one byte, the transaction has two.
+	f.failures = nil
+	bus.Expect(nil, []byte{0x03})
+	bus.Tx(nil, make([]byte, 2))
+	c.Assert(f.failures, qt.DeepEquals, []string{
+		"spi response size mismatch (expected: 1 bytes, got: 2 bytes)",
+	})
+}
+
+func TestSPIUnscripted(t *testing.T) {
+	c := qt.New(t)
+	f := &recordingFailer{}
+	bus := NewSPIBus(f)
+
+	bus.Tx([]byte{1, 2}, nil)
+	c.Assert(f.failures, qt.HasLen, 1)
+
+	f.failures = nil
+	bus.AllowUnscripted = true
+	r := []byte{0xff, 0xff}
+	bus.Tx([]byte{1, 2}, r)
+	c.Assert(f.failures, qt.HasLen, 0)
+	c.Assert(r, qt.DeepEquals, []byte{0, 0})
+	c.Assert(bus.Log, qt.HasLen, 2)
+}
+
+func TestSPINotDone(t *testing.T) {
+	c := qt.New(t)
+	f := &recordingFailer{}
+	bus := NewSPIBus(f)
+	bus.ExpectTransfer(0x01, 0)
+
+	c.Assert(bus.Pending(), qt.Equals, 1)
+	bus.AssertDone()
+	c.Assert(f.failures, qt.HasLen, 1)
+}
+
+func TestSPIErr(t *testing.T) {
+	c := qt.New(t)
+	bus := NewSPIBus(c)
+	bus.Err = errors.New("bus error")
+
+	_, err := bus.Transfer(0x01)
+	c.Assert(err, qt.Equals, bus.Err)
+	c.Assert(bus.Log, qt.HasLen, 0)
+}
@@ -0,0 +1,12 @@
+package tester
+
+import "fmt"
+
+// recordingFailer is a Failer that records failures instead of aborting.
+type recordingFailer struct {
+	failures []string
+}
+
+func (f *recordingFailer) Fatalf(format string, a ...interface{}) {
+	f.failures = append(f.failures, fmt.Sprintf(format, a...))
+}
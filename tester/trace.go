@@ -0,0 +1,298 @@
+package tester
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"tinygo.org/x/drivers"
+)
+
+// I2COpKind is the kind of an I2C bus call.
+type I2COpKind uint8
+
+const (
+	I2CRead I2COpKind = iota
+	I2CWrite
+	I2CTx
+)
+
+// String returns the name of the kind, as used in traces.
+func (k I2COpKind) String() string {
+	switch k {
+	case I2CRead:
+		return "read"
+	case I2CWrite:
+		return "write"
+	case I2CTx:
+		return "tx"
+	}
+	return "unknown"
+}
+
+// I2COp is a single call on an I2C bus, as recorded by I2CRecorder.
+//
+// In a trace, an operation is written on a single line in one of the
+// following forms, where data is in hexadecimal and the err field is only
+// present when the call failed:
+//
+//	read 0x39 reg=0x92 r=ab
+//	write 0x39 reg=0x80 w=05
+//	tx 0x29 w=010f r=eacc err="i2c: bus fault"
+//
+// Empty lines and lines starting with '#' are ignored.
+type I2COp struct {
+	Kind I2COpKind
+	Addr uint16
+	// Reg is the register of a read or write.
+	Reg uint8
+	// W is the data written to the device.
+	W []byte
+	// R is the data read from the device.
+	R []byte
+	// Err is the message of the error returned by the call, if any.
+	Err string
+
+	// line is the line of the operation in the trace it was parsed from.
+	line int
+}
+
+// String returns the operation in the trace format.
+func (op I2COp) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %#02x", op.Kind, op.Addr)
+	if op.Kind != I2CTx {
+		fmt.Fprintf(&b, " reg=%#02x", op.Reg)
+	}
+	if len(op.W) != 0 {
+		fmt.Fprintf(&b, " w=%x", op.W)
+	}
+	if len(op.R) != 0 {
+		fmt.Fprintf(&b, " r=%x", op.R)
+	}
+	if op.Err != "" {
+		fmt.Fprintf(&b, " err=%s", strconv.Quote(op.Err))
+	}
+	return b.String()
+}
+
+// I2CRecorder wraps an I2C bus and writes every call made on it to a trace,
+// in the format described by I2COp. It can be used on real hardware to
+// capture the traffic of a driver, for example by writing the trace to the
+// serial console, and the trace can later be replayed with I2CReplay.
+type I2CRecorder struct {
+	bus drivers.I2C
+	w   io.Writer
+
+	// Err holds the first error that happened while writing the trace.
+	Err error
+}
+
+// NewI2CRecorder returns a recorder that forwards calls to bus and writes
+// them to w.
+func NewI2CRecorder(bus drivers.I2C, w io.Writer) *I2CRecorder {
+	return &I2CRecorder{
+		bus: bus,
+		w:   w,
+	}
+}
+
+// ReadRegister implements I2C.ReadRegister.
+func (rec *I2CRecorder) ReadRegister(addr uint8, r uint8, buf []byte) error {
+	err := rec.bus.ReadRegister(addr, r, buf)
+	rec.record(I2COp{Kind: I2CRead, Addr: uint16(addr), Reg: r, R: buf}, err)
+	return err
+}
+
+// WriteRegister implements I2C.WriteRegister.
+func (rec *I2CRecorder) WriteRegister(addr uint8, r uint8, buf []byte) error {
+	err := rec.bus.WriteRegister(addr, r, buf)
+	rec.record(I2COp{Kind: I2CWrite, Addr: uint16(addr), Reg: r, W: buf}, err)
+	return err
+}
+
+// Tx implements I2C.Tx.
+func (rec *I2CRecorder) Tx(addr uint16, w, r []byte) error {
+	err := rec.bus.Tx(addr, w, r)
+	rec.record(I2COp{Kind: I2CTx, Addr: addr, W: w, R: r}, err)
+	return err
+}
+
+func (rec *I2CRecorder) record(op I2COp, err error) {
+	if err != nil {
+		op.Err = err.Error()
+	}
+	if _, werr := io.WriteString(rec.w, op.String()+"\n"); werr != nil && rec.Err == nil {
+		rec.Err = werr
+	}
+}
+
+// ParseI2CTrace parses a trace written by I2CRecorder.
+func ParseI2CTrace(r io.Reader) ([]I2COp, error) {
+	var ops []I2COp
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || text[0] == '#' {
+			continue
+		}
+		op, err := parseI2COp(text)
+		if err != nil {
+			return nil, fmt.Errorf("i2c trace line %d: %v", line, err)
+		}
+		op.line = line
+		ops = append(ops, op)
+	}
+	return ops, scanner.Err()
+}
+
+func parseI2COp(text string) (op I2COp, err error) {
+	// The error message is quoted and may contain spaces, so split it off
+	// before splitting the fields.
+	if i := strings.Index(text, " err="); i >= 0 {
+		op.Err, err = strconv.Unquote(text[i+len(" err="):])
+		if err != nil {
+			return op, fmt.Errorf("invalid error message: %v", err)
+		}
+		text = text[:i]
+	}
+
+	fields := strings.Fields(text)
+	if len(fields) < 2 {
+		return op, errors.New("missing address")
+	}
+	switch fields[0] {
+	case "read":
+		op.Kind = I2CRead
+	case "write":
+		op.Kind = I2CWrite
+	case "tx":
+		op.Kind = I2CTx
+	default:
+		return op, fmt.Errorf("unknown operation %q", fields[0])
+	}
+	addr, err := strconv.ParseUint(fields[1], 0, 16)
+	if err != nil {
+		return op, fmt.Errorf("invalid address: %v", err)
+	}
+	op.Addr = uint16(addr)
+
+	for _, field := range fields[2:] {
+		i := strings.IndexByte(field, '=')
+		if i < 0 {
+			return op, fmt.Errorf("invalid field %q", field)
+		}
+		key, value := field[:i], field[i+1:]
+		switch key {
+		case "reg":
+			reg, err := strconv.ParseUint(value, 0, 8)
+			if err != nil {
+				return op, fmt.Errorf("invalid register: %v", err)
+			}
+			op.Reg = uint8(reg)
+		case "w":
+			op.W, err = hex.DecodeString(value)
+		case "r":
+			op.R, err = hex.DecodeString(value)
+		default:
+			return op, fmt.Errorf("unknown field %q", key)
+		}
+		if err != nil {
+			return op, fmt.Errorf("invalid %s data: %v", key, err)
+		}
+	}
+	return op, nil
+}
+
+// I2CReplay implements the I2C interface by replaying a trace. Every call
+// made by the code under test must match the next operation of the trace,
+// which then provides the data read and the error returned.
+type I2CReplay struct {
+	c   Failer
+	ops []I2COp
+	pos int
+}
+
+// NewI2CReplay returns a bus that replays ops and uses c to flag calls that
+// do not match them.
+func NewI2CReplay(c Failer, ops []I2COp) *I2CReplay {
+	return &I2CReplay{
+		c:   c,
+		ops: ops,
+	}
+}
+
+// LoadI2CReplay returns a bus that replays the trace stored in the named
+// file, usually a golden file in the testdata directory of a driver.
+func LoadI2CReplay(c Failer, name string) *I2CReplay {
+	f, err := os.Open(name)
+	if err != nil {
+		c.Fatalf("cannot open i2c trace: %v", err)
+		return nil
+	}
+	defer f.Close()
+	ops, err := ParseI2CTrace(f)
+	if err != nil {
+		c.Fatalf("cannot parse i2c trace %s: %v", name, err)
+		return nil
+	}
+	return NewI2CReplay(c, ops)
+}
+
+// Pending returns the number of operations not yet replayed.
+func (p *I2CReplay) Pending() int {
+	return len(p.ops) - p.pos
+}
+
+// AssertDone flags an error if some operations have not been replayed.
+func (p *I2CReplay) AssertDone() {
+	if n := p.Pending(); n != 0 {
+		p.c.Fatalf("%d i2c operations not replayed, next: %v (line %d)", n, p.ops[p.pos], p.ops[p.pos].line)
+	}
+}
+
+// ReadRegister implements I2C.ReadRegister.
+func (p *I2CReplay) ReadRegister(addr uint8, r uint8, buf []byte) error {
+	return p.replay(I2COp{Kind: I2CRead, Addr: uint16(addr), Reg: r}, buf)
+}
+
+// WriteRegister implements I2C.WriteRegister.
+func (p *I2CReplay) WriteRegister(addr uint8, r uint8, buf []byte) error {
+	return p.replay(I2COp{Kind: I2CWrite, Addr: uint16(addr), Reg: r, W: buf}, nil)
+}
+
+// Tx implements I2C.Tx.
+func (p *I2CReplay) Tx(addr uint16, w, r []byte) error {
+	return p.replay(I2COp{Kind: I2CTx, Addr: addr, W: w}, r)
+}
+
+func (p *I2CReplay) replay(got I2COp, r []byte) error {
+	got.R = make([]byte, len(r))
+	if p.pos >= len(p.ops) {
+		p.c.Fatalf("unexpected i2c operation after end of trace: %v", got)
+		return nil
+	}
+	want := p.ops[p.pos]
+	p.pos++
+	if got.Kind != want.Kind || got.Addr != want.Addr || got.Reg != want.Reg ||
+		!bytes.Equal(got.W, want.W) || len(got.R) != len(want.R) {
+		p.c.Fatalf("i2c operation mismatch at trace line %d\nwant: %v\ngot:  %v", want.line, want, got)
+		return nil
+	}
+	copy(r, want.R)
+	switch want.Err {
+	case "":
+		return nil
+	case ErrNACK.Error():
+		return ErrNACK
+	case ErrBusFault.Error():
+		return ErrBusFault
+	}
+	return errors.New(want.Err)
+}
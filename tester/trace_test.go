@@ -0,0 +1,82 @@
+package tester
+
+import (
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestI2CRecordReplay(t *testing.T) {
+	c := qt.New(t)
+	bus := NewI2CBus(c)
+	dev := bus.NewDevice(0x39)
+	dev.Registers[0x92] = 0xab
+	bus.NACK(0x40)
+
+	var trace strings.Builder
+	rec := NewI2CRecorder(bus, &trace)
+	buf := make([]byte, 1)
+	c.Assert(rec.ReadRegister(0x39, 0x92, buf), qt.IsNil)
+	c.Assert(rec.WriteRegister(0x39, 0x80, []byte{0x05, 0x06}), qt.IsNil)
+	c.Assert(rec.Tx(0x40, []byte{0x01}, nil), qt.Equals, ErrNACK)
+	c.Assert(rec.Err, qt.IsNil)
+	c.Assert(trace.String(), qt.Equals, `read 0x39 reg=0x92 r=ab
+write 0x39 reg=0x80 w=0506
+tx 0x40 w=01 err="i2c: address not acknowledged"
+`)
+
+	ops, err := ParseI2CTrace(strings.NewReader("# captured on a feather-m4\n\n" + trace.String()))
+	c.Assert(err, qt.IsNil)
+	c.Assert(ops, qt.HasLen, 3)
+
+	replay := NewI2CReplay(c, ops)
+	buf[0] = 0
+	c.Assert(replay.ReadRegister(0x39, 0x92, buf), qt.IsNil)
+	c.Assert(buf, qt.DeepEquals, []byte{0xab})
+	c.Assert(replay.WriteRegister(0x39, 0x80, []byte{0x05, 0x06}), qt.IsNil)
+	c.Assert(replay.Tx(0x40, []byte{0x01}, nil), qt.Equals, ErrNACK)
+	replay.AssertDone()
+}
+
+func TestI2CReplayMismatch(t *testing.T) {
+	c := qt.New(t)
+	ops, err := ParseI2CTrace(strings.NewReader("tx 0x29 w=010f r=eacc\n"))
+	c.Assert(err, qt.IsNil)
+
+	f := &recordingFailer{}
+	replay := NewI2CReplay(f, ops)
+	replay.Tx(0x29, []byte{0x01, 0x10}, make([]byte, 2))
+	c.Assert(f.failures, qt.HasLen, 1)
+	c.Assert(f.failures[0], qt.Contains, "line 1")
+
+	f.failures = nil
+	replay.Tx(0x29, []byte{0x01, 0x0f}, make([]byte, 2))
+	c.Assert(f.failures, qt.HasLen, 1)
+	c.Assert(f.failures[0], qt.Contains, "after end of trace")
+}
+
+func TestI2CReplayNotDone(t *testing.T) {
+	c := qt.New(t)
+	ops, err := ParseI2CTrace(strings.NewReader("write 0x39 reg=0x80 w=00\n"))
+	c.Assert(err, qt.IsNil)
+
+	f := &recordingFailer{}
+	NewI2CReplay(f, ops).AssertDone()
+	c.Assert(f.failures, qt.HasLen, 1)
+}
+
+func TestParseI2CTraceErrors(t *testing.T) {
+	c := qt.New(t)
+	for _, text := range []string{
+		"read",
+		"poke 0x39",
+		"read 0x39 reg=0x100",
+		"write 0x39 reg=0x80 w=0",
+		"tx 0x29 x=00",
+		`tx 0x29 err="unterminated`,
+	} {
+		_, err := ParseI2CTrace(strings.NewReader("# comment\n" + text))
+		c.Assert(err, qt.ErrorMatches, "i2c trace line 2: .*", qt.Commentf("%s", text))
+	}
+}
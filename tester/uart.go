@@ -0,0 +1,173 @@
+package tester
+
+import "bytes"
+
+// UARTResponse is a canned reply sent by a mock UART when the code under
+// test writes a matching pattern.
+type UARTResponse struct {
+	// Pattern is the data that triggers the reply when it is written.
+	Pattern []byte
+	// Reply is the data made available for reading once Pattern is seen.
+	Reply []byte
+	// Delay is the number of polls (calls to Buffered, or to Read with
+	// nothing buffered) after which Reply becomes available.
+	Delay int
+	// Invocations is the number of times the pattern was seen.
+	Invocations int
+}
+
+// uartChunk is a piece of data queued for reading, which becomes available
+// after the given number of polls.
+type uartChunk struct {
+	delay int
+	data  []byte
+}
+
+// UART implements the UART interface in memory for testing.
+//
+// Data for the code under test is either injected directly with Inject, or
+// sent in reply to data written by the code under test using Respond. Gaps
+// in the incoming data are measured in polls of the UART: a gap of n polls
+// hides the data queued after it from the next n calls to Buffered, which
+// models a device that needs time to answer.
+type UART struct {
+	c Failer
+
+	// rx holds the data available for reading.
+	rx []byte
+	// queue holds data that is not available for reading yet.
+	queue []uartChunk
+
+	// unmatched holds the written data not yet matched by a response.
+	unmatched []byte
+	written   []byte
+
+	// Responses are the patterns the mock recognizes and replies to. When
+	// several patterns match, the one that ends first in the written data
+	// is used.
+	Responses []*UARTResponse
+
+	// If Err is non-nil, it will be returned as the error from the UART
+	// methods.
+	Err error
+}
+
+// NewUART returns a new mock UART that uses c to flag errors if they
+// happen.
+func NewUART(c Failer) *UART {
+	return &UART{
+		c: c,
+	}
+}
+
+// Respond adds a canned reply to send every time pattern is written.
+func (uart *UART) Respond(pattern, reply []byte) *UARTResponse {
+	r := &UARTResponse{
+		Pattern: pattern,
+		Reply:   reply,
+	}
+	uart.Responses = append(uart.Responses, r)
+	return r
+}
+
+// Inject queues unsolicited data for reading, such as a notification from a
+// modem or a burst of NMEA sentences.
+func (uart *UART) Inject(data []byte) {
+	uart.queue = append(uart.queue, uartChunk{data: data})
+}
+
+// Gap delays all data queued after it by the given number of polls.
+func (uart *UART) Gap(polls int) {
+	uart.queue = append(uart.queue, uartChunk{delay: polls})
+}
+
+// Written returns all data written to the UART so far.
+func (uart *UART) Written() []byte {
+	return uart.written
+}
+
+// Unread returns the number of bytes that are queued or buffered, but have
+// not been read by the code under test.
+func (uart *UART) Unread() int {
+	n := len(uart.rx)
+	for _, chunk := range uart.queue {
+		n += len(chunk.data)
+	}
+	return n
+}
+
+// AssertDone flags an error if some data has not been read.
+func (uart *UART) AssertDone() {
+	if n := uart.Unread(); n != 0 {
+		uart.c.Fatalf("%d bytes of uart data not read", n)
+	}
+}
+
+// Buffered implements UART.Buffered.
+func (uart *UART) Buffered() int {
+	uart.poll()
+	return len(uart.rx)
+}
+
+// Read implements UART.Read.
+func (uart *UART) Read(buf []byte) (int, error) {
+	if uart.Err != nil {
+		return 0, uart.Err
+	}
+	if len(uart.rx) == 0 {
+		uart.poll()
+	}
+	n := copy(buf, uart.rx)
+	uart.rx = uart.rx[n:]
+	return n, nil
+}
+
+// Write implements UART.Write.
+func (uart *UART) Write(buf []byte) (int, error) {
+	if uart.Err != nil {
+		return 0, uart.Err
+	}
+	uart.written = append(uart.written, buf...)
+	uart.unmatched = append(uart.unmatched, buf...)
+	uart.match()
+	return len(buf), nil
+}
+
+// poll makes the queued data available for reading, up to the next gap.
+func (uart *UART) poll() {
+	for len(uart.queue) > 0 {
+		chunk := &uart.queue[0]
+		if chunk.delay > 0 {
+			chunk.delay--
+			return
+		}
+		uart.rx = append(uart.rx, chunk.data...)
+		uart.queue = uart.queue[1:]
+	}
+}
+
+// match looks for response patterns in the unmatched written data and
+// queues their replies.
+func (uart *UART) match() {
+	for {
+		var found *UARTResponse
+		end := -1
+		for _, r := range uart.Responses {
+			if len(r.Pattern) == 0 {
+				continue
+			}
+			i := bytes.Index(uart.unmatched, r.Pattern)
+			if i >= 0 && (end < 0 || i+len(r.Pattern) < end) {
+				found = r
+				end = i + len(r.Pattern)
+			}
+		}
+		if found == nil {
+			return
+		}
+
+		found.Invocations++
+		uart.unmatched = uart.unmatched[end:]
+		uart.queue = append(uart.queue, uartChunk{delay: found.Delay, data: found.Reply})
+	}
+}
@@ -0,0 +1,85 @@
+package tester
+
+import (
+	"errors"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestUARTInject(t *testing.T) {
+	c := qt.New(t)
+	uart := NewUART(c)
+	uart.Inject([]byte("hello"))
+
+	c.Assert(uart.Buffered(), qt.Equals, 5)
+	buf := make([]byte, 3)
+	n, err := uart.Read(buf)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(buf[:n]), qt.Equals, "hel")
+	c.Assert(uart.Buffered(), qt.Equals, 2)
+	n, _ = uart.Read(buf)
+	c.Assert(string(buf[:n]), qt.Equals, "lo")
+	uart.AssertDone()
+}
+
+func TestUARTRespond(t *testing.T) {
+	c := qt.New(t)
+	uart := NewUART(c)
+	ok := uart.Respond([]byte("AT\r\n"), []byte("\r\nOK\r\n"))
+
+	c.Assert(uart.Buffered(), qt.Equals, 0)
+
+	// The pattern may be split over several writes.
+	uart.Write([]byte("A"))
+	uart.Write([]byte("T\r\nAT\r\n"))
+	c.Assert(ok.Invocations, qt.Equals, 2)
+	c.Assert(string(uart.Written()), qt.Equals, "AT\r\nAT\r\n")
+
+	buf := make([]byte, 32)
+	n, _ := uart.Read(buf)
+	c.Assert(string(buf[:n]), qt.Equals, "\r\nOK\r\n\r\nOK\r\n")
+}
+
+func TestUARTGap(t *testing.T) {
+	c := qt.New(t)
+	uart := NewUART(c)
+	uart.Inject([]byte("$GP"))
+	uart.Gap(2)
+	uart.Inject([]byte("GGA"))
+
+	c.Assert(uart.Buffered(), qt.Equals, 3)
+	c.Assert(uart.Buffered(), qt.Equals, 3)
+	c.Assert(uart.Buffered(), qt.Equals, 6)
+}
+
+func TestUARTDelayedResponse(t *testing.T) {
+	c := qt.New(t)
+	uart := NewUART(c)
+	uart.Respond([]byte("ping"), []byte("pong")).Delay = 1
+
+	uart.Write([]byte("ping"))
+	c.Assert(uart.Buffered(), qt.Equals, 0)
+	c.Assert(uart.Buffered(), qt.Equals, 4)
+}
+
+func TestUARTNotDone(t *testing.T) {
+	c := qt.New(t)
+	f := &recordingFailer{}
+	uart := NewUART(f)
+	uart.Inject([]byte("unread"))
+
+	uart.AssertDone()
+	c.Assert(f.failures, qt.HasLen, 1)
+}
+
+func TestUARTErr(t *testing.T) {
+	c := qt.New(t)
+	uart := NewUART(c)
+	uart.Err = errors.New("framing error")
+
+	_, err := uart.Write([]byte("AT"))
+	c.Assert(err, qt.Equals, uart.Err)
+	_, err = uart.Read(make([]byte, 1))
+	c.Assert(err, qt.Equals, uart.Err)
+}
@@ -5,19 +5,20 @@
 package tm1637
 
 import (
-	"machine"
 	"time"
+
+	"tinygo.org/x/drivers"
 )
 
 // Device wraps the pins of the TM1637.
 type Device struct {
-	clk        machine.Pin
-	dio        machine.Pin
+	clk        drivers.Pin
+	dio        drivers.Pin
 	brightness uint8
 }
 
 // New creates a new TM1637 device.
-func New(clk machine.Pin, dio machine.Pin, brightness uint8) Device {
+func New(clk drivers.Pin, dio drivers.Pin, brightness uint8) Device {
 	return Device{clk: clk, dio: dio, brightness: brightness}
 }
 
@@ -148,16 +149,16 @@ func delaytm() {
 	time.Sleep(time.Microsecond * time.Duration(TM1637_DELAY))
 }
 
-func pinMode(pin machine.Pin, mode bool) {
+func pinMode(pin drivers.Pin, mode bool) {
 	// TM1637 has internal pull-up resistors for both CLK and DIO pins.
 	// Set them to input mode will pull them high,
 	// and set them to output mode will pull them down
 	// (since we did so in the beginning.)
 	// The High()/Low() method don't work on some boards.
 	if mode {
-		pin.Configure(machine.PinConfig{Mode: machine.PinInput})
+		drivers.ConfigurePin(pin, drivers.PinInput)
 	} else {
-		pin.Configure(machine.PinConfig{Mode: machine.PinOutput})
+		drivers.ConfigurePin(pin, drivers.PinOutput)
 	}
 }
 
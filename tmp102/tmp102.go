@@ -45,6 +45,13 @@ func (d *Device) Connected() bool {
 
 }
 
+// Probe returns whether a TMP102 in its power-up configuration answers at addr.
+func Probe(bus drivers.I2C, addr uint16) bool {
+	d := New(bus)
+	d.address = uint8(addr)
+	return d.Connected()
+}
+
 // Reads the temperature from the sensor and returns it in celsius milli degrees (°C/1000).
 func (d *Device) ReadTemperature() (temperature int32, err error) {
 
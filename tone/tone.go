@@ -1,45 +1,27 @@
 package tone
 
-import (
-	"machine"
-)
-
-// PWM is the interface necessary for controlling a speaker.
-type PWM interface {
-	Configure(config machine.PWMConfig) error
-	Channel(pin machine.Pin) (channel uint8, err error)
-	Top() uint32
-	Set(channel uint8, value uint32)
-	SetPeriod(period uint64) error
-}
+import "tinygo.org/x/drivers"
 
 // Speaker is a configured audio output channel based on a PWM.
 type Speaker struct {
-	pwm PWM
-	ch  uint8
+	pwm drivers.PWM
 }
 
-// New returns a new Speaker instance readily configured for the given PWM and
-// pin combination. The lowest frequency possible is 27.5Hz, or A0. The audio
-// output uses a PWM so the audio will form a square wave, a sound that
-// generally sounds rather harsh.
-func New(pwm PWM, pin machine.Pin) (Speaker, error) {
-	err := pwm.Configure(machine.PWMConfig{
-		Period: uint64(1e9) / 55 / 2,
-	})
-	if err != nil {
-		return Speaker{}, err
-	}
-	ch, err := pwm.Channel(pin)
+// New returns a new Speaker instance readily configured for the given PWM
+// output, such as a channel of a PWM peripheral or of a PCA9685. The lowest
+// frequency possible is 27.5Hz, or A0. The audio output uses a PWM so the
+// audio will form a square wave, a sound that generally sounds rather harsh.
+func New(pwm drivers.PWM) (Speaker, error) {
+	err := pwm.SetPeriod(uint64(1e9) / 55 / 2)
 	if err != nil {
 		return Speaker{}, err
 	}
-	return Speaker{pwm, ch}, nil
+	return Speaker{pwm}, nil
 }
 
 // Stop disables the speaker, setting the output to low continuously.
-func (s Speaker) Stop() {
-	s.pwm.Set(s.ch, 0)
+func (s Speaker) Stop() error {
+	return s.pwm.Set(0)
 }
 
 // SetPeriod sets the period for the signal in nanoseconds. Use the following
@@ -48,26 +30,32 @@ func (s Speaker) Stop() {
 //     period = 1e9 / frequency
 //
 // You can also use s.SetNote() instead for MIDI note numbers.
-func (s Speaker) SetPeriod(period uint64) {
+func (s Speaker) SetPeriod(period uint64) error {
 	// Disable output.
-	s.Stop()
+	err := s.Stop()
+	if err != nil {
+		return err
+	}
 
 	if period == 0 {
 		// Assume a period of 0 is intended as "no output".
-		return
+		return nil
 	}
 
 	// Reconfigure period.
-	s.pwm.SetPeriod(period)
+	err = s.pwm.SetPeriod(period)
+	if err != nil {
+		return err
+	}
 
 	// Make this a square wave by setting the channel position to half the
 	// period.
-	s.pwm.Set(s.ch, s.pwm.Top()/2)
+	return s.pwm.Set(s.pwm.Top() / 2)
 }
 
 // SetNote starts playing the given note. For example, s.SetNote(C4) will
 // produce a 440Hz square wave tone.
-func (s Speaker) SetNote(note Note) {
+func (s Speaker) SetNote(note Note) error {
 	period := note.Period()
-	s.SetPeriod(period)
+	return s.SetPeriod(period)
 }
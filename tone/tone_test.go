@@ -0,0 +1,32 @@
+package tone
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"tinygo.org/x/drivers/tester"
+)
+
+func TestSetNote(t *testing.T) {
+	c := qt.New(t)
+	pwm := tester.NewPWM(1000)
+	s, err := New(pwm)
+	c.Assert(err, qt.IsNil)
+
+	c.Assert(s.SetNote(A4), qt.IsNil)
+	c.Assert(pwm.Period, qt.Equals, A4.Period())
+	c.Assert(pwm.Values, qt.DeepEquals, []uint32{0, 500})
+
+	// A zero period stops the output.
+	c.Assert(s.SetPeriod(0), qt.IsNil)
+	c.Assert(pwm.Value(), qt.Equals, uint32(0))
+}
+
+func TestPeriod(t *testing.T) {
+	c := qt.New(t)
+	// Each octave halves the period.
+	c.Assert(A5.Period(), qt.Equals, A4.Period()/2)
+	c.Assert(A6.Period(), qt.Equals, A4.Period()/4)
+	c.Assert(Note(0).Period(), qt.Equals, uint64(0))
+}
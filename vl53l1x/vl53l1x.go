@@ -10,12 +10,13 @@
 package vl53l1x // import "tinygo.org/x/drivers/vl53l1x"
 
 import (
-	"errors"
 	"time"
 
 	"tinygo.org/x/drivers"
 )
 
+var errROIOutOfRange = drivers.WrapError("ROI value out of range", drivers.ErrInvalidConfig)
+
 type DistanceMode uint8
 type RangeStatus uint8
 
@@ -39,6 +40,7 @@ type resultBuffer struct {
 type Device struct {
 	bus                drivers.I2C
 	Address            uint16
+	Clock              drivers.Clock
 	mode               DistanceMode
 	timeout            uint32
 	fastOscillatorFreq uint16
@@ -54,34 +56,53 @@ type Device struct {
 // configured.
 //
 // This function only creates the Device object, it does not touch the device.
+// The device waits and measures timeouts with drivers.SystemClock, unless
+// its Clock field is replaced. A nil Clock is drivers.SystemClock too.
 func New(bus drivers.I2C) Device {
 	return Device{
 		bus:     bus,
 		Address: Address,
+		Clock:   drivers.SystemClock,
 		mode:    LONG,
 		timeout: 500,
 	}
 }
 
+// clock returns the Clock of the device, or drivers.SystemClock for a
+// Device that was not created by New.
+func (d *Device) clock() drivers.Clock {
+	if d.Clock == nil {
+		return drivers.SystemClock
+	}
+	return d.Clock
+}
+
 // Connected returns whether a VL53L1X has been found.
 // It does a "who am I" request and checks the response.
 func (d *Device) Connected() bool {
 	return d.readReg16Bit(WHO_AM_I) == CHIP_ID
 }
 
+// Probe returns whether a VL53L1X answers at addr.
+func Probe(bus drivers.I2C, addr uint16) bool {
+	d := New(bus)
+	d.Address = addr
+	return d.Connected()
+}
+
 // Configure sets up the device for communication
 func (d *Device) Configure(use2v8Mode bool) bool {
 	if !d.Connected() {
 		return false
 	}
 	d.writeReg(SOFT_RESET, 0x00)
-	time.Sleep(100 * time.Microsecond)
+	d.clock().Sleep(100 * time.Microsecond)
 	d.writeReg(SOFT_RESET, 0x01)
-	time.Sleep(1 * time.Millisecond)
+	d.clock().Sleep(1 * time.Millisecond)
 
-	start := time.Now()
+	start := d.clock().Now()
 	for (d.readReg(FIRMWARE_SYSTEM_STATUS) & 0x01) == 0 {
-		elapsed := time.Since(start)
+		elapsed := d.clock().Now().Sub(start)
 		if d.timeout > 0 && uint32(elapsed.Seconds()*1000) > d.timeout {
 			return false
 		}
@@ -235,10 +256,10 @@ func (d *Device) SetMeasurementTimingBudget(budgetMicroseconds uint32) bool {
 // the current distance in mm
 func (d *Device) Read(blocking bool) uint16 {
 	if blocking {
-		start := time.Now()
+		start := d.clock().Now()
 
 		for !d.dataReady() {
-			elapsed := time.Since(start)
+			elapsed := d.clock().Now().Sub(start)
 			if d.timeout > 0 && uint32(elapsed.Seconds()*1000) > d.timeout {
 				d.rangingData.status = None
 				d.rangingData.mm = 0
@@ -421,7 +442,7 @@ func (d *Device) StopContinuous() {
 // SetROI sets the 'region of interest' for x and y coordinates. Valid ranges are from 4/4 to 16/16.
 func (d *Device) SetROI(x, y uint8) error {
 	if !validROIRange(x, y) {
-		return errors.New("ROI value out of range")
+		return errROIOutOfRange
 	}
 
 	if x > 10 || y > 10 {
@@ -440,7 +461,7 @@ func (d *Device) GetROI() (x, y uint8, err error) {
 	y = ((reg & 0xf0) >> 4) + 1
 
 	if !validROIRange(x, y) {
-		err = errors.New("ROI value out of range")
+		err = errROIOutOfRange
 	}
 
 	return
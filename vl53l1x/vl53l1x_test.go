@@ -0,0 +1,66 @@
+package vl53l1x
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"tinygo.org/x/drivers/tester"
+)
+
+func TestRanging(t *testing.T) {
+	c := qt.New(t)
+	bus := tester.LoadI2CReplay(c, "testdata/ranging.trace")
+
+	dev := New(bus)
+	c.Assert(dev.Configure(true), qt.IsTrue)
+	dev.StartContinuous(50)
+	c.Assert(dev.Read(true), qt.Equals, uint16(479))
+	c.Assert(dev.Status(), qt.Equals, RangeValid)
+	c.Assert(dev.SignalRate(), qt.Equals, int32(11000000))
+	c.Assert(dev.AmbientRate(), qt.Equals, int32(382812))
+	dev.StopContinuous()
+	bus.AssertDone()
+}
+
+// notReady is an I2C bus whose registers all read as 0x01, so the data of
+// the sensor is never ready.
+type notReady struct {
+	reads int
+}
+
+func (b *notReady) ReadRegister(addr uint8, r uint8, buf []byte) error { return nil }
+
+func (b *notReady) WriteRegister(addr uint8, r uint8, buf []byte) error { return nil }
+
+func (b *notReady) Tx(addr uint16, w, r []byte) error {
+	if len(r) > 0 {
+		b.reads++
+	}
+	for i := range r {
+		r[i] = 0x01
+	}
+	return nil
+}
+
+func TestReadTimeout(t *testing.T) {
+	c := qt.New(t)
+	bus := &notReady{}
+	clock := tester.NewClock()
+	clock.Step = time.Millisecond
+
+	dev := New(bus)
+	dev.Clock = clock
+	dev.SetTimeout(100)
+	c.Assert(dev.Read(true), qt.Equals, uint16(0))
+	c.Assert(dev.Status(), qt.Equals, None)
+	c.Assert(bus.reads, qt.Equals, 101)
+}
+
+func TestStructLiteral(t *testing.T) {
+	c := qt.New(t)
+	// A Device that was not created by New times out on the system clock.
+	dev := Device{bus: &notReady{}, Address: Address, timeout: 1}
+	c.Assert(dev.Read(true), qt.Equals, uint16(0))
+	c.Assert(dev.Status(), qt.Equals, None)
+}
@@ -7,7 +7,6 @@ package epd2in13 // import "tinygo.org/x/drivers/waveshare-epd/epd2in13"
 import (
 	"errors"
 	"image/color"
-	"machine"
 	"time"
 
 	"tinygo.org/x/drivers"
@@ -22,16 +21,17 @@ type Config struct {
 
 type Device struct {
 	bus          drivers.SPI
-	cs           machine.Pin
-	dc           machine.Pin
-	rst          machine.Pin
-	busy         machine.Pin
+	cs           drivers.Pin
+	dc           drivers.Pin
+	rst          drivers.Pin
+	busy         drivers.Pin
 	logicalWidth int16
 	width        int16
 	height       int16
 	buffer       []uint8
 	bufferLength uint32
 	rotation     Rotation
+	sleeping     bool
 }
 
 type Rotation uint8
@@ -53,11 +53,11 @@ var lutPartialUpdate = [30]uint8{
 }
 
 // New returns a new epd2in13x driver. Pass in a fully configured SPI bus.
-func New(bus drivers.SPI, csPin, dcPin, rstPin, busyPin machine.Pin) Device {
-	csPin.Configure(machine.PinConfig{Mode: machine.PinOutput})
-	dcPin.Configure(machine.PinConfig{Mode: machine.PinOutput})
-	rstPin.Configure(machine.PinConfig{Mode: machine.PinOutput})
-	busyPin.Configure(machine.PinConfig{Mode: machine.PinInput})
+func New(bus drivers.SPI, csPin, dcPin, rstPin, busyPin drivers.Pin) Device {
+	drivers.ConfigurePin(csPin, drivers.PinOutput)
+	drivers.ConfigurePin(dcPin, drivers.PinOutput)
+	drivers.ConfigurePin(rstPin, drivers.PinOutput)
+	drivers.ConfigurePin(busyPin, drivers.PinInput)
 	return Device{
 		bus:  bus,
 		cs:   csPin,
@@ -91,6 +91,11 @@ func (d *Device) Configure(cfg Config) {
 		d.buffer[i] = 0xFF
 	}
 
+	d.initDisplay()
+}
+
+// initDisplay resets the display and sends its initialization sequence.
+func (d *Device) initDisplay() {
 	d.cs.Low()
 	d.dc.Low()
 	d.rst.Low()
@@ -115,6 +120,7 @@ func (d *Device) Configure(cfg Config) {
 	d.SendData(0x03) // X increment; Y increment
 
 	d.SetLUT(true)
+	d.sleeping = false
 }
 
 // Reset resets the device
@@ -129,6 +135,27 @@ func (d *Device) Reset() {
 func (d *Device) DeepSleep() {
 	d.SendCommand(DEEP_SLEEP_MODE)
 	d.WaitUntilIdle()
+	d.sleeping = true
+}
+
+// Sleep puts the display into deepsleep. The image on the display is kept.
+// It implements drivers.Sleeper.
+func (d *Device) Sleep() error {
+	d.DeepSleep()
+	return nil
+}
+
+// Wake resets the display to bring it out of deepsleep, and initializes it
+// again. The buffer is kept, so the display can be refreshed with Display.
+// It implements drivers.Sleeper.
+func (d *Device) Wake() error {
+	d.initDisplay()
+	return nil
+}
+
+// Sleeping returns whether the display is in deepsleep.
+func (d *Device) Sleeping() bool {
+	return d.sleeping
 }
 
 // SendCommand sends a command to the display
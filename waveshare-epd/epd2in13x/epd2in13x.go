@@ -7,7 +7,6 @@ package epd2in13x // import "tinygo.org/x/drivers/waveshare-epd/epd2in13x"
 import (
 	"errors"
 	"image/color"
-	"machine"
 	"time"
 
 	"tinygo.org/x/drivers"
@@ -21,24 +20,25 @@ type Config struct {
 
 type Device struct {
 	bus          drivers.SPI
-	cs           machine.Pin
-	dc           machine.Pin
-	rst          machine.Pin
-	busy         machine.Pin
+	cs           drivers.Pin
+	dc           drivers.Pin
+	rst          drivers.Pin
+	busy         drivers.Pin
 	width        int16
 	height       int16
 	buffer       [][]uint8
 	bufferLength uint32
+	sleeping     bool
 }
 
 type Color uint8
 
 // New returns a new epd2in13x driver. Pass in a fully configured SPI bus.
-func New(bus drivers.SPI, csPin, dcPin, rstPin, busyPin machine.Pin) Device {
-	csPin.Configure(machine.PinConfig{Mode: machine.PinOutput})
-	dcPin.Configure(machine.PinConfig{Mode: machine.PinOutput})
-	rstPin.Configure(machine.PinConfig{Mode: machine.PinOutput})
-	busyPin.Configure(machine.PinConfig{Mode: machine.PinInput})
+func New(bus drivers.SPI, csPin, dcPin, rstPin, busyPin drivers.Pin) Device {
+	drivers.ConfigurePin(csPin, drivers.PinOutput)
+	drivers.ConfigurePin(dcPin, drivers.PinOutput)
+	drivers.ConfigurePin(rstPin, drivers.PinOutput)
+	drivers.ConfigurePin(busyPin, drivers.PinInput)
 	return Device{
 		bus:  bus,
 		cs:   csPin,
@@ -76,6 +76,11 @@ func (d *Device) Configure(cfg Config) {
 		}
 	}
 
+	d.initDisplay()
+}
+
+// initDisplay resets the display and sends its initialization sequence.
+func (d *Device) initDisplay() {
 	d.cs.Low()
 	d.dc.Low()
 	d.rst.Low()
@@ -96,6 +101,7 @@ func (d *Device) Configure(cfg Config) {
 	d.SendData(uint8(d.width))
 	d.SendData(0x00)
 	d.SendData(uint8(d.height))
+	d.sleeping = false
 }
 
 // Reset resets the device
@@ -112,6 +118,27 @@ func (d *Device) DeepSleep() {
 	d.WaitUntilIdle()
 	d.SendCommand(DEEP_SLEEP)
 	d.SendData(0xA5)
+	d.sleeping = true
+}
+
+// Sleep puts the display into deepsleep. The image on the display is kept.
+// It implements drivers.Sleeper.
+func (d *Device) Sleep() error {
+	d.DeepSleep()
+	return nil
+}
+
+// Wake resets the display to bring it out of deepsleep, and initializes it
+// again. The buffer is kept, so the display can be refreshed with Display.
+// It implements drivers.Sleeper.
+func (d *Device) Wake() error {
+	d.initDisplay()
+	return nil
+}
+
+// Sleeping returns whether the display is in deepsleep.
+func (d *Device) Sleeping() bool {
+	return d.sleeping
 }
 
 // SendCommand sends a command to the display
@@ -204,9 +231,9 @@ func (d *Device) SetDisplayRect(buffer [][]uint8, x int16, y int16, w int16, h i
 	d.SendCommand(PARTIAL_WINDOW)
 	d.SendData(uint8(x) & 0xF8)
 	d.SendData(((uint8(x) & 0xF8) + uint8(w) - 1) | 0x07)
-	d.SendData(uint8(y) >> 8)
+	d.SendData(uint8(y >> 8))
 	d.SendData(uint8(y) & 0xFF)
-	d.SendData(uint8(y+h-1) >> 8)
+	d.SendData(uint8((y + h - 1) >> 8))
 	d.SendData(uint8(y+h-1) & 0xFF)
 	d.SendData(0x01)
 	time.Sleep(2 * time.Millisecond)
@@ -241,9 +268,9 @@ func (d *Device) SetDisplayRectColor(buffer []uint8, x int16, y int16, w int16,
 	d.SendCommand(PARTIAL_WINDOW)
 	d.SendData(uint8(x) & 0xF8)
 	d.SendData(((uint8(x) & 0xF8) + uint8(w) - 1) | 0x07)
-	d.SendData(uint8(y) >> 8)
+	d.SendData(uint8(y >> 8))
 	d.SendData(uint8(y) & 0xFF)
-	d.SendData(uint8(y+h-1) >> 8)
+	d.SendData(uint8((y + h - 1) >> 8))
 	d.SendData(uint8(y+h-1) & 0xFF)
 	d.SendData(0x01)
 	time.Sleep(2 * time.Millisecond)
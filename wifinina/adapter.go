@@ -11,15 +11,15 @@ func (d *Device) ConnectToAccessPoint(ssid, pass string, timeout time.Duration)
 		return net.ErrWiFiMissingSSID
 	}
 
-	start := time.Now()
+	start := d.clock().Now()
 	d.SetPassphrase(ssid, pass)
 
-	for time.Since(start) < timeout {
+	for d.clock().Now().Sub(start) < timeout {
 		st, _ := d.GetConnectionStatus()
 		if st == StatusConnected {
 			return nil
 		}
-		time.Sleep(100 * time.Millisecond)
+		d.clock().Sleep(100 * time.Millisecond)
 	}
 
 	return net.ErrWiFiConnectTimeout
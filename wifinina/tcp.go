@@ -71,8 +71,8 @@ func (d *Device) connectSocket(addr, portStr string, mode uint8) error {
 	}
 
 	// FIXME: this 4 second timeout is simply mimicking the Arduino driver
-	start := time.Now()
-	for time.Since(start) < 4*time.Second {
+	start := d.clock().Now()
+	for d.clock().Now().Sub(start) < 4*time.Second {
 		connected, err := d.IsConnected()
 		if err != nil {
 			return err
@@ -80,7 +80,7 @@ func (d *Device) connectSocket(addr, portStr string, mode uint8) error {
 		if connected {
 			return nil
 		}
-		time.Sleep(1 * time.Millisecond)
+		d.clock().Sleep(1 * time.Millisecond)
 	}
 
 	return ErrConnectionTimeout
@@ -182,8 +182,6 @@ func (d *Device) Write(b []byte) (n int, err error) {
 		}
 		return len(b), nil
 	}
-
-	return len(b), nil
 }
 
 func (d *Device) ReadSocket(b []byte) (n int, err error) {
@@ -257,13 +255,13 @@ func (d *Device) stop() error {
 		return nil
 	}
 	d.StopClient(d.sock)
-	start := time.Now()
-	for time.Since(start) < 5*time.Second {
+	start := d.clock().Now()
+	for d.clock().Now().Sub(start) < 5*time.Second {
 		st, _ := d.status()
 		if st == TCPStateClosed {
 			break
 		}
-		time.Sleep(1 * time.Millisecond)
+		d.clock().Sleep(1 * time.Millisecond)
 	}
 	d.sock = NoSocketAvail
 	return nil
@@ -14,8 +14,6 @@ import (
 	"strings"
 	"time"
 
-	"machine"
-
 	"tinygo.org/x/drivers"
 	"tinygo.org/x/drivers/net"
 )
@@ -258,6 +256,16 @@ func (err Error) Error() string {
 	return "wifinina error: 0x" + hex.EncodeToString([]byte{uint8(err)})
 }
 
+// Is reports whether err matches target, so that the timeout errors match
+// drivers.ErrTimeout with errors.Is.
+func (err Error) Is(target error) bool {
+	switch err {
+	case ErrTimeoutChipReady, ErrTimeoutChipSelect, ErrConnectionTimeout:
+		return target == drivers.ErrTimeout
+	}
+	return false
+}
+
 // Cmd Struct Message */
 // ._______________________________________________________________________.
 // | START CMD | C/R  | CMD  | N.PARAM | PARAM LEN | PARAM  | .. | END CMD |
@@ -274,10 +282,14 @@ type command struct {
 
 type Device struct {
 	SPI   drivers.SPI
-	CS    machine.Pin
-	ACK   machine.Pin
-	GPIO0 machine.Pin
-	RESET machine.Pin
+	CS    drivers.Pin
+	ACK   drivers.Pin
+	GPIO0 drivers.Pin
+	RESET drivers.Pin
+
+	// Clock is used for the delays and timeouts. It is drivers.SystemClock
+	// if nil.
+	Clock drivers.Clock
 
 	buf   [64]byte
 	ssids [10]string
@@ -291,34 +303,44 @@ type Device struct {
 }
 
 // New returns a new Wifinina device.
-func New(bus drivers.SPI, csPin, ackPin, gpio0Pin, resetPin machine.Pin) *Device {
+func New(bus drivers.SPI, csPin, ackPin, gpio0Pin, resetPin drivers.Pin) *Device {
 	return &Device{
 		SPI:   bus,
 		CS:    csPin,
 		ACK:   ackPin,
 		GPIO0: gpio0Pin,
 		RESET: resetPin,
+		Clock: drivers.SystemClock,
+	}
+}
+
+// clock returns the Clock of the device, or drivers.SystemClock for a
+// Device that was not created by New.
+func (d *Device) clock() drivers.Clock {
+	if d.Clock == nil {
+		return drivers.SystemClock
 	}
+	return d.Clock
 }
 
 func (d *Device) Configure() {
 	net.UseDriver(d)
 	pinUseDevice(d)
 
-	d.CS.Configure(machine.PinConfig{Mode: machine.PinOutput})
-	d.ACK.Configure(machine.PinConfig{Mode: machine.PinInput})
-	d.RESET.Configure(machine.PinConfig{Mode: machine.PinOutput})
-	d.GPIO0.Configure(machine.PinConfig{Mode: machine.PinOutput})
+	drivers.ConfigurePin(d.CS, drivers.PinOutput)
+	drivers.ConfigurePin(d.ACK, drivers.PinInput)
+	drivers.ConfigurePin(d.RESET, drivers.PinOutput)
+	drivers.ConfigurePin(d.GPIO0, drivers.PinOutput)
 
 	d.GPIO0.High()
 	d.CS.High()
 	d.RESET.Low()
-	time.Sleep(1 * time.Millisecond)
+	d.clock().Sleep(1 * time.Millisecond)
 	d.RESET.High()
-	time.Sleep(1 * time.Millisecond)
+	d.clock().Sleep(1 * time.Millisecond)
 
 	d.GPIO0.Low()
-	d.GPIO0.Configure(machine.PinConfig{Mode: machine.PinInput})
+	drivers.ConfigurePin(d.GPIO0, drivers.PinInput)
 
 }
 
@@ -385,7 +407,7 @@ func (d *Device) CheckDataSent(sock uint8) (bool, error) {
 		if sent > 0 {
 			return true, nil
 		}
-		time.Sleep(100 * time.Microsecond)
+		d.clock().Sleep(100 * time.Microsecond)
 	}
 	return false, lastErr
 }
@@ -1041,12 +1063,12 @@ func (d *Device) waitForChipReady() error {
 	if _debug {
 		println("waitForChipReady()\r")
 	}
-	start := time.Now()
-	for time.Since(start) < 10*time.Second {
+	start := d.clock().Now()
+	for d.clock().Now().Sub(start) < 10*time.Second {
 		if !d.ACK.Get() {
 			return nil
 		}
-		time.Sleep(1 * time.Millisecond)
+		d.clock().Sleep(1 * time.Millisecond)
 	}
 	return ErrTimeoutChipReady
 }
@@ -1056,12 +1078,12 @@ func (d *Device) spiChipSelect() error {
 		println("spiChipSelect()\r")
 	}
 	d.CS.Low()
-	start := time.Now()
-	for time.Since(start) < 5*time.Millisecond {
+	start := d.clock().Now()
+	for d.clock().Now().Sub(start) < 5*time.Millisecond {
 		if d.ACK.Get() {
 			return nil
 		}
-		time.Sleep(100 * time.Microsecond)
+		d.clock().Sleep(100 * time.Microsecond)
 	}
 	return ErrTimeoutChipSelect
 }
@@ -0,0 +1,53 @@
+package wifinina
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"tinygo.org/x/drivers"
+	"tinygo.org/x/drivers/tester"
+)
+
+func newTestDevice(c *qt.C) (*Device, *tester.Pin, *tester.Clock) {
+	ack := tester.NewPin()
+	clock := tester.NewClock()
+	d := New(tester.NewSPIBus(c), tester.NewPin(), ack, tester.NewPin(), tester.NewPin())
+	d.Clock = clock
+	return d, ack, clock
+}
+
+func TestWaitForChipReady(t *testing.T) {
+	c := qt.New(t)
+	d, ack, clock := newTestDevice(c)
+
+	// The chip pulls ACK low once it is ready.
+	busy := true
+	ack.Input = func() bool { return busy }
+	clock.At(30*time.Millisecond, func() { busy = false })
+
+	c.Assert(d.waitForChipReady(), qt.IsNil)
+	c.Assert(clock.Elapsed(), qt.Equals, 30*time.Millisecond)
+}
+
+func TestWaitForChipReadyTimeout(t *testing.T) {
+	c := qt.New(t)
+	d, ack, clock := newTestDevice(c)
+	ack.Input = func() bool { return true }
+
+	err := d.waitForChipReady()
+	c.Assert(err, qt.Equals, ErrTimeoutChipReady)
+	c.Assert(errors.Is(err, drivers.ErrTimeout), qt.IsTrue)
+	c.Assert(clock.Elapsed(), qt.Equals, 10*time.Second)
+}
+
+func TestSPIChipSelectTimeout(t *testing.T) {
+	c := qt.New(t)
+	d, ack, clock := newTestDevice(c)
+	ack.Input = func() bool { return false }
+
+	c.Assert(d.spiChipSelect(), qt.Equals, ErrTimeoutChipSelect)
+	c.Assert(clock.Elapsed(), qt.Equals, 5*time.Millisecond)
+}
@@ -8,6 +8,8 @@ import (
 	"errors"
 	"image/color"
 	"machine"
+
+	"tinygo.org/x/drivers/pixel"
 )
 
 var errUnknownClockSpeed = errors.New("ws2812: unknown CPU clock speed")
@@ -15,12 +17,16 @@ var errUnknownClockSpeed = errors.New("ws2812: unknown CPU clock speed")
 // Device wraps a pin object for an easy driver interface.
 type Device struct {
 	Pin machine.Pin
+
+	// Gamma, if non-nil, corrects the colors sent by WriteColors, for
+	// example with pixel.Gamma28.
+	Gamma *pixel.GammaTable
 }
 
 // New returns a new WS2812 driver. It does not touch the pin object: you have
 // to configure it as an output pin before calling New.
 func New(pin machine.Pin) Device {
-	return Device{pin}
+	return Device{Pin: pin}
 }
 
 // Write the raw bitstring out using the WS2812 protocol.
@@ -34,10 +40,13 @@ func (d Device) Write(buf []byte) (n int, err error) {
 // Write the given color slice out using the WS2812 protocol.
 // Colors are sent out in the usual GRB format.
 func (d Device) WriteColors(buf []color.RGBA) error {
-	for _, color := range buf {
-		d.WriteByte(color.G) // green
-		d.WriteByte(color.R) // red
-		d.WriteByte(color.B) // blue
+	var grb [3]byte
+	for _, c := range buf {
+		if d.Gamma != nil {
+			c = d.Gamma.Correct(c)
+		}
+		pixel.GRB.Put(grb[:], c)
+		d.Write(grb[:])
 	}
 	return nil
 }
@@ -4,18 +4,18 @@
 package xpt2046
 
 import (
-	"machine"
 	"time"
 
+	"tinygo.org/x/drivers"
 	"tinygo.org/x/drivers/touch"
 )
 
 type Device struct {
-	t_clk  machine.Pin
-	t_cs   machine.Pin
-	t_din  machine.Pin
-	t_dout machine.Pin
-	t_irq  machine.Pin
+	t_clk  drivers.Pin
+	t_cs   drivers.Pin
+	t_din  drivers.Pin
+	t_dout drivers.Pin
+	t_irq  drivers.Pin
 
 	precision uint8
 }
@@ -24,7 +24,7 @@ type Config struct {
 	Precision uint8
 }
 
-func New(t_clk, t_cs, t_din, t_dout, t_irq machine.Pin) Device {
+func New(t_clk, t_cs, t_din, t_dout, t_irq drivers.Pin) Device {
 	return Device{
 		precision: 10,
 		t_clk:     t_clk,
@@ -43,12 +43,12 @@ func (d *Device) Configure(config *Config) error {
 		d.precision = config.Precision
 	}
 
-	d.t_clk.Configure(machine.PinConfig{Mode: machine.PinOutput})
-	d.t_cs.Configure(machine.PinConfig{Mode: machine.PinOutput})
-	d.t_din.Configure(machine.PinConfig{Mode: machine.PinOutput})
+	drivers.ConfigurePin(d.t_clk, drivers.PinOutput)
+	drivers.ConfigurePin(d.t_cs, drivers.PinOutput)
+	drivers.ConfigurePin(d.t_din, drivers.PinOutput)
 
-	d.t_dout.Configure(machine.PinConfig{Mode: machine.PinInput})
-	d.t_irq.Configure(machine.PinConfig{Mode: machine.PinInput})
+	drivers.ConfigurePin(d.t_dout, drivers.PinInput)
+	drivers.ConfigurePin(d.t_irq, drivers.PinInput)
 
 	d.t_clk.Low()
 	d.t_cs.High()
@@ -63,7 +63,7 @@ func busSleep() {
 	time.Sleep(5 * time.Nanosecond)
 }
 
-func pulseHigh(p machine.Pin) {
+func pulseHigh(p drivers.Pin) {
 	p.High()
 	busSleep()
 	p.Low()